@@ -0,0 +1,43 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dao
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type UserDao struct {
+	DB *sql.DB
+}
+
+func (d *UserDao) GetUserName(id int64) (string, error) {
+	var name string
+	err := d.DB.QueryRow("SELECT name FROM user WHERE id = ?", id).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return name, err
+}
+
+func RenameUser(db *sql.DB, id int64, name string) (bool, error) {
+	res, err := db.Exec("UPDATE user SET name = ? WHERE id = ?", name, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
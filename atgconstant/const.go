@@ -16,11 +16,13 @@
 package atgconstant
 
 import (
+	"fmt"
 	"go/build"
 	"go/types"
 	"math/rand"
 	"os"
 	"path"
+	"strings"
 	"time"
 )
 
@@ -88,6 +90,9 @@ const (
 	// random string length
 	RandomStringLen int = 5
 
+	// Possibility that the string which flows into a parser is malformed on purpose
+	MalformedStringRatio float64 = 0.2
+
 	// Preprocess Test Suite. It determine how many test case that we want to exist in the test suite
 	// Please take a look at the function: PreProcessTestSuite
 	PreProcessTestSuite = 1
@@ -120,14 +125,170 @@ const (
 	FunctionTemplate = "function_template"
 )
 
+// the parsers that a string parameter of the tested function flows into
+const (
+	StringSinkJSON   = "json"
+	StringSinkInt    = "int"
+	StringSinkFloat  = "float"
+	StringSinkBool   = "bool"
+	StringSinkURL    = "url"
+	StringSinkTime   = "time"
+	StringSinkRegexp = "regexp"
+)
+
 // mock type define
 const (
 	UseMockUnknown int = iota
+	// UseNoMock patches nothing. The test cases hold the plain stubs of the interfaces, the function fields and the
+	// function variables instead, whose calls are not recorded.
 	UseNoMock
 	UseMockitoMock
 	UseGoMonkeyMock
+	// UseInterfaceMock replaces the interface dependencies with the typed mocks instead of patching the functions
+	UseInterfaceMock
+)
+
+// assertion of the calls of the mocked functions
+const (
+	MockCallAssertArgs  = "args"
+	MockCallAssertCount = "count"
+	MockCallAssertNone  = "none"
+)
+
+// how the final suite checks the errors returned by the tested functions besides whether there is one.
+// The strategies are tried in order.
+const (
+	// ErrorAssertIs checks the sentinel error by errors.Is
+	ErrorAssertIs = "is"
+	// ErrorAssertAs checks the error type by errors.As
+	ErrorAssertAs = "as"
+	// ErrorAssertMessage compares the message of the error
+	ErrorAssertMessage = "message"
+	// ErrorAssertNone only checks whether there is an error
+	ErrorAssertNone = "none"
+)
+
+// how the cases which panic are kept, one case per panic site
+const (
+	// PanicTestExpect asserts the value and the type of the panic
+	PanicTestExpect = "expect"
+	// PanicTestReproduce keeps the case as the skipped reproducer of the bug
+	PanicTestReproduce = "reproduce"
+)
+
+// MaxRecordedMockCalls is the max number of the calls whose arguments are recorded for each mocked function.
+// The calls beyond it are only counted.
+const MaxRecordedMockCalls = 10
+
+// MaxMockOutputSeq is the max length of the return value sequence of each mocked function
+const MaxMockOutputSeq = 3
+
+// the seams which are replaced by the test instead of being patched
+const (
+	// SeamField is the interface or function field of the receiver, e.g. s.repo.Get(ctx)
+	SeamField = "field"
+	// SeamGlobal is the interface or function variable of the package, e.g. var now = time.Now
+	SeamGlobal = "global"
+	// ReceiverPlaceholder is replaced by the name of the receiver in the test
+	ReceiverPlaceholder = "__receiver__"
+)
+
+// MockCallMatcher decides how the final suite compares the calls of the mocked functions with the recorded ones.
+// By default the context arguments are ignored and the pointer arguments only need the same nil-ness.
+type MockCallMatcher struct {
+	Disabled      bool
+	CountOnly     bool
+	StrictContext bool
+	StrictPointer bool
+}
+
+// GlobalVar is the package variable which the tested function reads or writes.
+// The final suite snapshots it before each case and restores it after.
+type GlobalVar struct {
+	// the variable in the test file, e.g. dao.Counter
+	Code    string
+	PkgName string
+	PkgPath string
+	// the field of the test struct which holds the generated value, empty if the variable is only restored
+	Field string
+	Type  string
+}
+
+// Fault is the error injected into a result of the mocked callee. The middle code re-runs the tested function
+// with it after the random cases.
+type Fault struct {
+	// the mocked callee, the same as the name passed to mockfunc.MakeCall
+	Func string
+	// index of the error result
+	Result int
+	// the error in the test file, e.g. dao.ErrNotFound
+	Code    string
+	PkgName string
+	PkgPath string
+	// the error returned by the callee in the middle code
+	Err error
+}
+
+// String labels the test case which injects the fault
+func (f Fault) String() string {
+	return fmt.Sprintf("%s fails with %s", strings.TrimPrefix(f.Func, "&"), f.Code)
+}
+
+// The kinds of the results of the return shapes
+const (
+	// ResultAny is generated at random
+	ResultAny = "any"
+	// ResultNil is the nil pointer, slice, map, interface, etc.
+	ResultNil = "nil"
+	// ResultNonNil is generated at random but not nil, e.g. the error checked by err != nil
+	ResultNonNil = "non-nil"
+	// ResultConst is the constant or the sentinel error
+	ResultConst = "const"
+)
+
+// ReturnShapePercent is the percent of the return values of the mocked functions which take the return shapes,
+// the rest are generated at random
+const ReturnShapePercent = 90
+
+// ReturnShape is what a return statement of the mocked function returns, e.g. return nil, err after err != nil is
+// {nil, non-nil}. The return values of the mock take the shapes, so that it does not return what the function never
+// returns, e.g. the result with the error.
+type ReturnShape []ShapeResult
+
+// ShapeResult is a result of the return shape
+type ShapeResult struct {
+	Kind string
+	// the constant or the sentinel error in the test file, e.g. dao.ErrNotFound
+	Code    string
+	PkgName string
+	PkgPath string
+	// the value of the code in the middle code
+	Value interface{}
+}
+
+// The kinds of the mock policy rules
+const (
+	MockPolicyAlways = "always"
+	MockPolicyNever  = "never"
+	MockPolicyDepth  = "depth"
 )
 
+// MockPolicy decides which callees of the tested function are mocked. The direct callee is 1 call down.
+// The callees deeper than the depth are mocked and the ones within it run for real, so that their own callees
+// are checked in turn. The default depth 0 mocks the direct callees.
+type MockPolicy struct {
+	DefaultDepth int        `json:"default_depth"`
+	Rules        []MockRule `json:"rules"`
+}
+
+// MockRule is the policy of the packages which match the pattern, e.g. example.com/app/rpc/... or example.com/app/dao.
+// Mock is always, never or depth. The most specific pattern wins.
+type MockRule struct {
+	Pattern string `json:"pattern"`
+	Mock    string `json:"mock"`
+	Depth   int    `json:"depth,omitempty"`
+}
+
 type ImportInfo struct {
 	Name        string
 	PackagePath string
@@ -160,6 +321,43 @@ type Options struct {
 	RunForFinalSuite   bool
 	TemplateType       int
 	UseMockType        int
+	// generated dates are anchored around this unix time, 0 means time.Now()
+	ReferenceTime int64
+	// satisfy the interface params with the implementations of the module instead of the stubs
+	UseRealImplementation bool
+	// locale of the fake data, e.g. zh_CN, empty means the built-in English data
+	Locale string
+	// how the final suite asserts the calls of the mocked functions: args, count or none
+	MockCallAssertion string
+	// the context arguments of the mocked functions must not be nil
+	StrictContextArg bool
+	// the pointer arguments of the mocked functions are compared by the pointed values
+	StrictPointerArg bool
+	// serve the net/http client calls of the tested functions from an httptest server in the test
+	HTTPStandIn bool
+	// generate the *sql.DB inputs as the in-memory databases which return the rows and the driver errors
+	SQLStandIn bool
+	// serve the go-redis clients of the tested functions from the in-process redis stand-in
+	RedisStandIn bool
+	// patch the clock of the tested functions which read it with the fake clock, gomonkey only
+	FakeClock bool
+	// run the tested functions which read the environment or the files with the generated environment keys and files
+	// in a temporary working directory
+	Sandbox bool
+	// snapshot the package variables which the tested functions read or write before each case and restore them after.
+	// The variables of the basic types are generated as the inputs as well.
+	SnapshotGlobals bool
+	// which callees are mocked and which run for real, nil mocks the direct callees
+	MockPolicy *MockPolicy
+	// re-run the tested functions once per error result of each mocked callee with the error injected
+	FaultInjection bool
+	// the strategies by which the final suite checks the returned errors, e.g. is, as and message. empty only checks
+	// whether there is an error
+	ErrorAssertion []string
+	// the middle code re-runs each case this many times and ignores the result fields which differ between the runs
+	Reruns int
+	// keep one case per panic site of the tested functions, see PanicTestExpect. empty drops the cases which panic
+	PanicTests string
 }
 
 // ExecutionValues is used for the test suite
@@ -335,3 +335,11 @@ func TestRemoveGoComments(t *testing.T) {
 		})
 	}
 }
+
+func TestGetModulePath(t *testing.T) {
+	modulePath, err := GetModulePath("contexthelper")
+	assert.NilError(t, err)
+	assert.Equal(t, modulePath, "github.com/bytedance/nxt_unit")
+	_, err = GetModulePath("/")
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package contexthelper
+
+import (
+	"context"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+)
+
+type mockCallMatcherKey struct {
+}
+
+var MockCallMatcherKey = mockCallMatcherKey{}
+
+// SetMockCallMatcher sets how the final suite asserts the calls of the mocked functions
+func SetMockCallMatcher(ctx context.Context, matcher atgconstant.MockCallMatcher) context.Context {
+	return context.WithValue(ctx, MockCallMatcherKey, matcher)
+}
+
+func GetMockCallMatcher(ctx context.Context) (atgconstant.MockCallMatcher, bool) {
+	value := ctx.Value(MockCallMatcherKey)
+	matcher, ok := value.(atgconstant.MockCallMatcher)
+	if !ok {
+		return matcher, false
+	}
+	return matcher, true
+}
+
+type returnShapesKey struct {
+}
+
+var ReturnShapesKey = returnShapesKey{}
+
+// SetReturnShapes sets the return shapes of the mocked functions, keyed by the name passed to mockfunc.MakeCall
+func SetReturnShapes(ctx context.Context, shapes map[string][]atgconstant.ReturnShape) context.Context {
+	return context.WithValue(ctx, ReturnShapesKey, shapes)
+}
+
+func GetReturnShapes(ctx context.Context) (map[string][]atgconstant.ReturnShape, bool) {
+	value := ctx.Value(ReturnShapesKey)
+	shapes, ok := value.(map[string][]atgconstant.ReturnShape)
+	if !ok {
+		return nil, false
+	}
+	return shapes, true
+}
+
+type errorMatchKey struct {
+}
+
+var ErrorMatchKey = errorMatchKey{}
+
+// SetErrorMatch sets the functions whose final suite checks the returned errors beyond whether there is one
+func SetErrorMatch(ctx context.Context, funcNames []string) context.Context {
+	return context.WithValue(ctx, ErrorMatchKey, funcNames)
+}
+
+func GetErrorMatch(ctx context.Context) ([]string, bool) {
+	value := ctx.Value(ErrorMatchKey)
+	funcNames, ok := value.([]string)
+	if !ok {
+		return nil, false
+	}
+	return funcNames, true
+}
+
+type panicTestsKey struct {
+}
+
+var PanicTestsKey = panicTestsKey{}
+
+// SetPanicTests sets how the cases which panic are kept, see atgconstant.PanicTestExpect
+func SetPanicTests(ctx context.Context, mode string) context.Context {
+	return context.WithValue(ctx, PanicTestsKey, mode)
+}
+
+func GetPanicTests(ctx context.Context) (string, bool) {
+	value := ctx.Value(PanicTestsKey)
+	mode, ok := value.(string)
+	if !ok {
+		return "", false
+	}
+	return mode, true
+}
+
+type unstableKey struct {
+}
+
+var UnstableKey = unstableKey{}
+
+// SetUnstable sets the functions whose cases ignore the result fields which differ between the runs
+func SetUnstable(ctx context.Context, funcNames []string) context.Context {
+	return context.WithValue(ctx, UnstableKey, funcNames)
+}
+
+func GetUnstable(ctx context.Context) ([]string, bool) {
+	value := ctx.Value(UnstableKey)
+	funcNames, ok := value.([]string)
+	if !ok {
+		return nil, false
+	}
+	return funcNames, true
+}
+
+type typedMocksKey struct {
+}
+
+var TypedMocksKey = typedMocksKey{}
+
+// SetTypedMocks sets the functions whose final suite asserts the calls of the typed mocks in the inputs
+func SetTypedMocks(ctx context.Context, funcNames []string) context.Context {
+	return context.WithValue(ctx, TypedMocksKey, funcNames)
+}
+
+func GetTypedMocks(ctx context.Context) ([]string, bool) {
+	value := ctx.Value(TypedMocksKey)
+	funcNames, ok := value.([]string)
+	if !ok {
+		return nil, false
+	}
+	return funcNames, true
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package contexthelper
+
+import (
+	"context"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+)
+
+// StandIn names the feature of the final suite which the middle code turns on for some of the tested functions
+type StandIn string
+
+const (
+	// HTTPStandIn serves the net/http client calls from the httptest stand-in
+	HTTPStandIn StandIn = "http"
+	// SQLStandIn makes the *sql.DB inputs the in-memory stand-ins
+	SQLStandIn StandIn = "sql"
+	// RedisStandIn serves the go-redis clients from the redis stand-in
+	RedisStandIn StandIn = "redis"
+	// FakeClock patches the clock
+	FakeClock StandIn = "clock"
+	// Sandbox runs the cases in the sandbox
+	Sandbox StandIn = "sandbox"
+)
+
+type standInKey struct {
+	standIn StandIn
+}
+
+// SetStandIn sets the full names of the functions whose final suite uses the stand-in
+func SetStandIn(ctx context.Context, standIn StandIn, funcs []string) context.Context {
+	return context.WithValue(ctx, standInKey{standIn: standIn}, funcs)
+}
+
+func GetStandIn(ctx context.Context, standIn StandIn) ([]string, bool) {
+	value := ctx.Value(standInKey{standIn: standIn})
+	funcs, ok := value.([]string)
+	if !ok {
+		return nil, false
+	}
+	return funcs, true
+}
+
+type globalSnapshotKey struct {
+}
+
+var GlobalSnapshotKey = globalSnapshotKey{}
+
+// SetGlobalSnapshot sets the package variables which the final suite of each function snapshots, keyed by the full name
+func SetGlobalSnapshot(ctx context.Context, globals map[string][]atgconstant.GlobalVar) context.Context {
+	return context.WithValue(ctx, GlobalSnapshotKey, globals)
+}
+
+func GetGlobalSnapshot(ctx context.Context) (map[string][]atgconstant.GlobalVar, bool) {
+	value := ctx.Value(GlobalSnapshotKey)
+	globals, ok := value.(map[string][]atgconstant.GlobalVar)
+	if !ok {
+		return nil, false
+	}
+	return globals, true
+}
+
+type notIsolatedKey struct {
+}
+
+var NotIsolatedKey = notIsolatedKey{}
+
+// SetNotIsolated sets the calls which can not be isolated without patching, keyed by the full name of the function
+func SetNotIsolated(ctx context.Context, report map[string][]string) context.Context {
+	return context.WithValue(ctx, NotIsolatedKey, report)
+}
+
+func GetNotIsolated(ctx context.Context) (map[string][]string, bool) {
+	value := ctx.Value(NotIsolatedKey)
+	report, ok := value.(map[string][]string)
+	if !ok {
+		return nil, false
+	}
+	return report, true
+}
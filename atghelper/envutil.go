@@ -16,9 +16,14 @@
 package atghelper
 
 import (
+	"bufio"
 	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"os/exec"
 
@@ -75,3 +80,30 @@ func GetGoVersion(path string) (GoVersionInfo, error) {
 func GetUseMockByVersion(path string) int {
 	return atgconstant.UseMockitoMock
 }
+
+// GetModulePath returns the module path declared in the nearest go.mod of dir
+func GetModulePath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		file, err := os.Open(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module") {
+					return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`), nil
+				}
+			}
+			return "", fmt.Errorf("no module directive in %v", file.Name())
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("cannot find go.mod from %v", dir)
+		}
+		dir = parent
+	}
+}
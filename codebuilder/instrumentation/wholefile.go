@@ -415,7 +415,9 @@ func (c *CoverFile) defineCoverageTrace() string {
 	workPipe := fmt.Sprint("WorkPipe", c.Uid)
 	coverMap := fmt.Sprint("HitSet", c.Uid)
 	linesV := fmt.Sprint("Lines", c.Uid)
-	return fmt.Sprintf("\n const %v", fmt.Sprint(linesV, " = \"astLineTag\";var ", coverMap, "= [\"astLineTag\"]uint32{};", "type ", coverInfoV, " struct {PathID string;Coverage float64;ReturnString string;FunctionName string;Uid string;IsStart string;ReceiverName string};var ", workPipe, " = make(chan ", coverInfoV, ",10000);\n"))
+	// the middle code sets the hook to keep the panics of the tested functions
+	panicHook := fmt.Sprint("PanicHook", c.Uid)
+	return fmt.Sprintf("\n const %v", fmt.Sprint(linesV, " = \"astLineTag\";var ", coverMap, "= [\"astLineTag\"]uint32{};", "type ", coverInfoV, " struct {PathID string;Coverage float64;ReturnString string;FunctionName string;Uid string;IsStart string;ReceiverName string};var ", workPipe, " = make(chan ", coverInfoV, ",10000);var ", panicHook, " = func(string, interface{}, []byte) {};\n"))
 }
 
 func GetOriginExprV2(c *CoverFile, node ast.Node) []byte {
@@ -440,9 +442,15 @@ func (c *CoverFile) startCoverageTrace(funcName, fileName, isStart, receiverName
 	coverInfoV := fmt.Sprint("CoverInfoSU", c.Uid)
 	coverInfoVStatment := fmt.Sprintf("CoverInfoSU%s{FunctionName: \"%s\", Uid: \"%s\",IsStart: \"%s\",ReceiverName: \"%s\"}", c.Uid, funcName, c.Uid, isStart, receiverName)
 	workPipe := fmt.Sprint("WorkPipe", c.Uid)
+	// the panic is kept by the same name as the cases of the function in the middle code
+	declFuncName := receiverName + funcName
+	if isStart != "" {
+		declFuncName = "*" + declFuncName
+	}
 	return fmt.Sprint(branchVectorV, " := map[string]int{};hitCommit := []func(){};var ", rV, " ", coverInfoV, " = ", coverInfoVStatment,
 		";defer func() {if err := recover();err!= nil{", rV, ".Coverage = -1;",
 		"fmt.Println(fmt.Sprintf(\"panic:(tested_func:"+funcName+"#"+fileName+"#%v#%v)-c\", err, string(smartunit_debug.Stack())));",
+		"PanicHook", c.Uid, "(\"", declFuncName, "\", err, smartunit_debug.Stack());",
 		"};", "var bvs []string;for k := range ", branchVectorV, " {bvs = append(bvs, k)};for _, h := range hitCommit{h()};smartunit_sort.Strings(bvs);",
 		rV, ".PathID = smartunit_strings.Join(bvs,\"#\");", workPipe, " <- ", rV, "}();")
 }
@@ -0,0 +1,321 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/smartunitvariablebuild"
+)
+
+type anyArg struct{}
+
+// Any matches any argument of the mocked function
+var Any = anyArg{}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+func isContext(t reflect.Type) bool {
+	return t != nil && t.Implements(contextType)
+}
+
+// ExpectedCalls is the call count and the arguments of the first calls of the mocked function,
+// which are recorded by the middle code.
+type ExpectedCalls struct {
+	Count int
+	Args  [][]interface{}
+}
+
+// CallRecorder records the calls of the functions patched by the final suite
+type CallRecorder struct {
+	lock  sync.Mutex
+	calls map[string][][]reflect.Value
+}
+
+func NewCallRecorder() *CallRecorder {
+	return &CallRecorder{calls: make(map[string][][]reflect.Value, 0)}
+}
+
+// Return makes the function of the same type with m. The function records its arguments, and its n-th call returns
+// the n-th outputs. The calls beyond the outputs return the last ones, and the nil output becomes the zero value.
+func (r *CallRecorder) Return(funcName string, m interface{}, outputs ...[]interface{}) interface{} {
+	function := reflect.TypeOf(m)
+	if function.Kind() != reflect.Func {
+		panic(fmt.Sprintf("%v is not a function", funcName))
+	}
+	outs := make([][]reflect.Value, 0, len(outputs))
+	for _, output := range outputs {
+		outs = append(outs, makeReturn(funcName, function, output))
+	}
+	if len(outs) == 0 {
+		outs = append(outs, makeReturn(funcName, function, make([]interface{}, function.NumOut())))
+	}
+	r.lock.Lock()
+	if _, ok := r.calls[funcName]; !ok {
+		r.calls[funcName] = make([][]reflect.Value, 0)
+	}
+	r.lock.Unlock()
+	return reflect.MakeFunc(function, func(args []reflect.Value) []reflect.Value {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		index := len(r.calls[funcName])
+		if index >= len(outs) {
+			index = len(outs) - 1
+		}
+		r.calls[funcName] = append(r.calls[funcName], args)
+		return outs[index]
+	}).Interface()
+}
+
+func makeReturn(funcName string, function reflect.Type, output []interface{}) []reflect.Value {
+	if function.NumOut() != len(output) {
+		panic(fmt.Sprintf("%v has %d results but gets %d outputs", funcName, function.NumOut(), len(output)))
+	}
+	out := make([]reflect.Value, 0, len(output))
+	for i, value := range output {
+		resultType := function.Out(i)
+		v := reflect.ValueOf(value)
+		switch {
+		case value == nil:
+			v = reflect.Zero(resultType)
+		case v.Type().AssignableTo(resultType):
+		case v.Type().ConvertibleTo(resultType):
+			v = v.Convert(resultType)
+		default:
+			panic(fmt.Sprintf("the output %d of %v is %v, not %v", i, funcName, v.Type(), resultType))
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// CallCount returns how many times the patched function is called
+func (r *CallRecorder) CallCount(funcName string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.calls[funcName])
+}
+
+// ObserveMocks adds the calls of the typed mocks in the values to the recorder, so that DiffCalls checks them
+// like the calls of the patched functions. The calls are keyed by the mock and the method, e.g. MockStoreForLoad.Get.
+func (r *CallRecorder) ObserveMocks(values ...interface{}) *CallRecorder {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, mock := range smartunitvariablebuild.FindMocks(values...) {
+		for _, call := range mock.MockRecorder().AllCalls() {
+			funcName := smartunitvariablebuild.MockName(mock, call.Method)
+			r.calls[funcName] = append(r.calls[funcName], mockArgs(call.Args))
+		}
+	}
+	return r
+}
+
+func mockArgs(args []interface{}) []reflect.Value {
+	values := make([]reflect.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, reflect.ValueOf(arg))
+	}
+	return values
+}
+
+// ResetMocks forgets the calls of the typed mocks in the values, e.g. before the case runs again
+func ResetMocks(values ...interface{}) {
+	for _, mock := range smartunitvariablebuild.FindMocks(values...) {
+		mock.MockRecorder().Reset()
+	}
+}
+
+// DiffCalls compares the calls of the patched functions with the expected ones and returns the differences.
+// The function which is patched but not expected should not be called, and the expected one should be.
+func DiffCalls(want map[string]ExpectedCalls, r *CallRecorder, matcher atgconstant.MockCallMatcher) []string {
+	diffs := make([]string, 0)
+	if matcher.Disabled || r == nil {
+		return diffs
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	funcNames := make([]string, 0, len(r.calls))
+	for funcName := range r.calls {
+		funcNames = append(funcNames, funcName)
+	}
+	// the typed mocks only have the methods which are called
+	for funcName := range want {
+		if _, ok := r.calls[funcName]; !ok {
+			funcNames = append(funcNames, funcName)
+		}
+	}
+	sort.Strings(funcNames)
+	for _, funcName := range funcNames {
+		calls := r.calls[funcName]
+		expected := want[funcName]
+		if len(calls) != expected.Count {
+			diffs = append(diffs, fmt.Sprintf("%s is called %d times, want %d", funcName, len(calls), expected.Count))
+			continue
+		}
+		if matcher.CountOnly {
+			continue
+		}
+		for i, wantArgs := range expected.Args {
+			if i >= len(calls) {
+				break
+			}
+			if len(wantArgs) != len(calls[i]) {
+				diffs = append(diffs, fmt.Sprintf("call %d of %s has %d arguments, want %d", i, funcName, len(calls[i]), len(wantArgs)))
+				continue
+			}
+			for j, wantArg := range wantArgs {
+				if !matchArg(reflect.ValueOf(wantArg), calls[i][j], matcher) {
+					diffs = append(diffs, fmt.Sprintf("call %d of %s has argument %d %v, want %v", i, funcName, j, formatArg(calls[i][j]), wantArg))
+				}
+			}
+		}
+	}
+	return diffs
+}
+
+func formatArg(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return fmt.Sprintf("&%+v", v.Elem().Interface())
+	}
+	return fmt.Sprintf("%+v", v.Interface())
+}
+
+func isNilValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	if isNilValue(v) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// matchArg reports whether the actual argument got matches the recorded one.
+// The context, function and channel arguments are not compared by default.
+func matchArg(want, got reflect.Value, matcher atgconstant.MockCallMatcher) bool {
+	if got.IsValid() && isContext(got.Type()) {
+		return !matcher.StrictContext || !got.IsNil()
+	}
+	if want.IsValid() && want.Type() == reflect.TypeOf(Any) {
+		return true
+	}
+	if got.IsValid() && got.Kind() == reflect.Interface {
+		// the interface values which cannot be rendered are recorded as nil
+		if want.IsValid() && want.Kind() == reflect.Interface && want.IsNil() {
+			return true
+		}
+		got = got.Elem()
+	}
+	if want.IsValid() && want.Kind() == reflect.Interface {
+		want = want.Elem()
+	}
+	if isNilValue(want) || isNilValue(got) {
+		// the nil slice is the same with the empty one
+		return isEmptyValue(want) && isEmptyValue(got)
+	}
+	switch got.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return true
+	case reflect.Ptr:
+		if !matcher.StrictPointer {
+			return true
+		}
+		if want.Kind() != reflect.Ptr {
+			return false
+		}
+		return matchArg(want.Elem(), got.Elem(), matcher)
+	case reflect.Struct:
+		if want.Type() != got.Type() {
+			return false
+		}
+		for i := 0; i < got.NumField(); i++ {
+			// the unexported fields are not rendered
+			if got.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if !matchArg(want.Field(i), got.Field(i), matcher) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if want.Kind() != reflect.Slice && want.Kind() != reflect.Array || want.Len() != got.Len() {
+			return false
+		}
+		for i := 0; i < got.Len(); i++ {
+			if !matchArg(want.Index(i), got.Index(i), matcher) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if want.Kind() != reflect.Map || want.Len() != got.Len() || !want.Type().Key().ConvertibleTo(got.Type().Key()) {
+			return false
+		}
+		iter := want.MapRange()
+		for iter.Next() {
+			value := got.MapIndex(iter.Key().Convert(got.Type().Key()))
+			if !value.IsValid() || !matchArg(iter.Value(), value, matcher) {
+				return false
+			}
+		}
+		return true
+	}
+	if sameKindClass(want.Kind(), got.Kind()) {
+		return reflect.DeepEqual(want.Convert(got.Type()).Interface(), got.Interface())
+	}
+	return reflect.DeepEqual(want.Interface(), got.Interface())
+}
+
+func sameKindClass(a, b reflect.Kind) bool {
+	return kindClass(a) != "" && kindClass(a) == kindClass(b)
+}
+
+func kindClass(k reflect.Kind) string {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Complex64, reflect.Complex128:
+		return "complex"
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/bytedance/nxt_unit/smartunitvariablebuild"
+	"github.com/stretchr/testify/assert"
+)
+
+type callArg struct {
+	Name  string
+	Inner *callArg
+	inner int
+}
+
+func save(ctx context.Context, key string, value *callArg, tags []string) (int64, error) {
+	return 0, nil
+}
+
+func TestCallRecorderReturn(t *testing.T) {
+	r := NewCallRecorder()
+	f := r.Return("save", save, []interface{}{0, errors.New("retry")}, []interface{}{3, nil}).(func(context.Context, string, *callArg, []string) (int64, error))
+	n, err := f(context.Background(), "k", nil, nil)
+	assert.Equal(t, int64(0), n)
+	assert.EqualError(t, err, "retry")
+	// the calls beyond the outputs return the last ones
+	for i := 0; i < 2; i++ {
+		n, err = f(context.Background(), "k", nil, nil)
+		assert.Equal(t, int64(3), n)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 3, r.CallCount("save"))
+	assert.Equal(t, 0, r.CallCount("load"))
+
+	f = r.Return("load", save).(func(context.Context, string, *callArg, []string) (int64, error))
+	n, err = f(context.Background(), "k", nil, nil)
+	assert.Equal(t, int64(0), n)
+	assert.Nil(t, err)
+}
+
+func TestDiffCalls(t *testing.T) {
+	r := NewCallRecorder()
+	f := r.Return("save", save, []interface{}{0, errors.New("save fails")}).(func(context.Context, string, *callArg, []string) (int64, error))
+	_, _ = f(context.Background(), "k", &callArg{Name: "a", Inner: &callArg{Name: "b"}, inner: 1}, []string{})
+
+	// the context is ignored, the pointer only needs the same nil-ness and the empty slice equals the nil one
+	want := map[string]ExpectedCalls{"save": {Count: 1, Args: [][]interface{}{{Any, "k", &callArg{Name: "c"}, nil}}}}
+	assert.Empty(t, DiffCalls(want, r, atgconstant.MockCallMatcher{}))
+	assert.NotEmpty(t, DiffCalls(want, r, atgconstant.MockCallMatcher{StrictPointer: true}))
+
+	want = map[string]ExpectedCalls{"save": {Count: 1, Args: [][]interface{}{{nil, "k", &callArg{Name: "a", Inner: &callArg{Name: "b"}}, nil}}}}
+	assert.Empty(t, DiffCalls(want, r, atgconstant.MockCallMatcher{StrictPointer: true, StrictContext: true}))
+
+	want = map[string]ExpectedCalls{"save": {Count: 1, Args: [][]interface{}{{nil, "other", nil, nil}}}}
+	assert.Len(t, DiffCalls(want, r, atgconstant.MockCallMatcher{}), 2)
+	assert.Empty(t, DiffCalls(want, r, atgconstant.MockCallMatcher{CountOnly: true}))
+	assert.Empty(t, DiffCalls(want, r, atgconstant.MockCallMatcher{Disabled: true}))
+
+	// the patched function which is not expected should not be called
+	assert.Equal(t, []string{"save is called 1 times, want 0"}, DiffCalls(nil, r, atgconstant.MockCallMatcher{}))
+}
+
+func TestDiffCallsStrictContext(t *testing.T) {
+	r := NewCallRecorder()
+	f := r.Return("save", save, []interface{}{0, nil}).(func(context.Context, string, *callArg, []string) (int64, error))
+	_, _ = f(nil, "k", nil, []string{"a"})
+	want := map[string]ExpectedCalls{"save": {Count: 1, Args: [][]interface{}{{Any, "k", nil, []string{"a"}}}}}
+	assert.Empty(t, DiffCalls(want, r, atgconstant.MockCallMatcher{}))
+	assert.Len(t, DiffCalls(want, r, atgconstant.MockCallMatcher{StrictContext: true}), 1)
+}
+
+func TestStatementRenderRecordCall(t *testing.T) {
+	atgconstant.PkgRelativePath = "smart unit"
+	duplicatepackagemanager.Init()
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	mockRender := &StatementRender{UsedMockFunc: map[string]int{"save": 2}}
+	var c context.Context = context.Background()
+	args := []reflect.Value{reflect.ValueOf(&c).Elem(), reflect.ValueOf("k"), reflect.ValueOf((*callArg)(nil)), reflect.ValueOf([]string{"a"})}
+	for i := 0; i < atgconstant.MaxRecordedMockCalls+2; i++ {
+		mockRender.RecordCall(ctx, "save", args)
+	}
+	// the function which is not patched by the final suite is not recorded
+	mockRender.RecordCall(ctx, "load", args)
+
+	assert.Len(t, mockRender.MockCalls, 1)
+	call := mockRender.MockCalls["save"]
+	assert.Equal(t, atgconstant.MaxRecordedMockCalls+2, call.Count)
+	assert.Len(t, call.Args, atgconstant.MaxRecordedMockCalls)
+	assert.Equal(t, "mockfunc.Any, \"k\", nil, []string{\"a\",\n}", call.Args[0])
+}
+
+type MockStoreForCalls struct {
+	GetResult0 string
+	recorder   smartunitvariablebuild.MockRecorder
+}
+
+func (s *MockStoreForCalls) Get(key string) string {
+	s.recorder.Record("Get", key)
+	return s.GetResult0
+}
+
+func (s *MockStoreForCalls) MockRecorder() *smartunitvariablebuild.MockRecorder {
+	return &s.recorder
+}
+
+func TestTypedMockCalls(t *testing.T) {
+	atgconstant.PkgRelativePath = "smart unit"
+	duplicatepackagemanager.Init()
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	type Args struct {
+		Store interface{ Get(string) string }
+	}
+	type test struct {
+		Args Args
+	}
+	tt := test{Args: Args{Store: &MockStoreForCalls{}}}
+	tt.Args.Store.Get("a")
+
+	// the middle code records the calls of the mocks in the case
+	mockRender := &StatementRender{}
+	mockRender.RecordMocks(ctx, tt)
+	assert.Equal(t, 1, mockRender.MockCalls["MockStoreForCalls.Get"].Count)
+	assert.Equal(t, []string{"\"a\""}, mockRender.MockCalls["MockStoreForCalls.Get"].Args)
+
+	// the final suite checks them like the calls of the patches
+	want := map[string]ExpectedCalls{"MockStoreForCalls.Get": {Count: 1, Args: [][]interface{}{{"a"}}}}
+	assert.Empty(t, DiffCalls(want, NewCallRecorder().ObserveMocks(tt), atgconstant.MockCallMatcher{}))
+	want = map[string]ExpectedCalls{"MockStoreForCalls.Get": {Count: 1, Args: [][]interface{}{{"b"}}}}
+	assert.Len(t, DiffCalls(want, NewCallRecorder().ObserveMocks(tt), atgconstant.MockCallMatcher{}), 1)
+
+	ResetMocks(tt)
+	assert.Equal(t, []string{"MockStoreForCalls.Get is called 0 times, want 1"},
+		DiffCalls(want, NewCallRecorder().ObserveMocks(tt), atgconstant.MockCallMatcher{}))
+}
+
+func load(key string) (int, error) {
+	return 0, nil
+}
+
+func TestMakeCallOutputSeq(t *testing.T) {
+	atgconstant.PkgRelativePath = "smart unit"
+	duplicatepackagemanager.Init()
+	for i := 0; i < 20; i++ {
+		mockRender := &StatementRender{UsedMockFunc: map[string]int{}}
+		f := MakeCall(context.Background(), "load", mockRender, load, atgconstant.UseGoMonkeyMock).(func(string) (int, error))
+		assert.Len(t, mockRender.MonkeyOutputMap["load"], 1)
+		results := make([]int, 0)
+		for j := 0; j < atgconstant.MaxMockOutputSeq+1; j++ {
+			n, _ := f("k")
+			results = append(results, n)
+		}
+		outputs := mockRender.MonkeyOutputMap["load"]
+		assert.True(t, len(outputs) >= 1 && len(outputs) <= atgconstant.MaxMockOutputSeq)
+		// the calls beyond the sequence return the last values
+		assert.Equal(t, results[len(outputs)-1], results[len(results)-1])
+		assert.Equal(t, atgconstant.MaxMockOutputSeq+1, mockRender.MockCalls["load"].Count)
+	}
+}
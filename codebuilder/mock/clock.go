@@ -0,0 +1,124 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	gomonkeyv2 "github.com/agiledragon/gomonkey/v2"
+	"github.com/bytedance/nxt_unit/faker"
+)
+
+// clockSteps are how far the clock moves at each reading, 0 means it is frozen
+var clockSteps = []time.Duration{0, time.Millisecond, time.Second}
+
+// ClockSetting is the instant which the fake clock starts at and how far it moves at each reading.
+// They are written in RFC3339 and the duration format, e.g. {Start: "2023-05-01T10:00:00Z", Step: "1s"}.
+type ClockSetting struct {
+	Start string
+	Step  string
+}
+
+// ValueToCode renders the setting in the test suite
+func (c ClockSetting) ValueToCode() string {
+	return fmt.Sprintf("mockfunc.ClockSetting{Start: %q, Step: %q}", c.Start, c.Step)
+}
+
+// RandomClock returns the setting which starts at the reference time of the faker, and is frozen or steps
+func RandomClock() ClockSetting {
+	return ClockSetting{
+		Start: faker.ReferenceTime().Truncate(time.Second).UTC().Format(time.RFC3339Nano),
+		Step:  clockSteps[rand.Intn(len(clockSteps))].String(),
+	}
+}
+
+// clockLock makes the fake clocks take turns, because they patch the same functions of the process.
+// The middle code runs the other functions while holding the read lock, so that they never read a fake clock.
+// The final suite runs the functions one by one, so only the fake clocks take the lock there.
+var clockLock sync.RWMutex
+
+// UseRealClock keeps the fake clocks away until release is called
+func UseRealClock() (release func()) {
+	clockLock.RLock()
+	return clockLock.RUnlock
+}
+
+// Clock is the fake clock which takes the place of time.Now, time.Since, time.Until and time.Sleep.
+// Sleep moves the clock instead of blocking.
+type Clock struct {
+	lock    sync.Mutex
+	now     time.Time
+	step    time.Duration
+	patches *gomonkeyv2.Patches
+	once    sync.Once
+}
+
+// FreezeClock patches the time functions with the fake clock until Close
+func FreezeClock(setting ClockSetting) *Clock {
+	start, err := time.Parse(time.RFC3339Nano, setting.Start)
+	if err != nil {
+		start = faker.ReferenceTime()
+	}
+	step, err := time.ParseDuration(setting.Step)
+	if err != nil {
+		step = 0
+	}
+	c := &Clock{now: start, step: step}
+	clockLock.Lock()
+	c.patches = gomonkeyv2.ApplyFunc(time.Now, c.Now)
+	c.patches.ApplyFunc(time.Since, c.Since)
+	c.patches.ApplyFunc(time.Until, c.Until)
+	c.patches.ApplyFunc(time.Sleep, c.Sleep)
+	return c
+}
+
+// Now returns the instant of the clock, then moves it by the step
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *Clock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// Sleep moves the clock by d
+func (c *Clock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Close restores the time functions
+func (c *Clock) Close() {
+	c.once.Do(func() {
+		c.patches.Reset()
+		clockLock.Unlock()
+	})
+}
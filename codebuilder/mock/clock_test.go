@@ -0,0 +1,45 @@
+package mock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestFreezeClock(t *testing.T) {
+	convey.Convey("TestFreezeClock", t, func() {
+		clock := FreezeClock(ClockSetting{Start: "2023-05-01T10:00:00Z", Step: "1s"})
+		start := time.Now()
+		convey.So(start.Equal(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)), convey.ShouldBeTrue)
+		time.Sleep(time.Hour)
+		convey.So(time.Since(start), convey.ShouldEqual, time.Hour+time.Second)
+		clock.Close()
+		clock.Close()
+		convey.So(time.Since(start), convey.ShouldBeGreaterThan, 24*time.Hour)
+		setting := RandomClock()
+		convey.So(setting.ValueToCode(), convey.ShouldStartWith, "mockfunc.ClockSetting{Start: \"")
+	})
+}
+
+func TestUseRealClock(t *testing.T) {
+	convey.Convey("TestUseRealClock", t, func() {
+		release := UseRealClock()
+		frozen := make(chan *Clock)
+		go func() {
+			frozen <- FreezeClock(ClockSetting{Start: "2023-05-01T10:00:00Z"})
+		}()
+		// the fake clock waits until the function which reads the real clock returns
+		select {
+		case clock := <-frozen:
+			clock.Close()
+			t.Fatal("the clock is frozen while the real one is used")
+		case <-time.After(50 * time.Millisecond):
+		}
+		convey.So(time.Now().Year(), convey.ShouldNotEqual, 2023)
+		release()
+		clock := <-frozen
+		convey.So(time.Now().Year(), convey.ShouldEqual, 2023)
+		clock.Close()
+	})
+}
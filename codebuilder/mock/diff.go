@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Diff compares the result with the wanted one and returns the difference, empty if they are equal.
+// The ignored fields are the paths of go-cmp, e.g. Items.CreatedAt, and the empty path ignores the whole result.
+func Diff(got, want interface{}, ignore ...string) string {
+	return cmp.Diff(want, got, cmpOptions(ignore))
+}
+
+// Unstable lists the fields of each result which differ between the runs of the same case, keyed by the result
+type Unstable map[string][]string
+
+// Add compares the results of two runs and records the fields which differ
+func (u Unstable) Add(result string, x, y interface{}) {
+	reporter := &pathReporter{paths: map[string]bool{}}
+	cmp.Equal(x, y, cmpOptions(u[result]), cmp.Reporter(reporter))
+	if len(reporter.paths) == 0 {
+		return
+	}
+	for _, path := range u[result] {
+		reporter.paths[path] = true
+	}
+	paths := make([]string, 0, len(reporter.paths))
+	for path := range reporter.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	u[result] = paths
+}
+
+// ValueToCode renders the unstable fields in the test suite
+func (u Unstable) ValueToCode() string {
+	if len(u) == 0 {
+		return "nil"
+	}
+	results := make([]string, 0, len(u))
+	for result := range u {
+		results = append(results, result)
+	}
+	sort.Strings(results)
+	builder := strings.Builder{}
+	builder.WriteString("mockfunc.Unstable{")
+	for _, result := range results {
+		builder.WriteString(fmt.Sprintf("\n%q: %#v,", result, u[result]))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// CaseRuns are the calls of the tested function made by a case, i.e. its first run and the re-runs.
+// The coverage of the calls is reported in the order of the calls.
+type CaseRuns struct {
+	// index of the first call of the case among the calls of the tested function
+	First int
+	Count int
+}
+
+// FoldRuns folds the coverage reports of the calls into one per case, the case is available if any of its runs
+// reaches the new path. The cases which were skipped, e.g. because they panicked, are left out of cases, so that
+// their calls are not taken for the others.
+func FoldRuns(available []bool, cases []CaseRuns) []bool {
+	folded := make([]bool, len(cases))
+	for i, c := range cases {
+		for call := c.First; call < c.First+c.Count && call < len(available); call++ {
+			folded[i] = folded[i] || available[call]
+		}
+	}
+	return folded
+}
+
+func cmpOptions(ignore []string) cmp.Options {
+	ignored := make(map[string]bool, len(ignore))
+	for _, path := range ignore {
+		ignored[path] = true
+	}
+	return cmp.Options{
+		// the generated values have the unexported fields
+		cmp.Exporter(func(reflect.Type) bool { return true }),
+		// the functions are not comparable
+		cmp.FilterPath(func(p cmp.Path) bool {
+			return p.Last().Type() != nil && p.Last().Type().Kind() == reflect.Func
+		}, cmp.Ignore()),
+		cmp.FilterPath(func(p cmp.Path) bool {
+			return ignored[p.String()]
+		}, cmp.Ignore()),
+	}
+}
+
+// pathReporter collects the paths of the values which differ
+type pathReporter struct {
+	path  cmp.Path
+	paths map[string]bool
+}
+
+func (r *pathReporter) PushStep(step cmp.PathStep) {
+	r.path = append(r.path, step)
+}
+
+func (r *pathReporter) Report(result cmp.Result) {
+	if !result.Equal() {
+		r.paths[r.path.String()] = true
+	}
+}
+
+func (r *pathReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
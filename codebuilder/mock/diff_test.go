@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID        string
+	Items     []item
+	CreatedAt time.Time
+	total     int
+	OnDone    func()
+}
+
+type item struct {
+	Name  string
+	Token int
+}
+
+func TestDiff(t *testing.T) {
+	want := &order{ID: "a", Items: []item{{Name: "x", Token: 1}}, total: 2}
+	assert.Empty(t, Diff(&order{ID: "a", Items: []item{{Name: "x", Token: 1}}, total: 2, OnDone: func() {}}, want))
+	assert.Contains(t, Diff(&order{ID: "a", Items: []item{{Name: "x", Token: 1}}, total: 3}, want), "total")
+	assert.Empty(t, Diff(&order{ID: "a", Items: []item{{Name: "x", Token: 9}}, total: 2}, want, "Items.Token"))
+	assert.Empty(t, Diff("b", "a", ""))
+}
+
+func TestUnstable(t *testing.T) {
+	unstable := Unstable{}
+	first := order{ID: "a", Items: []item{{Name: "x", Token: 1}}, CreatedAt: time.Unix(1, 0)}
+	unstable.Add("Want", first, order{ID: "a", Items: []item{{Name: "x", Token: 2}}, CreatedAt: time.Unix(2, 0)})
+	unstable.Add("Want", first, order{ID: "a", Items: []item{{Name: "x", Token: 3}}, CreatedAt: time.Unix(1, 0)})
+	unstable.Add("Want1", "b", "b")
+	assert.Equal(t, Unstable{"Want": {"CreatedAt", "Items.Token"}}, unstable)
+	assert.Equal(t, "mockfunc.Unstable{\n\"Want\": []string{\"CreatedAt\", \"Items.Token\"},}", unstable.ValueToCode())
+	assert.Equal(t, "nil", Unstable{}.ValueToCode())
+
+	unstable.Add("Want1", 1, 2)
+	assert.Equal(t, []string{""}, unstable["Want1"])
+}
+
+func TestFoldRuns(t *testing.T) {
+	available := []bool{false, true, false, false, false, false, true}
+	assert.Equal(t, []bool{true, false, true}, FoldRuns(available, []CaseRuns{{0, 3}, {3, 3}, {6, 1}}))
+	// the calls of the skipped case are not taken for the next one
+	assert.Equal(t, []bool{false, true}, FoldRuns(available, []CaseRuns{{0, 1}, {4, 3}}))
+	assert.Empty(t, FoldRuns(available, nil))
+}
+
+func TestStatementRender_Rewind(t *testing.T) {
+	mockRender := &StatementRender{UsedMockFunc: map[string]int{}}
+	ctx := context.Background()
+	next := MakeCall(ctx, "next", mockRender, func() int { return 0 }, atgconstant.UseGoMonkeyMock).(func() int)
+	first := make([]int, 0)
+	for i := 0; i < atgconstant.MaxMockOutputSeq+1; i++ {
+		first = append(first, next())
+	}
+	calls := mockRender.MockCalls["next"].Count
+	mockRender.Rewind()
+	for i := 0; i < atgconstant.MaxMockOutputSeq+1; i++ {
+		assert.Equal(t, first[i], next())
+	}
+	assert.Equal(t, calls, mockRender.MockCalls["next"].Count)
+}
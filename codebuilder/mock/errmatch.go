@@ -0,0 +1,140 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+)
+
+// Sentinel is the package variable of the error type, e.g. dao.ErrNotFound
+type Sentinel struct {
+	Err     error
+	Code    string
+	PkgName string
+	PkgPath string
+}
+
+// ErrorType is the error type checked by errors.As. Target points to the error, e.g. new(*dao.NotFoundError),
+// and Code is the code of Target.
+type ErrorType struct {
+	Target  interface{}
+	Code    string
+	PkgName string
+	PkgPath string
+}
+
+// ErrorMatcher finds how the final suite checks the error returned by the tested function.
+// The strategies are tried in order, see atgconstant.ErrorAssertIs.
+type ErrorMatcher struct {
+	Strategies []string
+	Sentinels  []Sentinel
+	Types      []ErrorType
+}
+
+// ErrorMatch is how the final suite checks the error: by errors.Is, by errors.As or by the message.
+// The empty one checks nothing.
+type ErrorMatch struct {
+	Is      error
+	As      interface{}
+	Message string
+	// the code of the match in the test suite
+	code string
+}
+
+// ValueToCode renders the match in the test suite
+func (m ErrorMatch) ValueToCode() string {
+	if m.code == "" {
+		return "mockfunc.ErrorMatch{}"
+	}
+	return m.code
+}
+
+// Match returns the first match of the error by the strategies
+func (m ErrorMatcher) Match(ctx context.Context, err error) ErrorMatch {
+	if err == nil {
+		return ErrorMatch{}
+	}
+	for _, strategy := range m.Strategies {
+		switch strategy {
+		case atgconstant.ErrorAssertIs:
+			for _, sentinel := range m.Sentinels {
+				if sentinel.Err != nil && errors.Is(err, sentinel.Err) {
+					putPkg(ctx, sentinel.PkgName, sentinel.PkgPath)
+					return ErrorMatch{Is: sentinel.Err, code: fmt.Sprintf("mockfunc.ErrorMatch{Is: %s}", sentinel.Code)}
+				}
+			}
+		case atgconstant.ErrorAssertAs:
+			for _, errType := range m.Types {
+				if matchErrorType(err, errType.Target) {
+					putPkg(ctx, errType.PkgName, errType.PkgPath)
+					return ErrorMatch{As: errType.Target, code: fmt.Sprintf("mockfunc.ErrorMatch{As: %s}", errType.Code)}
+				}
+			}
+		case atgconstant.ErrorAssertMessage:
+			return ErrorMatch{Message: err.Error(), code: fmt.Sprintf("mockfunc.ErrorMatch{Message: %q}", err.Error())}
+		}
+	}
+	return ErrorMatch{}
+}
+
+// Stable keeps the match if the error of another run of the case matches it too. Otherwise the match is dropped,
+// e.g. the message which carries the time.
+func (m ErrorMatch) Stable(err error) ErrorMatch {
+	if DiffError(err, m) != "" {
+		return ErrorMatch{}
+	}
+	return m
+}
+
+// DiffError checks the error by the match and returns the difference, empty if the error matches
+func DiffError(err error, want ErrorMatch) string {
+	switch {
+	case want.Is != nil:
+		if !errors.Is(err, want.Is) {
+			return fmt.Sprintf("the error %v is not %v", err, want.Is)
+		}
+	case want.As != nil:
+		if !matchErrorType(err, want.As) {
+			return fmt.Sprintf("the error %v is not %v", err, reflect.TypeOf(want.As).Elem())
+		}
+	case want.Message != "":
+		if err == nil || err.Error() != want.Message {
+			return fmt.Sprintf("the error %v does not have the message %q", err, want.Message)
+		}
+	}
+	return ""
+}
+
+// matchErrorType reports whether errors.As finds the error of the type which the target points to
+func matchErrorType(err error, target interface{}) bool {
+	typ := reflect.TypeOf(target)
+	if err == nil || typ == nil || typ.Kind() != reflect.Ptr {
+		return false
+	}
+	return errors.As(err, reflect.New(typ.Elem()).Interface())
+}
+
+func putPkg(ctx context.Context, pkgName, pkgPath string) {
+	if pkgPath != "" {
+		duplicatepackagemanager.GetInstance(ctx).PutAndGet(pkgName, pkgPath)
+	}
+}
@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotFound = errors.New("not found")
+
+type codeError struct {
+	Code int
+}
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.Code) }
+
+func TestErrorMatcher_Match(t *testing.T) {
+	matcher := ErrorMatcher{
+		Strategies: []string{atgconstant.ErrorAssertIs, atgconstant.ErrorAssertAs, atgconstant.ErrorAssertMessage},
+		Sentinels:  []Sentinel{{Err: errNotFound, Code: "errNotFound"}},
+		Types:      []ErrorType{{Target: new(*codeError), Code: "new(*codeError)"}},
+	}
+	ctx := context.Background()
+	assert.Equal(t, "mockfunc.ErrorMatch{}", matcher.Match(ctx, nil).ValueToCode())
+
+	match := matcher.Match(ctx, fmt.Errorf("load: %w", errNotFound))
+	assert.Equal(t, "mockfunc.ErrorMatch{Is: errNotFound}", match.ValueToCode())
+	assert.Empty(t, DiffError(fmt.Errorf("get: %w", errNotFound), match))
+	assert.NotEmpty(t, DiffError(errors.New("not found"), match))
+
+	match = matcher.Match(ctx, fmt.Errorf("load: %w", &codeError{Code: 3}))
+	assert.Equal(t, "mockfunc.ErrorMatch{As: new(*codeError)}", match.ValueToCode())
+	assert.Empty(t, DiffError(&codeError{Code: 4}, match))
+	assert.NotEmpty(t, DiffError(errNotFound, match))
+
+	match = matcher.Match(ctx, errors.New("timeout"))
+	assert.Equal(t, `mockfunc.ErrorMatch{Message: "timeout"}`, match.ValueToCode())
+	assert.Empty(t, DiffError(errors.New("timeout"), match))
+	assert.NotEmpty(t, DiffError(nil, match))
+	// the message which differs in another run is not asserted
+	assert.Equal(t, match, match.Stable(errors.New("timeout")))
+	assert.Equal(t, ErrorMatch{}, match.Stable(errors.New("timeout at 10:00")))
+
+	// the message is not checked unless it is asked for
+	matcher.Strategies = []string{atgconstant.ErrorAssertIs}
+	assert.Equal(t, ErrorMatch{}, matcher.Match(ctx, errors.New("timeout")))
+	assert.Empty(t, DiffError(errors.New("timeout"), ErrorMatch{}))
+}
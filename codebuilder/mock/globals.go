@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// globalsLock makes the snapshots take turns, because the middle code runs the tested functions in parallel
+// and they might share the package variables
+var globalsLock sync.Mutex
+
+// Globals is the snapshot of the package variables which the tested function reads or writes
+type Globals struct {
+	vars     []reflect.Value
+	original []reflect.Value
+	once     sync.Once
+}
+
+// SnapshotGlobals copies the variables which the pointers point to, e.g. mockfunc.SnapshotGlobals(t, &dao.Counter).
+// Restore sets them back after the case, and the test restores the first snapshot by t.Cleanup when it finishes,
+// so that the cases do not leak the state into one another or into the other tests.
+func SnapshotGlobals(t Cleaner, ptrs ...interface{}) *Globals {
+	globalsLock.Lock()
+	g := &Globals{}
+	for _, ptr := range ptrs {
+		v := reflect.ValueOf(ptr)
+		if v.Kind() != reflect.Ptr || v.IsNil() {
+			globalsLock.Unlock()
+			panic(fmt.Sprintf("%v is not the address of the variable", ptr))
+		}
+		original := reflect.New(v.Elem().Type()).Elem()
+		original.Set(v.Elem())
+		g.vars = append(g.vars, v.Elem())
+		g.original = append(g.original, original)
+	}
+	t.Cleanup(func() {
+		// the case might not restore the variables itself
+		g.Restore()
+		globalsLock.Lock()
+		defer globalsLock.Unlock()
+		g.restore()
+	})
+	return g
+}
+
+func (g *Globals) restore() {
+	for i, v := range g.vars {
+		v.Set(g.original[i])
+	}
+}
+
+// Restore sets the variables back to the snapshot
+func (g *Globals) Restore() {
+	g.once.Do(func() {
+		g.restore()
+		globalsLock.Unlock()
+	})
+}
@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	globalCount = 1
+	globalNames = []string{"a"}
+)
+
+type cleaner struct {
+	funcs []func()
+}
+
+func (c *cleaner) Cleanup(f func()) {
+	c.funcs = append(c.funcs, f)
+}
+
+func (c *cleaner) run() {
+	for i := len(c.funcs) - 1; i >= 0; i-- {
+		c.funcs[i]()
+	}
+}
+
+func TestSnapshotGlobals(t *testing.T) {
+	convey.Convey("TestSnapshotGlobals", t, func() {
+		c := &cleaner{}
+		globals := SnapshotGlobals(c, &globalCount, &globalNames)
+		globalCount = 7
+		globalNames = append(globalNames, "b")
+		globals.Restore()
+		globals.Restore()
+		convey.So(globalCount, convey.ShouldEqual, 1)
+		convey.So(globalNames, convey.ShouldResemble, []string{"a"})
+
+		// the case leaves the variable changed without Restore, and the cleanup restores it
+		SnapshotGlobals(c, &globalCount)
+		globalCount = 9
+		c.run()
+		convey.So(globalCount, convey.ShouldEqual, 1)
+		convey.So(func() { SnapshotGlobals(c, globalCount) }, convey.ShouldPanic)
+	})
+}
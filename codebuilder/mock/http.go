@@ -0,0 +1,227 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+)
+
+// HTTPResponse is one response which the stand-in serves
+type HTTPResponse struct {
+	Status      int
+	Body        string
+	ContentType string
+}
+
+// HTTPResponses are served in order, and the last one is repeated
+type HTTPResponses []HTTPResponse
+
+// ValueToCode renders the responses in the test suite
+func (r HTTPResponses) ValueToCode() string {
+	builder := strings.Builder{}
+	builder.WriteString("mockfunc.HTTPResponses{")
+	for _, response := range r {
+		builder.WriteString(fmt.Sprintf("\n{Status: %d, Body: %q, ContentType: %q},", response.Status, response.Body, response.ContentType))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+var (
+	successStatus     = []int{http.StatusOK, http.StatusCreated, http.StatusNoContent}
+	clientErrorStatus = []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusTooManyRequests}
+	serverErrorStatus = []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}
+)
+
+// RandomHTTPResponses returns the responses of the success, the 4xx, the 5xx and the malformed JSON randomly
+func RandomHTTPResponses() HTTPResponses {
+	n := rand.Intn(atgconstant.MaxMockOutputSeq) + 1
+	responses := make(HTTPResponses, 0, n)
+	for i := 0; i < n; i++ {
+		responses = append(responses, randomHTTPResponse())
+	}
+	return responses
+}
+
+func randomHTTPResponse() HTTPResponse {
+	switch rand.Intn(4) {
+	case 0:
+		status := successStatus[rand.Intn(len(successStatus))]
+		if status == http.StatusNoContent {
+			return HTTPResponse{Status: status}
+		}
+		return HTTPResponse{Status: status, Body: successBody(), ContentType: "application/json"}
+	case 1:
+		status := clientErrorStatus[rand.Intn(len(clientErrorStatus))]
+		return HTTPResponse{Status: status, Body: fmt.Sprintf(`{"code":%d,"message":%q}`, status, http.StatusText(status)), ContentType: "application/json"}
+	case 2:
+		status := serverErrorStatus[rand.Intn(len(serverErrorStatus))]
+		return HTTPResponse{Status: status, Body: http.StatusText(status), ContentType: "text/plain; charset=utf-8"}
+	default:
+		// the body is cut off, so that decoding it fails
+		body := successBody()
+		return HTTPResponse{Status: http.StatusOK, Body: body[:rand.Intn(len(body)-1)+1], ContentType: "application/json"}
+	}
+}
+
+func successBody() string {
+	return fmt.Sprintf(`{"code":0,"message":"success","data":{"id":%d,"name":"%s"}}`, rand.Intn(100000), randomName())
+}
+
+func randomName() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, rand.Intn(8)+3)
+	for i := range b {
+		b[i] = letters[rand.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// HTTPStandIn is the httptest server which takes the place of the remote servers.
+// The connections are in memory, so that it works while the suite stops the real connections.
+type HTTPStandIn struct {
+	*httptest.Server
+	original http.RoundTripper
+	lock     sync.Mutex
+	requests int
+	once     sync.Once
+}
+
+// standInLock makes the stand-ins take turns, because they share http.DefaultTransport
+var standInLock sync.Mutex
+
+// StandInHTTP starts the stand-in and routes http.DefaultTransport to it, so that http.Get and
+// the clients without their own transport get the responses. Close restores http.DefaultTransport.
+// The stand-ins of the concurrent tests wait for each other.
+func StandInHTTP(responses ...HTTPResponse) *HTTPStandIn {
+	standInLock.Lock()
+	s := &HTTPStandIn{original: http.DefaultTransport}
+	listener := newPipeListener()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		index := s.requests
+		s.requests++
+		s.lock.Unlock()
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if index >= len(responses) {
+			index = len(responses) - 1
+		}
+		response := responses[index]
+		if response.ContentType != "" {
+			w.Header().Set("Content-Type", response.ContentType)
+		}
+		w.WriteHeader(response.Status)
+		fmt.Fprint(w, response.Body)
+	})
+	s.Server = &httptest.Server{Listener: listener, Config: &http.Server{Handler: handler}}
+	s.Server.Start()
+	http.DefaultTransport = &standInTransport{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return listener.dial()
+		},
+	}}
+	return s
+}
+
+// Requests returns how many requests the stand-in served
+func (s *HTTPStandIn) Requests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests
+}
+
+// Close restores http.DefaultTransport and shuts down the stand-in
+func (s *HTTPStandIn) Close() {
+	s.once.Do(func() {
+		if transport, ok := http.DefaultTransport.(*standInTransport); ok {
+			transport.CloseIdleConnections()
+		}
+		http.DefaultTransport = s.original
+		s.Server.Close()
+		standInLock.Unlock()
+	})
+}
+
+// standInTransport sends the requests of any host to the stand-in. The https requests are sent in plain http.
+type standInTransport struct {
+	*http.Transport
+}
+
+func (t *standInTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "https" {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = "http"
+	}
+	return t.Transport.RoundTrip(req)
+}
+
+// pipeListener accepts the in-memory connections created by dial
+type pipeListener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newPipeListener() *pipeListener {
+	return &pipeListener{conns: make(chan net.Conn), closed: make(chan struct{})}
+}
+
+func (l *pipeListener) dial() (net.Conn, error) {
+	client, server := net.Pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr{}
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "stand-in.local" }
@@ -0,0 +1,62 @@
+package mock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestStandInHTTP(t *testing.T) {
+	convey.Convey("TestStandInHTTP", t, func() {
+		original := http.DefaultTransport
+		standIn := StandInHTTP(
+			HTTPResponse{Status: http.StatusOK, Body: `{"code":0}`, ContentType: "application/json"},
+			HTTPResponse{Status: http.StatusServiceUnavailable, Body: "Service Unavailable"},
+		)
+		get := func(url string) (int, string) {
+			resp, err := http.Get(url)
+			convey.So(err, convey.ShouldBeNil)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			convey.So(err, convey.ShouldBeNil)
+			return resp.StatusCode, string(body)
+		}
+		status, body := get("https://example.com/api")
+		convey.So(status, convey.ShouldEqual, http.StatusOK)
+		convey.So(body, convey.ShouldEqual, `{"code":0}`)
+		status, body = get("http://example.com/api")
+		convey.So(status, convey.ShouldEqual, http.StatusServiceUnavailable)
+		convey.So(body, convey.ShouldEqual, "Service Unavailable")
+		// the last response is repeated
+		status, _ = get("http://other.example.com")
+		convey.So(status, convey.ShouldEqual, http.StatusServiceUnavailable)
+		convey.So(standIn.Requests(), convey.ShouldEqual, 3)
+		standIn.Close()
+		standIn.Close()
+		convey.So(http.DefaultTransport, convey.ShouldEqual, original)
+	})
+}
+
+func TestRandomHTTPResponses(t *testing.T) {
+	convey.Convey("TestRandomHTTPResponses", t, func() {
+		for i := 0; i < 50; i++ {
+			responses := RandomHTTPResponses()
+			convey.So(len(responses), convey.ShouldBeBetweenOrEqual, 1, 3)
+			for _, response := range responses {
+				if response.Status >= 400 || response.Status == http.StatusNoContent {
+					continue
+				}
+				// the success bodies are JSON, the others are cut off
+				var v interface{}
+				if json.Unmarshal([]byte(response.Body), &v) != nil {
+					convey.So(response.Body, convey.ShouldStartWith, "{")
+				}
+			}
+		}
+		code := HTTPResponses{{Status: 404, Body: `{"message":"Not Found"}`, ContentType: "application/json"}}.ValueToCode()
+		convey.So(code, convey.ShouldEqual, "mockfunc.HTTPResponses{\n{Status: 404, Body: \"{\\\"message\\\":\\\"Not Found\\\"}\", ContentType: \"application/json\"},}")
+	})
+}
@@ -17,12 +17,18 @@ package mock
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper"
 	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
 	"github.com/bytedance/nxt_unit/codebuilder/variablecard"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/bytedance/nxt_unit/smartunitvariablebuild"
+	"math/rand"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 type StatementRender struct {
@@ -32,6 +38,130 @@ type StatementRender struct {
 	Imports         []string
 	MonkeyOutputMap variablecard.MonkeyOutputMap
 	UsedMockFunc    map[string]int
+	MockCalls       variablecard.MockCallRecord
+	// the error injected into the mocked function of the fault, nil for the random case
+	Fault *atgconstant.Fault
+	// the mocked functions which are called
+	called map[string]bool
+	// the sequences of the mocked functions restart on rewinding, and the calls after it are not recorded
+	rewinds []func()
+	replay  bool
+	// the tested function might call the mocked functions in other goroutines
+	lock sync.Mutex
+}
+
+// Called reports whether the tested function called the mocked function
+func (s *StatementRender) Called(funcName string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.called[funcName]
+}
+
+// Rewind restarts the sequences of the values returned by the mocked functions, so the tested function runs again
+// with the same values. The calls of the run are not recorded.
+func (s *StatementRender) Rewind() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.replay = true
+	for _, rewind := range s.rewinds {
+		rewind()
+	}
+}
+
+func (s *StatementRender) onRewind(rewind func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rewinds = append(s.rewinds, rewind)
+}
+
+func (s *StatementRender) markCalled(funcName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.called == nil {
+		s.called = make(map[string]bool)
+	}
+	s.called[funcName] = true
+}
+
+// RecordCall records the call count and the arguments of the mocked function.
+// Only the functions patched by the final suite are recorded.
+func (s *StatementRender) RecordCall(ctx context.Context, funcName string, args []reflect.Value) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.UsedMockFunc[funcName]; !ok || s.replay {
+		return
+	}
+	s.recordCall(ctx, funcName, args)
+}
+
+// RecordMocks records the calls of the typed mocks in the values like the calls of the patched functions,
+// see CallRecorder.ObserveMocks
+func (s *StatementRender) RecordMocks(ctx context.Context, values ...interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, mock := range smartunitvariablebuild.FindMocks(values...) {
+		for _, call := range mock.MockRecorder().AllCalls() {
+			s.recordCall(ctx, smartunitvariablebuild.MockName(mock, call.Method), mockArgs(call.Args))
+		}
+	}
+}
+
+func (s *StatementRender) recordCall(ctx context.Context, funcName string, args []reflect.Value) {
+	if s.MockCalls == nil {
+		s.MockCalls = make(variablecard.MockCallRecord, 0)
+	}
+	call, ok := s.MockCalls[funcName]
+	if !ok {
+		call = &variablecard.MockCallCode{}
+		s.MockCalls[funcName] = call
+	}
+	call.Count++
+	if len(call.Args) >= atgconstant.MaxRecordedMockCalls {
+		return
+	}
+	codes := make([]string, 0, len(args))
+	for _, arg := range args {
+		codes = append(codes, argToString(ctx, arg))
+	}
+	call.Args = append(call.Args, strings.Join(codes, ", "))
+}
+
+// RecordOutput records the code of the values returned by the mocked function until the index-th call.
+// The final suite returns the recorded values in order. Only the functions patched by the final suite are recorded.
+func (s *StatementRender) RecordOutput(funcName string, outputs []string, index int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.UsedMockFunc[funcName]; !ok || s.replay {
+		return
+	}
+	if s.MonkeyOutputMap == nil {
+		s.MonkeyOutputMap = make(variablecard.MonkeyOutputMap, 0)
+	}
+	if index >= len(s.MonkeyOutputMap[funcName]) {
+		s.MonkeyOutputMap[funcName] = outputs[:index+1]
+	}
+}
+
+// argToString renders the argument as the expected value. The argument which cannot be compared or rendered
+// matches any value.
+func argToString(ctx context.Context, arg reflect.Value) string {
+	mockName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("mockfunc", "github.com/bytedance/nxt_unit/codebuilder/mock")
+	anyCode := mockName + ".Any"
+	if !arg.IsValid() {
+		return "nil"
+	}
+	if isContext(arg.Type()) {
+		return anyCode
+	}
+	switch arg.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return anyCode
+	}
+	code := variablecard.ValueToString(ctx, arg)
+	if code == "unexport variable" || (code == "nil" && !atghelper.IsValueNil(arg)) {
+		return anyCode
+	}
+	return code
 }
 
 func OverPassMakeCall(ctx context.Context, funcName string, mockRender *StatementRender, m interface{}) (fun interface{}) {
@@ -95,8 +225,9 @@ func OverPassMakeCall(ctx context.Context, funcName string, mockRender *Statemen
 }
 
 // TODO（siwei.wang）: alias will make MakeCall panic.
+// MakeCall mutates a sequence of the return values. The n-th call of the mocked function returns the n-th values,
+// and the calls beyond the sequence return the last ones.
 func MakeCall(ctx context.Context, funcName string, mockRender *StatementRender, m interface{}, useMockType int) (fun interface{}) {
-	var out []reflect.Value
 	function := reflect.TypeOf(m)
 	if function.Kind() != reflect.Func {
 		panic("it's no true")
@@ -107,69 +238,218 @@ func MakeCall(ctx context.Context, funcName string, mockRender *StatementRender,
 		CanBeNil: false,
 	}
 	ctx = contexthelper.SetVariableContext(ctx, vtx)
-	for i := 0; i < function.NumOut(); i++ {
-		// 参数是 interface
-		// function
-		// 别名
-		// 不行就放过，没有statement
-		resultType := function.Out(i)
-		switch resultType.Kind() {
-		// reflect.Zero new the value of addressable nor settable
-		// it make faker.GetValue(for pointer) fail
-		// so we to Mutate struct for pointer by reflectValue.Addr()
-		// see: https://halfrost.com/go_reflection/
-		case reflect.Ptr:
-			v := reflect.New(resultType.Elem()).Elem()
-			mutationElement := variablecard.VariableMutate(ctx, resultType.Elem(), v)
-			if mutationElement.CanAddr() {
-				out = append(out, variablecard.VariableMutate(ctx, resultType.Elem(), v).Addr())
-			} else {
-				var vNil interface{} = nil
-				out = append(out, reflect.ValueOf(vNil))
+	outs := make([][]reflect.Value, 0)
+	// the code of the constant results, empty for the generated ones
+	codes := make([][]string, 0)
+	shapes, _ := contexthelper.GetReturnShapes(ctx)
+	for i := rand.Intn(atgconstant.MaxMockOutputSeq) + 1; i > 0; i-- {
+		out, code := makeShapedOutput(ctx, function, shapes[funcName])
+		outs = append(outs, out)
+		codes = append(codes, code)
+	}
+	fault := mockRender.Fault
+	if fault != nil && (fault.Func != funcName || fault.Result >= function.NumOut()) {
+		fault = nil
+	}
+	if fault != nil {
+		// every call fails, the other results are the zero values
+		code := make([]string, function.NumOut())
+		code[fault.Result] = fault.Code
+		outs, codes = [][]reflect.Value{injectFault(function, fault)}, [][]string{code}
+		if fault.PkgPath != "" {
+			duplicatepackagemanager.GetInstance(ctx).PutAndGet(fault.PkgName, fault.PkgPath)
+		}
+	}
+	ctx = contexthelper.SetVariableContext(ctx, atgconstant.VariableContext{Level: 0, ID: 0, CanBeNil: false})
+	cards := make([][]string, 0, len(outs))
+	outputs := make([]string, 0, len(outs))
+	for j, out := range outs {
+		var card []string
+		for i, r := range out {
+			if codes[j][i] != "" {
+				card = append(card, codes[j][i])
+				continue
 			}
-
-		default:
-			v := reflect.Zero(resultType)
-			out = append(out, variablecard.VariableMutate(ctx, resultType, v))
+			card = append(card, variablecard.ValueToString(ctx, r))
 		}
+		cards = append(cards, card)
+		outputs = append(outputs, strings.Join(card, ", "))
 	}
+	var callLock sync.Mutex
+	var called int
 	newFunc := reflect.MakeFunc(function, func(args []reflect.Value) (results []reflect.Value) {
-		return out
+		callLock.Lock()
+		index := called
+		if index >= len(outs) {
+			index = len(outs) - 1
+		}
+		called++
+		callLock.Unlock()
+		mockRender.markCalled(funcName)
+		if useMockType == atgconstant.UseGoMonkeyMock {
+			mockRender.RecordCall(ctx, funcName, args)
+			mockRender.RecordOutput(funcName, outputs, index)
+		}
+		return outs[index]
 	})
-	ctx = contexthelper.SetVariableContext(ctx, atgconstant.VariableContext{Level: 0, ID: 0, CanBeNil: false})
-	var card []string
-	for _, r := range out {
-		card = append(card, variablecard.ValueToString(ctx, r))
-	}
-	for _, code := range card {
-		if code == "unexport variable" {
-			return newFunc.Interface()
+	mockRender.onRewind(func() {
+		callLock.Lock()
+		defer callLock.Unlock()
+		called = 0
+	})
+	for _, card := range cards {
+		for _, code := range card {
+			if code == "unexport variable" {
+				return newFunc.Interface()
+			}
 		}
 	}
 	switch useMockType {
 	case atgconstant.UseMockitoMock:
-		mockStateMent := fmt.Sprintf("mockito.Mock(%s).Return(%s).Build()", funcName, strings.Join(card, ", "))
+		mockStateMent := fmt.Sprintf("mockito.Mock(%s).Return(%s).Build()", funcName, strings.Join(cards[0], ", "))
+		if len(outputs) > 1 {
+			// mockito returns the same values, so the sequence is returned by the counter based stub
+			seq := make([]string, 0, len(outputs))
+			for _, output := range outputs {
+				seq = append(seq, fmt.Sprintf("[]interface{}{%s}", output))
+			}
+			mockStateMent = fmt.Sprintf("mockito.Mock(%s).To(mockfunc.NewCallRecorder().Return(\"%s\", %s, %s)).Build()", funcName, funcName, funcName, strings.Join(seq, ", "))
+		}
 		mockRender.MockStatement = append(mockRender.MockStatement, mockStateMent)
 	case atgconstant.UseGoMonkeyMock:
-		mockRender.MonkeyOutputMap[funcName] = strings.Join(card, ", ")
+		card := cards[0]
 		if len(card) != 0 {
 			_, ok := mockRender.UsedMockFunc[funcName]
 			if !ok {
 				mockRender.UsedMockFunc[funcName] = len(card)
 			}
 		} else {
-			if len(out) == 0 {
+			if function.NumOut() == 0 {
 				_, ok := mockRender.UsedMockFunc[funcName]
 				if !ok {
 					mockRender.UsedMockFunc[funcName] = 0
 				}
 			}
 		}
+		// the function not called still returns the first values in the final suite
+		mockRender.RecordOutput(funcName, outputs, 0)
 	}
 
 	return newFunc.Interface()
 }
 
+// injectFault returns the error of the fault and the zero values of the other results
+func injectFault(function reflect.Type, fault *atgconstant.Fault) []reflect.Value {
+	out := make([]reflect.Value, 0, function.NumOut())
+	for i := 0; i < function.NumOut(); i++ {
+		v := reflect.New(function.Out(i)).Elem()
+		if i == fault.Result && fault.Err != nil && reflect.TypeOf(fault.Err).AssignableTo(v.Type()) {
+			v.Set(reflect.ValueOf(fault.Err))
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// makeShapedOutput mutates the return values of the function. Mostly they take one of the return shapes of the
+// function, so that the mock returns what the function is able to return. It returns the code of the constant results
+// as well, which is empty for the generated ones.
+func makeShapedOutput(ctx context.Context, function reflect.Type, shapes []atgconstant.ReturnShape) ([]reflect.Value, []string) {
+	out := makeOutput(ctx, function)
+	codes := make([]string, len(out))
+	if len(shapes) == 0 || rand.Intn(100) >= atgconstant.ReturnShapePercent {
+		return out, codes
+	}
+	shape := shapes[rand.Intn(len(shapes))]
+	if len(shape) != len(out) {
+		return out, codes
+	}
+	for i, result := range shape {
+		resultType := function.Out(i)
+		switch result.Kind {
+		case atgconstant.ResultNil:
+			out[i] = reflect.Zero(resultType)
+		case atgconstant.ResultNonNil:
+			out[i] = makeNonNil(resultType, out[i])
+		case atgconstant.ResultConst:
+			if result.Value == nil {
+				continue
+			}
+			v := reflect.ValueOf(result.Value)
+			switch {
+			case v.Type().AssignableTo(resultType):
+				value := reflect.New(resultType).Elem()
+				value.Set(v)
+				out[i] = value
+			case v.Type().ConvertibleTo(resultType):
+				out[i] = v.Convert(resultType)
+			default:
+				continue
+			}
+			codes[i] = result.Code
+			if result.PkgPath != "" {
+				duplicatepackagemanager.GetInstance(ctx).PutAndGet(result.PkgName, result.PkgPath)
+			}
+		}
+	}
+	return out, codes
+}
+
+// makeNonNil replaces the nil value with the empty one, and the nil error with the mock error
+func makeNonNil(t reflect.Type, v reflect.Value) reflect.Value {
+	if v.IsValid() && v.Type().AssignableTo(t) && !atghelper.IsValueNil(v) {
+		return v
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return reflect.New(t.Elem())
+	case reflect.Slice:
+		return reflect.MakeSlice(t, 0, 0)
+	case reflect.Map:
+		return reflect.MakeMap(t)
+	case reflect.Interface:
+		err := reflect.ValueOf(errors.New("mock error"))
+		if err.Type().AssignableTo(t) {
+			value := reflect.New(t).Elem()
+			value.Set(err)
+			return value
+		}
+	}
+	return v
+}
+
+// makeOutput mutates the return values of the function
+func makeOutput(ctx context.Context, function reflect.Type) []reflect.Value {
+	var out []reflect.Value
+	for i := 0; i < function.NumOut(); i++ {
+		// 参数是 interface
+		// function
+		// 别名
+		// 不行就放过，没有statement
+		resultType := function.Out(i)
+		switch resultType.Kind() {
+		// reflect.Zero new the value of addressable nor settable
+		// it make faker.GetValue(for pointer) fail
+		// so we to Mutate struct for pointer by reflectValue.Addr()
+		// see: https://halfrost.com/go_reflection/
+		case reflect.Ptr:
+			v := reflect.New(resultType.Elem()).Elem()
+			mutationElement := variablecard.VariableMutate(ctx, resultType.Elem(), v)
+			if mutationElement.CanAddr() {
+				out = append(out, variablecard.VariableMutate(ctx, resultType.Elem(), v).Addr())
+			} else {
+				var vNil interface{} = nil
+				out = append(out, reflect.ValueOf(vNil))
+			}
+
+		default:
+			v := reflect.Zero(resultType)
+			out = append(out, variablecard.VariableMutate(ctx, resultType, v))
+		}
+	}
+	return out
+}
+
 func GetPrivateFunc(m interface{}) (r interface{}) {
 	f := reflect.TypeOf(m)
 	// it's no receiver func
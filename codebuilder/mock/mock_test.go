@@ -1,8 +1,14 @@
 package mock
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/stretchr/testify/assert"
 )
 
 func Mock(name string) (int, error) {
@@ -59,3 +65,54 @@ func (s *s) Mock(name string) (int, error) {
 func TestMakeCall(t *testing.T) {
 
 }
+
+var errMissing = errors.New("missing")
+
+func TestMakeCall_Fault(t *testing.T) {
+	mockRender := &StatementRender{UsedMockFunc: map[string]int{}}
+	mockRender.Fault = &atgconstant.Fault{Func: "Mock", Result: 1, Code: "errMissing", Err: errMissing}
+	fake := MakeCall(context.Background(), "Mock", mockRender, Mock, atgconstant.UseGoMonkeyMock).(func(string) (int, error))
+	assert.False(t, mockRender.Called("Mock"))
+	for i := 0; i < 3; i++ {
+		got, err := fake("name")
+		assert.Equal(t, 0, got)
+		assert.Equal(t, errMissing, err)
+	}
+	assert.True(t, mockRender.Called("Mock"))
+	assert.Equal(t, []string{"0, errMissing"}, mockRender.MonkeyOutputMap["Mock"])
+
+	// the fault of the other function is not injected
+	mockRender = &StatementRender{UsedMockFunc: map[string]int{}}
+	mockRender.Fault = &atgconstant.Fault{Func: "Other", Result: 1, Code: "errMissing", Err: errMissing}
+	fake = MakeCall(context.Background(), "Mock", mockRender, Mock, atgconstant.UseGoMonkeyMock).(func(string) (int, error))
+	for i := 0; i < atgconstant.MaxMockOutputSeq; i++ {
+		_, err := fake("name")
+		assert.NotEqual(t, errMissing, err)
+	}
+}
+
+func TestMakeShapedOutput(t *testing.T) {
+	function := reflect.TypeOf(Mock)
+	shapes := []atgconstant.ReturnShape{{
+		{Kind: atgconstant.ResultConst, Code: "int64(7)", Value: int64(7)},
+		{Kind: atgconstant.ResultConst, Code: "errMissing", Value: errMissing},
+	}}
+	shaped := 0
+	for i := 0; i < 50; i++ {
+		out, codes := makeShapedOutput(context.Background(), function, shapes)
+		assert.Equal(t, 2, len(out))
+		if codes[1] == "" {
+			continue
+		}
+		shaped++
+		assert.Equal(t, []string{"int64(7)", "errMissing"}, codes)
+		assert.Equal(t, 7, out[0].Interface())
+		assert.Equal(t, errMissing, out[1].Interface())
+	}
+	assert.True(t, shaped > 0)
+
+	err := makeNonNil(reflect.TypeOf((*error)(nil)).Elem(), reflect.Zero(reflect.TypeOf((*error)(nil)).Elem()))
+	assert.NotNil(t, err.Interface())
+	assert.NotNil(t, makeNonNil(reflect.TypeOf(&s{}), reflect.Zero(reflect.TypeOf(&s{}))).Interface())
+	assert.Equal(t, 0, makeNonNil(reflect.TypeOf([]int{}), reflect.Zero(reflect.TypeOf([]int{}))).Len())
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Panic is what the tested function panics with. The empty one means the case does not panic.
+type Panic struct {
+	Value string
+	Type  string
+	// the frame which panics, e.g. github.com/a/dao.(*Store).Get at dao.go:30
+	Site string
+	// the case reproduces the panic as the bug instead of expecting it
+	reproduce bool
+}
+
+// Reproduce marks the panic as the bug which the case reproduces
+func (p Panic) Reproduce() Panic {
+	p.reproduce = true
+	return p
+}
+
+// ValueToCode renders the panic in the test suite
+func (p Panic) ValueToCode() string {
+	if p.Site == "" {
+		return "mockfunc.Panic{}"
+	}
+	code := fmt.Sprintf("mockfunc.Panic{Value: %q, Type: %q, Site: %q}", p.Value, p.Type, p.Site)
+	if p.reproduce {
+		code = fmt.Sprintf("mockfunc.Panic{\n// TODO: the tested function panics at %s, fix it and remove the skip\nValue: %q, Type: %q, Site: %q}", p.Site, p.Value, p.Type, p.Site)
+	}
+	return code
+}
+
+// DiffPanic calls the function and returns the difference between what it panics with and the wanted panic,
+// empty if they are the same
+func DiffPanic(f func(), want Panic) (diff string) {
+	defer func() {
+		value := recover()
+		switch {
+		case value == nil:
+			diff = fmt.Sprintf("want the panic %q at %s, but there is none", want.Value, want.Site)
+		case fmt.Sprintf("%T", value) != want.Type:
+			diff = fmt.Sprintf("want the panic of %s, but got %T: %v", want.Type, value, value)
+		case fmt.Sprint(value) != want.Value:
+			diff = fmt.Sprintf("want the panic %q, but got %q", want.Value, fmt.Sprint(value))
+		}
+	}()
+	f()
+	return ""
+}
+
+// Panics records the panics which the instrumented functions recover, keyed by the function
+type Panics struct {
+	lock   sync.Mutex
+	panics map[string]Panic
+}
+
+// Record is the panic hook of the instrumented functions
+func (p *Panics) Record(funcName string, value interface{}, stack []byte) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.panics == nil {
+		p.panics = make(map[string]Panic)
+	}
+	p.panics[funcName] = Panic{Value: fmt.Sprint(value), Type: fmt.Sprintf("%T", value), Site: PanicSite(stack)}
+}
+
+// Take returns the last panic of the function and forgets it
+func (p *Panics) Take(funcName string) (Panic, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	panicked, ok := p.panics[funcName]
+	delete(p.panics, funcName)
+	return panicked, ok
+}
+
+// PanicSite finds the frame which panics in the stack of runtime/debug.Stack, that is the first frame after panic
+// besides the runtime ones
+func PanicSite(stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	panicked := false
+	// the first line is the goroutine, then each frame is the function and the file
+	for i := 1; i+1 < len(lines); i += 2 {
+		function := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(function, "panic(") {
+			panicked = true
+			continue
+		}
+		if !panicked || strings.HasPrefix(function, "runtime.") {
+			continue
+		}
+		if index := strings.LastIndex(function, "("); index > 0 {
+			function = function[:index]
+		}
+		file := strings.TrimSpace(lines[i+1])
+		if index := strings.LastIndex(file, " +0x"); index > 0 {
+			file = file[:index]
+		}
+		return fmt.Sprintf("%s at %s", function, filepath.Base(file))
+	}
+	return ""
+}
+
+// SelectPanics keeps the first case of each panic site besides the available ones. sites are the panic sites of the
+// cases, empty for the case which does not panic.
+func SelectPanics(available []bool, sites []string) []bool {
+	selected := make([]bool, len(available))
+	seen := map[string]bool{}
+	for i := range available {
+		if i >= len(sites) || sites[i] == "" {
+			selected[i] = available[i]
+			continue
+		}
+		selected[i] = !seen[sites[i]]
+		seen[sites[i]] = true
+	}
+	return selected
+}
@@ -0,0 +1,51 @@
+package mock
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type store struct {
+	items map[string]*item
+}
+
+func (s *store) name(key string) string {
+	return s.items[key].Name
+}
+
+func TestPanics(t *testing.T) {
+	panics := &Panics{}
+	func() {
+		defer func() {
+			if err := recover(); err != nil {
+				panics.Record("*storename", err, debug.Stack())
+			}
+		}()
+		(&store{}).name("a")
+	}()
+	_, ok := panics.Take("name")
+	assert.False(t, ok)
+	panicked, ok := panics.Take("*storename")
+	assert.True(t, ok)
+	assert.Equal(t, "runtime error: invalid memory address or nil pointer dereference", panicked.Value)
+	assert.Equal(t, "runtime.errorString", strings.TrimPrefix(panicked.Type, "*"))
+	assert.True(t, strings.HasSuffix(panicked.Site, "mock.(*store).name at panics_test.go:16"), panicked.Site)
+	_, ok = panics.Take("*storename")
+	assert.False(t, ok)
+
+	assert.Empty(t, DiffPanic(func() { (&store{}).name("a") }, panicked))
+	assert.NotEmpty(t, DiffPanic(func() { (&store{items: map[string]*item{"a": {}}}).name("a") }, panicked))
+	assert.NotEmpty(t, DiffPanic(func() { panic("a") }, panicked))
+
+	assert.Equal(t, "mockfunc.Panic{}", Panic{}.ValueToCode())
+	assert.Contains(t, panicked.Reproduce().ValueToCode(), "// TODO: the tested function panics at "+panicked.Site)
+}
+
+func TestSelectPanics(t *testing.T) {
+	assert.Equal(t, []bool{true, false, true, false, true},
+		SelectPanics([]bool{true, false, false, false, false}, []string{"", "", "a", "a", "b"}))
+	assert.Equal(t, []bool{true, false}, SelectPanics([]bool{true, false}, nil))
+}
@@ -0,0 +1,731 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RedisData is the data which the redis stand-in holds before the tested function runs
+type RedisData struct {
+	Strings map[string]string
+	Hashes  map[string]map[string]string
+}
+
+// ValueToCode renders the data in the test suite
+func (d RedisData) ValueToCode() string {
+	builder := strings.Builder{}
+	builder.WriteString("mockfunc.RedisData{")
+	if len(d.Strings) != 0 {
+		builder.WriteString("Strings: map[string]string{")
+		for _, key := range sortedKeys(d.Strings) {
+			builder.WriteString(fmt.Sprintf("\n%q: %q,", key, d.Strings[key]))
+		}
+		builder.WriteString("},")
+	}
+	if len(d.Hashes) != 0 {
+		builder.WriteString("Hashes: map[string]map[string]string{")
+		keys := make([]string, 0, len(d.Hashes))
+		for key := range d.Hashes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("\n%q: {", key))
+			for _, field := range sortedKeys(d.Hashes[key]) {
+				builder.WriteString(fmt.Sprintf("%q: %q,", field, d.Hashes[key][field]))
+			}
+			builder.WriteString("},")
+		}
+		builder.WriteString("},")
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// redisHitRates are the chances that a key read by the tested function is in the cache
+var redisHitRates = []float64{0, 0.5, 1}
+
+var (
+	// redisTurnLock makes the stand-ins take turns, because the clients dial the current one
+	redisTurnLock sync.Mutex
+	redisLock     sync.Mutex
+	redisCurrent  *RedisStandIn
+)
+
+// RedisStandIn is the in-process redis server which the clients built with DialRedis talk to.
+// It serves the strings, the hashes and the common commands of them.
+type RedisStandIn struct {
+	lock    sync.Mutex
+	strings map[string]string
+	hashes  map[string]map[string]string
+	ttl     map[string]int64
+	// the middle code generates the keys which the tested function reads first,
+	// and the final suite preloads them
+	generate  bool
+	hitRate   float64
+	touched   map[string]bool
+	preloaded RedisData
+	once      sync.Once
+}
+
+// StandInRedis starts serving the data. Close stops it, and the stand-ins of the concurrent tests wait for each other.
+func StandInRedis(data RedisData) *RedisStandIn {
+	s := &RedisStandIn{
+		strings: map[string]string{},
+		hashes:  map[string]map[string]string{},
+		ttl:     map[string]int64{},
+		touched: map[string]bool{},
+	}
+	for key, value := range data.Strings {
+		s.strings[key] = value
+	}
+	for key, hash := range data.Hashes {
+		s.hashes[key] = map[string]string{}
+		for field, value := range hash {
+			s.hashes[key][field] = value
+		}
+	}
+	redisTurnLock.Lock()
+	redisLock.Lock()
+	redisCurrent = s
+	redisLock.Unlock()
+	return s
+}
+
+// RandomRedis starts serving the empty data. When the tested function reads a key before writing it,
+// the key is a hit or a miss by chance, and the hit is recorded by Preloaded.
+func RandomRedis() *RedisStandIn {
+	s := StandInRedis(RedisData{})
+	s.generate = true
+	s.hitRate = redisHitRates[rand.Intn(len(redisHitRates))]
+	s.preloaded = RedisData{Strings: map[string]string{}, Hashes: map[string]map[string]string{}}
+	return s
+}
+
+// Preloaded returns the keys which the stand-in generated for the hits
+func (s *RedisStandIn) Preloaded() RedisData {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.preloaded
+}
+
+// Close stops serving the data
+func (s *RedisStandIn) Close() {
+	s.once.Do(func() {
+		redisLock.Lock()
+		if redisCurrent == s {
+			redisCurrent = nil
+		}
+		redisLock.Unlock()
+		redisTurnLock.Unlock()
+	})
+}
+
+// DialRedis is the Dialer of the redis client options, e.g.
+// redis.NewClient(&redis.Options{Addr: "stand-in", Dialer: mockfunc.DialRedis})
+func DialRedis(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, server := net.Pipe()
+	go serveRedis(server)
+	return client, nil
+}
+
+func currentRedis() *RedisStandIn {
+	redisLock.Lock()
+	defer redisLock.Unlock()
+	return redisCurrent
+}
+
+// serveRedis reads the commands while the replies are written, because the pipe has no buffer
+// and the client writes the whole pipeline before it reads.
+func serveRedis(conn net.Conn) {
+	replies := make(chan []byte, 1024)
+	go func() {
+		for reply := range replies {
+			if _, err := conn.Write(reply); err != nil {
+				break
+			}
+		}
+		conn.Close()
+		for range replies {
+		}
+	}()
+	defer close(replies)
+	reader := bufio.NewReader(conn)
+	var queued [][]string
+	inMulti := false
+	for {
+		args, err := readRedisCommand(reader)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		name := strings.ToUpper(args[0])
+		switch {
+		case name == "QUIT":
+			replies <- redisSimple("OK")
+			return
+		case name == "MULTI":
+			inMulti, queued = true, nil
+			replies <- redisSimple("OK")
+		case name == "DISCARD":
+			inMulti, queued = false, nil
+			replies <- redisSimple("OK")
+		case name == "EXEC":
+			results := make([][]byte, 0, len(queued))
+			for _, command := range queued {
+				results = append(results, execRedis(command))
+			}
+			inMulti, queued = false, nil
+			replies <- redisArray(results)
+		case inMulti:
+			queued = append(queued, args)
+			replies <- redisSimple("QUEUED")
+		default:
+			replies <- execRedis(args)
+		}
+	}
+}
+
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := readRedisLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		// the inline command, e.g. PING
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		line, err = readRedisLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, fmt.Errorf("unexpected %q in the command", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func readRedisLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func redisSimple(s string) []byte {
+	return []byte("+" + s + "\r\n")
+}
+
+func redisError(s string) []byte {
+	return []byte("-" + s + "\r\n")
+}
+
+func redisInteger(n int64) []byte {
+	return []byte(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func redisBulk(s string) []byte {
+	return []byte("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+func redisNil() []byte {
+	return []byte("$-1\r\n")
+}
+
+func redisArray(items [][]byte) []byte {
+	res := []byte("*" + strconv.Itoa(len(items)) + "\r\n")
+	for _, item := range items {
+		res = append(res, item...)
+	}
+	return res
+}
+
+var (
+	redisWrongType   = redisError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	redisNotInteger  = redisError("ERR value is not an integer or out of range")
+	redisSyntaxError = redisError("ERR syntax error")
+)
+
+func execRedis(args []string) []byte {
+	s := currentRedis()
+	if s == nil {
+		return redisError("ERR the redis stand-in is closed")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.exec(strings.ToUpper(args[0]), args[1:])
+}
+
+// arity is the number of the arguments after the command name, negative means at least -arity
+var redisArity = map[string]int{
+	"GET": 1, "SET": -2, "SETNX": 2, "SETEX": 3, "PSETEX": 3, "GETSET": 2, "MGET": -1, "MSET": -2, "DEL": -1,
+	"UNLINK": -1, "EXISTS": -1, "EXPIRE": 2, "PEXPIRE": 2, "TTL": 1, "PTTL": 1, "PERSIST": 1, "TYPE": 1,
+	"INCR": 1, "DECR": 1, "INCRBY": 2, "DECRBY": 2, "HGET": 2, "HSET": -3, "HMSET": -3, "HSETNX": 3,
+	"HGETALL": 1, "HMGET": -2, "HDEL": -2, "HEXISTS": 2, "HLEN": 1, "HINCRBY": 3, "HKEYS": 1, "HVALS": 1,
+}
+
+func (s *RedisStandIn) exec(name string, args []string) []byte {
+	switch name {
+	case "PING":
+		if len(args) > 0 {
+			return redisBulk(args[0])
+		}
+		return redisSimple("PONG")
+	case "ECHO":
+		if len(args) != 1 {
+			return redisSyntaxError
+		}
+		return redisBulk(args[0])
+	case "SELECT", "AUTH", "CLIENT", "READONLY", "WATCH", "UNWATCH":
+		return redisSimple("OK")
+	}
+	arity, ok := redisArity[name]
+	if !ok {
+		// HELLO is unknown as well, so that the client speaks RESP2
+		return redisError(fmt.Sprintf("ERR unknown command '%s'", strings.ToLower(name)))
+	}
+	if (arity >= 0 && len(args) != arity) || (arity < 0 && len(args) < -arity) {
+		return redisError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+	}
+	switch name {
+	case "GET":
+		s.read(args[0], false)
+		if _, ok := s.hashes[args[0]]; ok {
+			return redisWrongType
+		}
+		return s.getString(args[0])
+	case "GETSET":
+		s.read(args[0], false)
+		if _, ok := s.hashes[args[0]]; ok {
+			return redisWrongType
+		}
+		res := s.getString(args[0])
+		s.setString(args[0], args[1])
+		return res
+	case "SET":
+		return s.set(args)
+	case "SETNX":
+		s.touch(args[0])
+		if s.exists(args[0]) {
+			return redisInteger(0)
+		}
+		s.setString(args[0], args[1])
+		return redisInteger(1)
+	case "SETEX", "PSETEX":
+		ttl, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || ttl <= 0 {
+			return redisNotInteger
+		}
+		s.touch(args[0])
+		s.setString(args[0], args[2])
+		if name == "SETEX" {
+			ttl *= 1000
+		}
+		s.ttl[args[0]] = ttl
+		return redisSimple("OK")
+	case "MGET":
+		items := make([][]byte, 0, len(args))
+		for _, key := range args {
+			s.read(key, false)
+			items = append(items, s.getString(key))
+		}
+		return redisArray(items)
+	case "MSET":
+		if len(args)%2 != 0 {
+			return redisError("ERR wrong number of arguments for 'mset' command")
+		}
+		for i := 0; i < len(args); i += 2 {
+			s.touch(args[i])
+			s.setString(args[i], args[i+1])
+		}
+		return redisSimple("OK")
+	case "DEL", "UNLINK":
+		var n int64
+		for _, key := range args {
+			s.touch(key)
+			if s.exists(key) {
+				s.delete(key)
+				n++
+			}
+		}
+		return redisInteger(n)
+	case "EXISTS":
+		var n int64
+		for _, key := range args {
+			s.read(key, false)
+			if s.exists(key) {
+				n++
+			}
+		}
+		return redisInteger(n)
+	case "EXPIRE", "PEXPIRE":
+		ttl, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return redisNotInteger
+		}
+		s.touch(args[0])
+		if !s.exists(args[0]) {
+			return redisInteger(0)
+		}
+		if name == "EXPIRE" {
+			ttl *= 1000
+		}
+		if ttl <= 0 {
+			s.delete(args[0])
+		} else {
+			s.ttl[args[0]] = ttl
+		}
+		return redisInteger(1)
+	case "TTL", "PTTL":
+		s.read(args[0], false)
+		if !s.exists(args[0]) {
+			return redisInteger(-2)
+		}
+		ttl, ok := s.ttl[args[0]]
+		if !ok {
+			return redisInteger(-1)
+		}
+		if name == "TTL" {
+			ttl = (ttl + 999) / 1000
+		}
+		return redisInteger(ttl)
+	case "PERSIST":
+		s.touch(args[0])
+		if _, ok := s.ttl[args[0]]; !ok || !s.exists(args[0]) {
+			return redisInteger(0)
+		}
+		delete(s.ttl, args[0])
+		return redisInteger(1)
+	case "TYPE":
+		s.read(args[0], false)
+		if _, ok := s.strings[args[0]]; ok {
+			return redisSimple("string")
+		}
+		if _, ok := s.hashes[args[0]]; ok {
+			return redisSimple("hash")
+		}
+		return redisSimple("none")
+	case "INCR", "DECR", "INCRBY", "DECRBY":
+		delta := int64(1)
+		if len(args) == 2 {
+			var err error
+			if delta, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				return redisNotInteger
+			}
+		}
+		if strings.HasPrefix(name, "DECR") {
+			delta = -delta
+		}
+		s.touch(args[0])
+		if _, ok := s.hashes[args[0]]; ok {
+			return redisWrongType
+		}
+		value, err := strconv.ParseInt(s.stringOr(args[0], "0"), 10, 64)
+		if err != nil {
+			return redisNotInteger
+		}
+		value += delta
+		s.strings[args[0]] = strconv.FormatInt(value, 10)
+		return redisInteger(value)
+	}
+	return s.execHash(name, args)
+}
+
+func (s *RedisStandIn) execHash(name string, args []string) []byte {
+	key := args[0]
+	if _, ok := s.strings[key]; ok {
+		return redisWrongType
+	}
+	switch name {
+	case "HGET":
+		s.read(key, true, args[1])
+		value, ok := s.hashes[key][args[1]]
+		if !ok {
+			return redisNil()
+		}
+		return redisBulk(value)
+	case "HMGET":
+		s.read(key, true, args[1:]...)
+		items := make([][]byte, 0, len(args)-1)
+		for _, field := range args[1:] {
+			if value, ok := s.hashes[key][field]; ok {
+				items = append(items, redisBulk(value))
+			} else {
+				items = append(items, redisNil())
+			}
+		}
+		return redisArray(items)
+	case "HGETALL", "HKEYS", "HVALS":
+		s.read(key, true)
+		hash := s.hashes[key]
+		items := make([][]byte, 0, 2*len(hash))
+		for _, field := range sortedKeys(hash) {
+			if name != "HVALS" {
+				items = append(items, redisBulk(field))
+			}
+			if name != "HKEYS" {
+				items = append(items, redisBulk(hash[field]))
+			}
+		}
+		return redisArray(items)
+	case "HSET", "HMSET":
+		if len(args)%2 != 1 {
+			return redisError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+		}
+		s.touch(key)
+		var n int64
+		for i := 1; i < len(args); i += 2 {
+			if _, ok := s.hashes[key][args[i]]; !ok {
+				n++
+			}
+			s.setField(key, args[i], args[i+1])
+		}
+		if name == "HMSET" {
+			return redisSimple("OK")
+		}
+		return redisInteger(n)
+	case "HSETNX":
+		s.touch(key)
+		if _, ok := s.hashes[key][args[1]]; ok {
+			return redisInteger(0)
+		}
+		s.setField(key, args[1], args[2])
+		return redisInteger(1)
+	case "HDEL":
+		s.touch(key)
+		var n int64
+		for _, field := range args[1:] {
+			if _, ok := s.hashes[key][field]; ok {
+				delete(s.hashes[key], field)
+				n++
+			}
+		}
+		if len(s.hashes[key]) == 0 {
+			s.delete(key)
+		}
+		return redisInteger(n)
+	case "HEXISTS":
+		s.read(key, true, args[1])
+		if _, ok := s.hashes[key][args[1]]; ok {
+			return redisInteger(1)
+		}
+		return redisInteger(0)
+	case "HLEN":
+		s.read(key, true)
+		return redisInteger(int64(len(s.hashes[key])))
+	case "HINCRBY":
+		delta, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return redisNotInteger
+		}
+		s.touch(key)
+		current := "0"
+		if value, ok := s.hashes[key][args[1]]; ok {
+			current = value
+		}
+		value, err := strconv.ParseInt(current, 10, 64)
+		if err != nil {
+			return redisError("ERR hash value is not an integer")
+		}
+		value += delta
+		s.setField(key, args[1], strconv.FormatInt(value, 10))
+		return redisInteger(value)
+	}
+	return redisError(fmt.Sprintf("ERR unknown command '%s'", strings.ToLower(name)))
+}
+
+// set handles SET key value [EX seconds|PX milliseconds|KEEPTTL] [NX|XX] [GET]
+func (s *RedisStandIn) set(args []string) []byte {
+	key := args[0]
+	var ttl int64
+	nx, xx, keepTTL, get := false, false, false, false
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "KEEPTTL":
+			keepTTL = true
+		case "GET":
+			get = true
+		case "EX", "PX":
+			if i+1 >= len(args) {
+				return redisSyntaxError
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return redisNotInteger
+			}
+			if strings.ToUpper(args[i]) == "EX" {
+				n *= 1000
+			}
+			ttl = n
+			i++
+		default:
+			return redisSyntaxError
+		}
+	}
+	if get {
+		s.read(key, false)
+	} else {
+		s.touch(key)
+	}
+	if _, ok := s.hashes[key]; ok && get {
+		return redisWrongType
+	}
+	old := redisNil()
+	if get {
+		old = s.getString(key)
+	}
+	if (nx && s.exists(key)) || (xx && !s.exists(key)) {
+		if get {
+			return old
+		}
+		return redisNil()
+	}
+	previousTTL, hasTTL := s.ttl[key]
+	s.delete(key)
+	s.strings[key] = args[1]
+	if ttl > 0 {
+		s.ttl[key] = ttl
+	} else if keepTTL && hasTTL {
+		s.ttl[key] = previousTTL
+	}
+	if get {
+		return old
+	}
+	return redisSimple("OK")
+}
+
+func (s *RedisStandIn) exists(key string) bool {
+	_, isString := s.strings[key]
+	_, isHash := s.hashes[key]
+	return isString || isHash
+}
+
+func (s *RedisStandIn) getString(key string) []byte {
+	value, ok := s.strings[key]
+	if !ok {
+		return redisNil()
+	}
+	return redisBulk(value)
+}
+
+func (s *RedisStandIn) stringOr(key string, defaultValue string) string {
+	if value, ok := s.strings[key]; ok {
+		return value
+	}
+	return defaultValue
+}
+
+func (s *RedisStandIn) setString(key string, value string) {
+	delete(s.hashes, key)
+	delete(s.ttl, key)
+	s.strings[key] = value
+}
+
+func (s *RedisStandIn) setField(key string, field string, value string) {
+	if s.hashes[key] == nil {
+		s.hashes[key] = map[string]string{}
+	}
+	s.hashes[key][field] = value
+}
+
+func (s *RedisStandIn) delete(key string) {
+	delete(s.strings, key)
+	delete(s.hashes, key)
+	delete(s.ttl, key)
+}
+
+func (s *RedisStandIn) touch(key string) {
+	s.touched[key] = true
+}
+
+// read generates the key by chance if the tested function reads it before touching it
+func (s *RedisStandIn) read(key string, hash bool, fields ...string) {
+	if s.touched[key] {
+		return
+	}
+	s.touched[key] = true
+	if !s.generate || s.exists(key) || rand.Float64() >= s.hitRate {
+		return
+	}
+	if !hash {
+		value := randomRedisValue()
+		s.strings[key] = value
+		s.preloaded.Strings[key] = value
+		return
+	}
+	if len(fields) == 0 {
+		fields = []string{"id", "name"}
+	}
+	s.preloaded.Hashes[key] = map[string]string{}
+	for _, field := range fields {
+		value := randomRedisValue()
+		s.setField(key, field, value)
+		s.preloaded.Hashes[key][field] = value
+	}
+}
+
+// randomRedisValue returns the cached JSON, the number or the word
+func randomRedisValue() string {
+	switch rand.Intn(3) {
+	case 0:
+		return fmt.Sprintf(`{"id":%d,"name":"%s"}`, rand.Intn(100000), randomName())
+	case 1:
+		return strconv.Itoa(rand.Intn(1000))
+	}
+	return randomName()
+}
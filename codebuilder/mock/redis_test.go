@@ -0,0 +1,98 @@
+package mock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// redisClient sends the commands in a pipeline and reads the replies as the lines
+type redisClient struct {
+	t      *testing.T
+	reader *bufio.Reader
+	send   func(...string)
+}
+
+func newRedisClient(t *testing.T) *redisClient {
+	conn, err := DialRedis(context.Background(), "tcp", "stand-in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &redisClient{t: t, reader: bufio.NewReader(conn), send: func(args ...string) {
+		command := fmt.Sprintf("*%d\r\n", len(args))
+		for _, arg := range args {
+			command += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+		}
+		if _, err := conn.Write([]byte(command)); err != nil {
+			t.Fatal(err)
+		}
+	}}
+}
+
+func (c *redisClient) lines(n int) []string {
+	res := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		res = append(res, strings.TrimRight(line, "\r\n"))
+	}
+	return res
+}
+
+func TestStandInRedis(t *testing.T) {
+	convey.Convey("TestStandInRedis", t, func() {
+		standIn := StandInRedis(RedisData{
+			Strings: map[string]string{"user:1": `{"id":1}`},
+			Hashes:  map[string]map[string]string{"profile:1": {"name": "tom"}},
+		})
+		defer standIn.Close()
+		client := newRedisClient(t)
+		// the pipeline is written before the replies are read
+		client.send("GET", "user:1")
+		client.send("GET", "user:2")
+		client.send("SET", "user:2", "v", "EX", "60", "NX")
+		client.send("TTL", "user:2")
+		client.send("INCR", "counter")
+		client.send("HGET", "profile:1", "name")
+		client.send("GET", "profile:1")
+		client.send("HELLO", "3")
+		convey.So(client.lines(10), convey.ShouldResemble, []string{
+			"$8", `{"id":1}`, "$-1", "+OK", ":60", ":1", "$3", "tom",
+			"-WRONGTYPE Operation against a key holding the wrong kind of value",
+			"-ERR unknown command 'hello'",
+		})
+		client.send("MULTI")
+		client.send("DEL", "user:1", "user:3")
+		client.send("EXEC")
+		convey.So(client.lines(4), convey.ShouldResemble, []string{"+OK", "+QUEUED", "*1", ":1"})
+	})
+}
+
+func TestRandomRedis(t *testing.T) {
+	convey.Convey("TestRandomRedis", t, func() {
+		standIn := RandomRedis()
+		standIn.hitRate = 1
+		client := newRedisClient(t)
+		client.send("SET", "written", "1")
+		client.send("GET", "written")
+		client.send("HGET", "profile:1", "name")
+		client.lines(5)
+		client.send("EXISTS", "user:1")
+		convey.So(client.lines(1), convey.ShouldResemble, []string{":1"})
+		preloaded := standIn.Preloaded()
+		standIn.Close()
+		// the keys written before read are not preloaded
+		convey.So(len(preloaded.Strings), convey.ShouldEqual, 1)
+		convey.So(preloaded.Strings, convey.ShouldContainKey, "user:1")
+		convey.So(preloaded.Hashes["profile:1"], convey.ShouldContainKey, "name")
+		code := RedisData{Strings: map[string]string{"b": "2", "a": "1"}, Hashes: map[string]map[string]string{"h": {"f": "v"}}}.ValueToCode()
+		convey.So(code, convey.ShouldEqual, "mockfunc.RedisData{Strings: map[string]string{\n\"a\": \"1\",\n\"b\": \"2\",},Hashes: map[string]map[string]string{\n\"h\": {\"f\": \"v\",},},}")
+	})
+}
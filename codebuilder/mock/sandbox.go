@@ -0,0 +1,205 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Sandbox is the environment and the files which a test case runs with. The keys in Unset are removed
+// from the environment, and the files are written in the working directory of the test case.
+type Sandbox struct {
+	Env   map[string]string
+	Unset []string
+	Files map[string]string
+}
+
+// ValueToCode renders the sandbox in the test suite
+func (s Sandbox) ValueToCode() string {
+	builder := strings.Builder{}
+	builder.WriteString("mockfunc.Sandbox{")
+	if len(s.Env) != 0 {
+		builder.WriteString("Env: map[string]string{")
+		for _, key := range sortedKeys(s.Env) {
+			builder.WriteString(fmt.Sprintf("\n%q: %q,", key, s.Env[key]))
+		}
+		builder.WriteString("},")
+	}
+	if len(s.Unset) != 0 {
+		builder.WriteString(fmt.Sprintf("Unset: %#v,", s.Unset))
+	}
+	if len(s.Files) != 0 {
+		builder.WriteString("Files: map[string]string{")
+		for _, path := range sortedKeys(s.Files) {
+			builder.WriteString(fmt.Sprintf("\n%q: %q,", path, s.Files[path]))
+		}
+		builder.WriteString("},")
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// RandomSandbox sets or removes the environment keys and writes or leaves out the files by chance.
+// The values are generated by the names, e.g. a port for APP_PORT and a JSON object for conf/app.json.
+func RandomSandbox(envKeys []string, files []string) Sandbox {
+	s := Sandbox{Env: map[string]string{}, Files: map[string]string{}}
+	for _, key := range envKeys {
+		if rand.Intn(4) == 0 {
+			s.Unset = append(s.Unset, key)
+			continue
+		}
+		s.Env[key] = randomEnvValue(key)
+	}
+	sort.Strings(s.Unset)
+	for _, path := range files {
+		if rand.Intn(4) == 0 {
+			continue
+		}
+		s.Files[path] = randomFileContent(path)
+	}
+	return s
+}
+
+func randomEnvValue(key string) string {
+	key = strings.ToUpper(key)
+	switch {
+	case strings.Contains(key, "PORT"):
+		return fmt.Sprint(1024 + rand.Intn(64511))
+	case strings.Contains(key, "URL") || strings.Contains(key, "ENDPOINT"):
+		return fmt.Sprintf("http://127.0.0.1:%d/%s", 1024+rand.Intn(64511), randomName())
+	case strings.Contains(key, "HOST") || strings.Contains(key, "ADDR"):
+		return "127.0.0.1"
+	case strings.Contains(key, "DEBUG") || strings.Contains(key, "ENABLE") || strings.Contains(key, "DISABLE"):
+		return fmt.Sprint(rand.Intn(2) == 0)
+	case strings.Contains(key, "TIMEOUT") || strings.Contains(key, "INTERVAL"):
+		return fmt.Sprintf("%ds", rand.Intn(60)+1)
+	case strings.Contains(key, "ENV") || strings.Contains(key, "STAGE"):
+		return []string{"dev", "test", "prod"}[rand.Intn(3)]
+	case strings.Contains(key, "NUM") || strings.Contains(key, "SIZE") || strings.Contains(key, "COUNT") || strings.Contains(key, "LIMIT"):
+		return fmt.Sprint(rand.Intn(100))
+	}
+	return randomName()
+}
+
+func randomFileContent(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return fmt.Sprintf(`{"name":"%s","port":%d,"debug":%v}`, randomName(), 1024+rand.Intn(64511), rand.Intn(2) == 0)
+	case ".yaml", ".yml":
+		return fmt.Sprintf("name: %s\nport: %d\ndebug: %v\n", randomName(), 1024+rand.Intn(64511), rand.Intn(2) == 0)
+	case ".toml", ".ini", ".conf":
+		return fmt.Sprintf("name = \"%s\"\nport = %d\n", randomName(), 1024+rand.Intn(64511))
+	case ".csv":
+		return fmt.Sprintf("id,name\n%d,%s\n", rand.Intn(1000), randomName())
+	}
+	return randomName()
+}
+
+// sandboxLock makes the sandboxes take turns, because the environment and the working directory
+// belong to the process. The middle code runs the tests in parallel, so that t.Setenv cannot be used.
+// The other functions of the file hold the read lock meanwhile, so that they never see a sandbox.
+// The final suite does not run the cases of the file with a sandbox in parallel.
+var sandboxLock sync.RWMutex
+
+// OutsideSandbox keeps the sandboxes away until release is called
+func OutsideSandbox() (release func()) {
+	sandboxLock.RLock()
+	return sandboxLock.RUnlock
+}
+
+// SandboxScope restores the environment and the working directory when the test case finishes
+type SandboxScope struct {
+	Dir  string
+	env  map[string]*string
+	wd   string
+	once sync.Once
+}
+
+// EnterSandbox applies the environment, writes the files in a t.TempDir and changes the working directory to it
+// until Close. The test fails if the sandbox cannot be set up.
+func EnterSandbox(t testing.TB, sandbox Sandbox) *SandboxScope {
+	t.Helper()
+	sandboxLock.Lock()
+	s := &SandboxScope{Dir: t.TempDir(), env: map[string]*string{}}
+	fatal := func(err error) {
+		s.Close()
+		t.Fatalf("enter the sandbox: %v", err)
+	}
+	var err error
+	if s.wd, err = os.Getwd(); err != nil {
+		fatal(err)
+	}
+	for key, value := range sandbox.Env {
+		s.save(key)
+		if err = os.Setenv(key, value); err != nil {
+			fatal(err)
+		}
+	}
+	for _, key := range sandbox.Unset {
+		s.save(key)
+		if err = os.Unsetenv(key); err != nil {
+			fatal(err)
+		}
+	}
+	for path, content := range sandbox.Files {
+		target := filepath.Join(s.Dir, filepath.FromSlash(path))
+		if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			fatal(err)
+		}
+		if err = os.WriteFile(target, []byte(content), 0644); err != nil {
+			fatal(err)
+		}
+	}
+	if err = os.Chdir(s.Dir); err != nil {
+		fatal(err)
+	}
+	return s
+}
+
+func (s *SandboxScope) save(key string) {
+	if _, ok := s.env[key]; ok {
+		return
+	}
+	if value, ok := os.LookupEnv(key); ok {
+		s.env[key] = &value
+	} else {
+		s.env[key] = nil
+	}
+}
+
+// Close restores the environment and the working directory
+func (s *SandboxScope) Close() {
+	s.once.Do(func() {
+		for key, value := range s.env {
+			if value == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *value)
+			}
+		}
+		if s.wd != "" {
+			os.Chdir(s.wd)
+		}
+		sandboxLock.Unlock()
+	})
+}
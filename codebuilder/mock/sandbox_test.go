@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestEnterSandbox(t *testing.T) {
+	convey.Convey("TestEnterSandbox", t, func() {
+		os.Setenv("SANDBOX_TEST_KEEP", "origin")
+		os.Unsetenv("SANDBOX_TEST_NEW")
+		defer os.Unsetenv("SANDBOX_TEST_KEEP")
+		wd, _ := os.Getwd()
+		sandbox := EnterSandbox(t, Sandbox{
+			Env:   map[string]string{"SANDBOX_TEST_NEW": "8080"},
+			Unset: []string{"SANDBOX_TEST_KEEP"},
+			Files: map[string]string{"conf/app.yaml": "port: 8080\n"},
+		})
+		convey.So(os.Getenv("SANDBOX_TEST_NEW"), convey.ShouldEqual, "8080")
+		_, ok := os.LookupEnv("SANDBOX_TEST_KEEP")
+		convey.So(ok, convey.ShouldBeFalse)
+		data, err := os.ReadFile(filepath.Join("conf", "app.yaml"))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(string(data), convey.ShouldEqual, "port: 8080\n")
+		convey.So(os.WriteFile("out.txt", nil, 0644), convey.ShouldBeNil)
+		sandbox.Close()
+		sandbox.Close()
+		current, _ := os.Getwd()
+		convey.So(current, convey.ShouldEqual, wd)
+		convey.So(os.Getenv("SANDBOX_TEST_KEEP"), convey.ShouldEqual, "origin")
+		_, ok = os.LookupEnv("SANDBOX_TEST_NEW")
+		convey.So(ok, convey.ShouldBeFalse)
+		_, err = os.Stat("out.txt")
+		convey.So(os.IsNotExist(err), convey.ShouldBeTrue)
+	})
+}
+
+// fatalRecorder stops the goroutine at Fatalf like testing.T and keeps the message
+type fatalRecorder struct {
+	testing.TB
+	fatal string
+}
+
+func (f *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	f.fatal = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func TestEnterSandboxFails(t *testing.T) {
+	convey.Convey("TestEnterSandboxFails", t, func() {
+		wd, _ := os.Getwd()
+		recorder := &fatalRecorder{TB: t}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			// a is both the file and the directory
+			EnterSandbox(recorder, Sandbox{
+				Env:   map[string]string{"SANDBOX_TEST_FAIL": "1"},
+				Files: map[string]string{"a": "a", "a/b": "b"},
+			})
+		}()
+		<-done
+		convey.So(recorder.fatal, convey.ShouldStartWith, "enter the sandbox: ")
+		// the sandbox is left and the next one can enter
+		current, _ := os.Getwd()
+		convey.So(current, convey.ShouldEqual, wd)
+		_, ok := os.LookupEnv("SANDBOX_TEST_FAIL")
+		convey.So(ok, convey.ShouldBeFalse)
+		EnterSandbox(t, Sandbox{}).Close()
+	})
+}
+
+func TestOutsideSandbox(t *testing.T) {
+	convey.Convey("TestOutsideSandbox", t, func() {
+		release := OutsideSandbox()
+		// the other cases outside the sandbox keep running
+		OutsideSandbox()()
+		entered := make(chan struct{})
+		go func() {
+			EnterSandbox(t, Sandbox{}).Close()
+			close(entered)
+		}()
+		select {
+		case <-entered:
+			t.Fatal("the sandbox is entered while another case runs outside it")
+		case <-time.After(50 * time.Millisecond):
+		}
+		release()
+		<-entered
+	})
+}
+
+func TestRandomSandbox(t *testing.T) {
+	convey.Convey("TestRandomSandbox", t, func() {
+		for i := 0; i < 20; i++ {
+			s := RandomSandbox([]string{"APP_PORT", "APP_ENV"}, []string{"conf/app.json"})
+			convey.So(len(s.Env)+len(s.Unset), convey.ShouldEqual, 2)
+			if content, ok := s.Files["conf/app.json"]; ok {
+				convey.So(content, convey.ShouldStartWith, `{"name":"`)
+			}
+		}
+		s := Sandbox{Env: map[string]string{"APP_PORT": "80"}, Unset: []string{"APP_ENV"}, Files: map[string]string{"a.txt": "a"}}
+		convey.So(s.ValueToCode(), convey.ShouldEqual,
+			"mockfunc.Sandbox{Env: map[string]string{\n\"APP_PORT\": \"80\",},Unset: []string{\"APP_ENV\"},Files: map[string]string{\n\"a.txt\": \"a\",},}")
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Cleaner is implemented by *testing.T
+type Cleaner interface {
+	Cleanup(func())
+}
+
+// Replace sets the field or the variable which ptr points to with the fake, and restores it when the test finishes.
+// It takes the place of the patch for the seams, e.g. mockfunc.Replace(t, &now, fake).
+func Replace(t Cleaner, ptr interface{}, fake interface{}) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Sprintf("%v is not the address of the seam", ptr))
+	}
+	v = v.Elem()
+	original := reflect.New(v.Type()).Elem()
+	original.Set(v)
+	if fake == nil {
+		v.Set(reflect.Zero(v.Type()))
+	} else {
+		v.Set(reflect.ValueOf(fake))
+	}
+	t.Cleanup(func() {
+		v.Set(original)
+	})
+}
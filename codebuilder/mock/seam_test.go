@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var seamNow = func() int64 { return 1 }
+
+func TestReplace(t *testing.T) {
+	t.Run("replace", func(t *testing.T) {
+		r := NewCallRecorder()
+		Replace(t, &seamNow, r.Return("&seamNow", seamNow, []interface{}{int64(7)}))
+		assert.Equal(t, int64(7), seamNow())
+		assert.Equal(t, 1, r.CallCount("&seamNow"))
+	})
+	// the variable is restored when the test finishes
+	assert.Equal(t, int64(1), seamNow())
+
+	s := struct{ clock func() int64 }{}
+	t.Run("field", func(t *testing.T) {
+		Replace(t, &s.clock, nil)
+		assert.Nil(t, s.clock)
+	})
+}
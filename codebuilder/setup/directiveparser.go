@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package setup
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// RegexDirective is the comment of the tested function which declares the format of a string parameter,
+// e.g. //smartunit:regex orderID ^ORD-[0-9]{8}$
+const RegexDirective = "//smartunit:regex"
+
+// GetRegexDirectives returns the patterns declared by the doc comment of the function.
+// The key of the result is the parameter name.
+func GetRegexDirectives(function *ssa.Function) map[string]string {
+	directives := make(map[string]string)
+	doc := functionDoc(function)
+	if doc == nil {
+		return directives
+	}
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, RegexDirective+" ") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(comment.Text, RegexDirective))
+		if len(fields) != 2 {
+			continue
+		}
+		directives[fields[0]] = fields[1]
+	}
+	return directives
+}
+
+// functionDoc returns the doc comment of the function. The ssa package does not always keep the comments,
+// so we parse the source file again if it is necessary.
+func functionDoc(function *ssa.Function) *ast.CommentGroup {
+	if function == nil || function.Prog == nil || !function.Pos().IsValid() {
+		return nil
+	}
+	if decl, ok := function.Syntax().(*ast.FuncDecl); ok && decl.Doc != nil {
+		return decl.Doc
+	}
+	position := function.Prog.Fset.Position(function.Pos())
+	if position.Filename == "" {
+		return nil
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), position.Filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	for _, d := range file.Decls {
+		decl, ok := d.(*ast.FuncDecl)
+		if !ok || decl.Name.Name != function.Name() {
+			continue
+		}
+		if sameReceiver(decl, function) {
+			return decl.Doc
+		}
+	}
+	return nil
+}
+
+func sameReceiver(decl *ast.FuncDecl, function *ssa.Function) bool {
+	recv := function.Signature.Recv()
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return recv == nil
+	}
+	if recv == nil {
+		return false
+	}
+	expr := decl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if index, ok := expr.(*ast.IndexExpr); ok {
+		expr = index.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return strings.HasSuffix(strings.TrimPrefix(recv.Type().String(), "*"), "."+ident.Name)
+}
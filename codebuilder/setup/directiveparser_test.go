@@ -0,0 +1,36 @@
+package setup
+
+import (
+	"go/parser"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const regexDirectiveSrc = `package order
+
+type Service struct{}
+
+// Find returns the order by its id
+//smartunit:regex orderID ^ORD-[0-9]{8}$
+//smartunit:regex broken
+func (s *Service) Find(orderID string, name string) string {
+	return orderID + name
+}
+
+func Find(orderID string) string {
+	return orderID
+}
+`
+
+func TestGetRegexDirectives(t *testing.T) {
+	// the comments are dropped by the parser, so they are read from the file
+	for _, mode := range []parser.Mode{parser.ParseComments, 0} {
+		ssaPkg := buildSSA(t, "order", regexDirectiveSrc, mode)
+		method := ssaPkg.Prog.LookupMethod(types.NewPointer(ssaPkg.Type("Service").Type()), ssaPkg.Pkg, "Find")
+		assert.Equal(t, map[string]string{"orderID": "^ORD-[0-9]{8}$"}, GetRegexDirectives(method))
+		assert.Empty(t, GetRegexDirectives(ssaPkg.Func("Find")))
+	}
+	assert.Empty(t, GetRegexDirectives(nil))
+}
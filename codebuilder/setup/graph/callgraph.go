@@ -22,17 +22,35 @@ import (
 	"golang.org/x/tools/go/ssa"
 )
 
-func BuildCallGraph(function *parsermodel.ProjectFunction, functionName string) *callgraph.Graph {
-	cg := &callgraph.Graph{Nodes: make(map[*ssa.Function]*callgraph.Node)}
-	cg.Root = cg.CreateNode(function.Function)
-	createGraph(cg.Root, cg, function)
-	// buildNode(cg.Root, cg, function)
-	return cg
+// BuildCallGraph finds the callees of the tested function to mock. The policy decides which callees run for real,
+// whose own callees are checked one call deeper. Nil policy mocks the direct callees.
+// The patch replaces the callee at all its calls, so the mock wins when the policy mocks the callee at one depth
+// and runs it for real at another: the graph is built again with the callee mocked everywhere, until no more
+// callee is mocked. So the result does not depend on which call is scanned first.
+func BuildCallGraph(function *parsermodel.ProjectFunction, functionName string, policy *atgconstant.MockPolicy) *callgraph.Graph {
+	mocked := map[*ssa.Function]bool{}
+	for {
+		for name := range function.CalleeFunctionsForTargetFunction {
+			delete(function.CalleeFunctionsForTargetFunction, name)
+		}
+		cg := &callgraph.Graph{Nodes: make(map[*ssa.Function]*callgraph.Node)}
+		cg.Root = cg.CreateNode(function.Function)
+		createGraph(cg.Root, cg, function, 1, policy, mocked)
+		grown := false
+		for _, callee := range function.CalleeFunctionsForTargetFunction {
+			if !mocked[callee] {
+				mocked[callee], grown = true, true
+			}
+		}
+		if !grown {
+			return cg
+		}
+	}
 }
 
-func createGraph(parentNode *callgraph.Node, cg *callgraph.Graph, projectFunction *parsermodel.ProjectFunction) *callgraph.Node {
+func createGraph(parentNode *callgraph.Node, cg *callgraph.Graph, projectFunction *parsermodel.ProjectFunction, depth int, policy *atgconstant.MockPolicy, mocked map[*ssa.Function]bool) *callgraph.Node {
 	fNode := parentNode
-	ScanFuncBlocks(parentNode.Func.Blocks, projectFunction, fNode, cg)
+	ScanFuncBlocks(parentNode.Func.Blocks, projectFunction, fNode, cg, depth, policy, mocked)
 	return fNode
 }
 
@@ -91,7 +109,9 @@ func joinPath(elem ...string) string {
 	return filepath.Join(elem...)
 }
 
-func ScanFuncBlocks(bacicBlocks []*ssa.BasicBlock, projectFunction *parsermodel.ProjectFunction, parentNode *callgraph.Node, cg *callgraph.Graph) {
+// ScanFuncBlocks adds the callees in the blocks, which are depth calls down from the tested function.
+// The mocked callees are mocked at any depth.
+func ScanFuncBlocks(bacicBlocks []*ssa.BasicBlock, projectFunction *parsermodel.ProjectFunction, parentNode *callgraph.Node, cg *callgraph.Graph, depth int, policy *atgconstant.MockPolicy, mocked map[*ssa.Function]bool) {
 	for _, b := range bacicBlocks {
 		for _, instr := range b.Instrs {
 			var gNode *callgraph.Node
@@ -131,16 +151,24 @@ func ScanFuncBlocks(bacicBlocks []*ssa.BasicBlock, projectFunction *parsermodel.
 				switch commonValue := callCommon.Value.(type) {
 				case *ssa.Function:
 					if commonValue != nil && commonValue.Blocks != nil && len(commonValue.Blocks) > 0 {
-						ScanFuncBlocks(commonValue.Blocks, projectFunction, parentNode, cg)
+						ScanFuncBlocks(commonValue.Blocks, projectFunction, parentNode, cg, depth, policy, mocked)
 					}
 				case *ssa.MakeClosure:
 					closureFunc, ok := commonValue.Fn.(*ssa.Function)
 					if ok {
 						if closureFunc != nil && closureFunc.Blocks != nil && len(closureFunc.Blocks) > 0 {
-							ScanFuncBlocks(closureFunc.Blocks, projectFunction, parentNode, cg)
+							ScanFuncBlocks(closureFunc.Blocks, projectFunction, parentNode, cg, depth, policy, mocked)
 						}
 					}
 				}
+			} else if !funcInStd(downStreamFunc) && !mocked[downStreamFunc] && !shouldMock(policy, downStreamFunc, depth) {
+				// the callee runs for real, so that its own callees are mocked instead
+				if _, visited := cg.Nodes[downStreamFunc]; !visited && len(downStreamFunc.Blocks) != 0 {
+					realNode := cg.CreateNode(downStreamFunc)
+					callgraph.AddEdge(parentNode, site, realNode)
+					createGraph(realNode, cg, projectFunction, depth+1, policy, mocked)
+				}
+				continue
 			} else {
 				gNode = createCalleeNode(downStreamFunc, cg, projectFunction)
 			}
@@ -152,7 +180,7 @@ func ScanFuncBlocks(bacicBlocks []*ssa.BasicBlock, projectFunction *parsermodel.
 				callgraph.AddEdge(parentNode, site, gNode)
 				continue
 			} else {
-				callgraph.AddEdge(gNode, site, createGraph(gNode, cg, projectFunction))
+				callgraph.AddEdge(gNode, site, createGraph(gNode, cg, projectFunction, depth+1, policy, mocked))
 			}
 		}
 	}
@@ -130,7 +130,7 @@ func GetFunctionCallGraph(option atgconstant.Options) (*parsermodel.ProjectFunct
 		return TestedFunctionAndCallees, nil, fmt.Errorf("callees of Function not found")
 	}
 
-	return TestedFunctionAndCallees, BuildCallGraph(TestedFunctionAndCallees, option.FuncName), nil
+	return TestedFunctionAndCallees, BuildCallGraph(TestedFunctionAndCallees, option.FuncName, option.MockPolicy), nil
 }
 
 // GetPackageFunction gets all functions from the package, the tested function, the constant in the packages
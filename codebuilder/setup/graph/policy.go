@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"golang.org/x/tools/go/ssa"
+)
+
+// LoadMockPolicy reads the mock policy from the JSON file, e.g.
+//
+//	{"default_depth": 0, "rules": [
+//		{"pattern": "example.com/app/...", "mock": "never"},
+//		{"pattern": "example.com/app/rpc/...", "mock": "always"},
+//		{"pattern": "example.com/app/service", "mock": "depth", "depth": 2}]}
+func LoadMockPolicy(file string) (*atgconstant.MockPolicy, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	policy := &atgconstant.MockPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("invalid mock policy %v: %w", file, err)
+	}
+	if policy.DefaultDepth < 0 {
+		return nil, fmt.Errorf("invalid mock policy %v: negative default_depth", file)
+	}
+	for _, rule := range policy.Rules {
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("invalid mock policy %v: empty pattern", file)
+		}
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid mock policy %v: pattern %v: %w", file, rule.Pattern, err)
+		}
+		switch rule.Mock {
+		case atgconstant.MockPolicyAlways, atgconstant.MockPolicyNever:
+		case atgconstant.MockPolicyDepth:
+			if rule.Depth < 0 {
+				return nil, fmt.Errorf("invalid mock policy %v: negative depth of %v", file, rule.Pattern)
+			}
+		default:
+			return nil, fmt.Errorf("invalid mock policy %v: unknown mock %q of %v, use always, never or depth", file, rule.Mock, rule.Pattern)
+		}
+	}
+	return policy, nil
+}
+
+// shouldMock reports whether the callee which is depth calls down from the tested function is mocked
+func shouldMock(policy *atgconstant.MockPolicy, f *ssa.Function, depth int) bool {
+	if policy == nil {
+		return true
+	}
+	if f.Pkg == nil {
+		return true
+	}
+	pkgPath := f.Pkg.Pkg.Path()
+	if paths := strings.SplitN(pkgPath, "vendor/", 2); len(paths) == 2 {
+		pkgPath = paths[1]
+	}
+	rule, ok := matchMockRule(policy, pkgPath)
+	if !ok {
+		return depth > policy.DefaultDepth
+	}
+	switch rule.Mock {
+	case atgconstant.MockPolicyAlways:
+		return true
+	case atgconstant.MockPolicyNever:
+		return false
+	}
+	return depth > rule.Depth
+}
+
+// matchMockRule returns the rule of the most specific pattern which matches the package
+func matchMockRule(policy *atgconstant.MockPolicy, pkgPath string) (atgconstant.MockRule, bool) {
+	var res atgconstant.MockRule
+	found := false
+	for _, rule := range policy.Rules {
+		if !matchPkgPattern(rule.Pattern, pkgPath) {
+			continue
+		}
+		if !found || patternSpecificity(rule.Pattern) > patternSpecificity(res.Pattern) {
+			res, found = rule, true
+		}
+	}
+	return res, found
+}
+
+// patternSpecificity is the length of the pattern without the trailing /..., so that example.com/app/rpc
+// is more specific than example.com/app/...
+func patternSpecificity(pattern string) int {
+	return len(strings.TrimSuffix(pattern, "/..."))
+}
+
+// matchPkgPattern matches the package like the go command, where example.com/app/... matches example.com/app
+// and the packages under it. The other patterns are matched by path.Match.
+func matchPkgPattern(pattern, pkgPath string) bool {
+	if prefix := strings.TrimSuffix(pattern, "/..."); prefix != pattern {
+		return pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+	}
+	ok, _ := path.Match(pattern, pkgPath)
+	return ok
+}
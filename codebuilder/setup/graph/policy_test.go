@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+var policySrcs = map[string]string{
+	"example.com/app/rpc": `package rpc
+
+func Call(name string) string { return name }
+`,
+	"example.com/app/dao": `package dao
+
+func Get(id int) string { return "" }
+`,
+}
+
+const serviceSrc = `package service
+
+import (
+	"example.com/app/dao"
+	"example.com/app/rpc"
+)
+
+func Handle(id int) string {
+	return helper(id)
+}
+
+func helper(id int) string {
+	return rpc.Call(dao.Get(id))
+}
+`
+
+type policyImporter struct {
+	fset *token.FileSet
+}
+
+func (m policyImporter) Import(path string) (*types.Package, error) {
+	f, err := parser.ParseFile(m.fset, path+".go", policySrcs[path], 0)
+	if err != nil {
+		return nil, err
+	}
+	return (&types.Config{}).Check(path, m.fset, []*ast.File{f}, nil)
+}
+
+// reusedSrc calls helper at depth 1 first, then through wrap at depth 2
+const reusedSrc = `package service
+
+import (
+	"example.com/app/dao"
+	"example.com/app/rpc"
+)
+
+func Handle(id int) string {
+	return helper(id) + wrap(id)
+}
+
+func wrap(id int) string {
+	return helper(id)
+}
+
+func helper(id int) string {
+	return rpc.Call(dao.Get(id))
+}
+`
+
+// buildSSA builds the service package from the source
+func buildSSA(t *testing.T, src string) *ssa.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "service.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("example.com/app/service", "service")
+	ssaPkg, _, err := ssautil.BuildPackage(&types.Config{Importer: policyImporter{fset: fset}}, fset, pkg, []*ast.File{f}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssaPkg
+}
+
+func mockedCallees(t *testing.T, src string, policy *atgconstant.MockPolicy) []string {
+	ssaPkg := buildSSA(t, src)
+	function := &parsermodel.ProjectFunction{Function: ssaPkg.Func("Handle"), CalleeFunctionsForTargetFunction: map[string]*ssa.Function{}}
+	BuildCallGraph(function, "Handle", policy)
+	res := make([]string, 0)
+	for name := range function.CalleeFunctionsForTargetFunction {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func TestBuildCallGraph_MockPolicy(t *testing.T) {
+	assert.Equal(t, []string{"example.com/app/service/helper"}, mockedCallees(t, serviceSrc, nil))
+	// the helper runs for real, so that the clients it calls are mocked
+	assert.Equal(t, []string{"example.com/app/dao/Get", "example.com/app/rpc/Call"}, mockedCallees(t, serviceSrc, &atgconstant.MockPolicy{
+		Rules: []atgconstant.MockRule{{Pattern: "example.com/app/service", Mock: atgconstant.MockPolicyNever}},
+	}))
+	assert.Equal(t, []string{"example.com/app/rpc/Call"}, mockedCallees(t, serviceSrc, &atgconstant.MockPolicy{
+		DefaultDepth: 1,
+		Rules: []atgconstant.MockRule{
+			{Pattern: "example.com/app/...", Mock: atgconstant.MockPolicyDepth, Depth: 2},
+			{Pattern: "example.com/app/rpc", Mock: atgconstant.MockPolicyAlways},
+		},
+	}))
+}
+
+func TestBuildCallGraph_MockWins(t *testing.T) {
+	// helper is mocked through wrap, so it is mocked at depth 1 too and its callees are not
+	policy := &atgconstant.MockPolicy{
+		Rules: []atgconstant.MockRule{{Pattern: "example.com/app/service", Mock: atgconstant.MockPolicyDepth, Depth: 1}},
+	}
+	assert.Equal(t, []string{"example.com/app/service/helper"}, mockedCallees(t, reusedSrc, policy))
+}
+
+func TestLoadMockPolicy(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "policy.json")
+	ioutil.WriteFile(file, []byte(`{"default_depth": 1, "rules": [{"pattern": "example.com/app/rpc/...", "mock": "always"}]}`), 0644)
+	policy, err := LoadMockPolicy(file)
+	assert.Nil(t, err)
+	assert.Equal(t, &atgconstant.MockPolicy{DefaultDepth: 1, Rules: []atgconstant.MockRule{{Pattern: "example.com/app/rpc/...", Mock: "always"}}}, policy)
+
+	ioutil.WriteFile(file, []byte(`{"rules": [{"pattern": "example.com/app", "mock": "sometimes"}]}`), 0644)
+	_, err = LoadMockPolicy(file)
+	assert.NotNil(t, err)
+	_, err = LoadMockPolicy(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestMatchPkgPattern(t *testing.T) {
+	assert.True(t, matchPkgPattern("example.com/app/...", "example.com/app"))
+	assert.True(t, matchPkgPattern("example.com/app/...", "example.com/app/rpc/user"))
+	assert.False(t, matchPkgPattern("example.com/app/...", "example.com/application"))
+	assert.True(t, matchPkgPattern("example.com/*/rpc", "example.com/app/rpc"))
+	assert.False(t, matchPkgPattern("example.com/app", "example.com/app/rpc"))
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package setup
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Implementation is a concrete type of the module which implements an interface.
+type Implementation struct {
+	Type        types.Type    // T or *T
+	Constructor *ssa.Function // the constructor without params, nil if there is none
+}
+
+// GetImplementations finds the implementations of the interface in the packages of modulePath.
+// The implementations closer to the tested package come first:
+// the same package, then the package sharing the longest path.
+func GetImplementations(program *parsermodel.ProjectProgram, iface types.Type, modulePath string) []Implementation {
+	res := make([]Implementation, 0)
+	I, ok := iface.Underlying().(*types.Interface)
+	if program == nil || !ok {
+		return res
+	}
+	for _, t := range program.LookupImplementations(I) {
+		pkg := implementationPkg(t)
+		if pkg == nil || !isModulePackage(pkg.Path(), modulePath) {
+			continue
+		}
+		// the test file cannot import the package which imports the tested package
+		if pkg.Path() != program.PkgPath && (pkg.Name() == "main" || importsPackage(pkg, program.PkgPath, map[string]bool{})) {
+			continue
+		}
+		constructor := findConstructor(program, t)
+		if constructor == nil {
+			// the methods of T belong to *T as well
+			if _, isPtr := t.(*types.Pointer); !isPtr {
+				if constructor = findConstructor(program, types.NewPointer(t)); constructor != nil {
+					t = types.NewPointer(t)
+				}
+			}
+		}
+		res = append(res, Implementation{Type: t, Constructor: constructor})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		pi := packageProximity(implementationPkg(res[i].Type).Path(), program.PkgPath)
+		pj := packageProximity(implementationPkg(res[j].Type).Path(), program.PkgPath)
+		if pi != pj {
+			return pi > pj
+		}
+		return res[i].Type.String() < res[j].Type.String()
+	})
+	return res
+}
+
+func implementationPkg(t types.Type) *types.Package {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+	return named.Obj().Pkg()
+}
+
+func isModulePackage(pkgPath, modulePath string) bool {
+	return modulePath != "" && (pkgPath == modulePath || strings.HasPrefix(pkgPath, modulePath+"/"))
+}
+
+// packageProximity is the number of the leading path elements shared by the two packages.
+// The same package ranks above all the others.
+func packageProximity(pkgPath, testedPkgPath string) int {
+	if pkgPath == testedPkgPath {
+		return len(strings.Split(testedPkgPath, "/")) + 1
+	}
+	a, b := strings.Split(pkgPath, "/"), strings.Split(testedPkgPath, "/")
+	shared := 0
+	for shared < len(a) && shared < len(b) && a[shared] == b[shared] {
+		shared++
+	}
+	return shared
+}
+
+func importsPackage(pkg *types.Package, pkgPath string, visited map[string]bool) bool {
+	if visited[pkg.Path()] {
+		return false
+	}
+	visited[pkg.Path()] = true
+	for _, imported := range pkg.Imports() {
+		if imported.Path() == pkgPath || importsPackage(imported, pkgPath, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// findConstructor returns the function without params whose only result is t, so that its call is one value.
+// The constructor must be declared in the package of t.
+func findConstructor(program *parsermodel.ProjectProgram, t types.Type) *ssa.Function {
+	var constructor *ssa.Function
+	for function := range program.AllFuncs {
+		if function.Signature.Recv() != nil || function.Parent() != nil || function.Pkg == nil {
+			continue
+		}
+		if function.Pkg.Pkg != implementationPkg(t) || function.Signature.Params().Len() > 0 || function.Signature.Results().Len() != 1 {
+			continue
+		}
+		if !types.Identical(function.Signature.Results().At(0).Type(), t) {
+			continue
+		}
+		// AllFuncs is a map, sort the candidates to keep the result stable
+		if constructor == nil || function.Name() < constructor.Name() {
+			constructor = function
+		}
+	}
+	return constructor
+}
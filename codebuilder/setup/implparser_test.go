@@ -0,0 +1,87 @@
+package setup
+
+import (
+	"testing"
+
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const implementationSrc = `package store
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type MemoryStore struct {
+	Data map[string]string
+}
+
+func (m *MemoryStore) Get(key string) (string, error) {
+	return m.Data[key], nil
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{Data: map[string]string{}}
+}
+
+type EmptyStore struct{}
+
+func (EmptyStore) Get(key string) (string, error) {
+	return "", nil
+}
+
+func OpenEmptyStore() (EmptyStore, error) {
+	return EmptyStore{}, nil
+}
+
+func Load(s Store, key string) string {
+	v, _ := s.Get(key)
+	return v
+}
+`
+
+func buildImplementationProgram(t *testing.T) (*parsermodel.ProjectProgram, *ssa.Package) {
+	ssaPkg := buildSSA(t, "example.com/mod/store", implementationSrc, 0)
+	allFuncs := ssautil.AllFunctions(ssaPkg.Prog)
+	methodsByName := make(map[string][]*ssa.Function)
+	for f := range allFuncs {
+		if f.Signature.Recv() != nil {
+			methodsByName[f.Name()] = append(methodsByName[f.Name()], f)
+		}
+	}
+	return &parsermodel.ProjectProgram{
+		PkgName:       "store",
+		PkgPath:       "example.com/mod/store",
+		Prog:          ssaPkg.Prog,
+		Pkgs:          []*ssa.Package{ssaPkg},
+		AllFuncs:      allFuncs,
+		MethodsByName: methodsByName,
+	}, ssaPkg
+}
+
+func TestGetImplementations(t *testing.T) {
+	program, ssaPkg := buildImplementationProgram(t)
+	iface := ssaPkg.Type("Store").Type()
+
+	implementations := GetImplementations(program, iface, "example.com/mod")
+	assert.Equal(t, 2, len(implementations))
+	assert.Equal(t, "*example.com/mod/store.MemoryStore", implementations[0].Type.String())
+	assert.Equal(t, "NewMemoryStore", implementations[0].Constructor.Name())
+	assert.Equal(t, "example.com/mod/store.EmptyStore", implementations[1].Type.String())
+	// the constructor which also returns the error is not one value
+	assert.Nil(t, implementations[1].Constructor)
+
+	// the implementations out of the module are ignored
+	assert.Equal(t, 0, len(GetImplementations(program, iface, "example.com/other")))
+}
+
+func TestPackageProximity(t *testing.T) {
+	assert.Equal(t, 4, packageProximity("a.com/b/c", "a.com/b/c"))
+	assert.Equal(t, 2, packageProximity("a.com/b/d", "a.com/b/c"))
+	assert.Equal(t, 1, packageProximity("a.com/e", "a.com/b/c"))
+	assert.True(t, isModulePackage("a.com/b", "a.com"))
+	assert.False(t, isModulePackage("a.com.cn/b", "a.com"))
+}
@@ -68,3 +68,30 @@ func (prog *ProjectProgram) LookupMethods(I *types.Interface, m *types.Func) []*
 	}
 	return methods
 }
+
+// LookupImplementations returns the named types of the program which implement I.
+// The result is *T if only the pointer of T has all the methods.
+func (prog *ProjectProgram) LookupImplementations(I *types.Interface) []types.Type {
+	if I.NumMethods() == 0 {
+		return nil
+	}
+	implementations := make([]types.Type, 0)
+	visited := make(map[*types.TypeName]bool)
+	for _, f := range prog.LookupMethods(I, I.Method(0)) {
+		C := f.Signature.Recv().Type()
+		if ptr, ok := C.(*types.Pointer); ok {
+			C = ptr.Elem()
+		}
+		named, ok := C.(*types.Named)
+		if !ok || visited[named.Obj()] {
+			continue
+		}
+		visited[named.Obj()] = true
+		if types.Implements(named, I) {
+			implementations = append(implementations, named)
+		} else {
+			implementations = append(implementations, types.NewPointer(named))
+		}
+	}
+	return implementations
+}
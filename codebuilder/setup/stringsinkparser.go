@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package setup
+
+import (
+	"go/constant"
+	"go/types"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"golang.org/x/tools/go/ssa"
+)
+
+// StringSink describes the parser that a string parameter of the tested function flows into.
+type StringSink struct {
+	Kind   string     // one of atgconstant.StringSinkXXX
+	Layout string     // the time layout or the regexp pattern if it is a constant
+	Target types.Type // the type that json.Unmarshal decodes into
+}
+
+type stringSinkRule struct {
+	kind      string
+	arg       int // index of the parsed string
+	layoutArg int // index of the layout or pattern, -1 if there is none
+}
+
+var stringSinkRules = map[string]stringSinkRule{
+	"encoding/json.Unmarshal": {atgconstant.StringSinkJSON, 0, -1},
+	"strconv.Atoi":            {atgconstant.StringSinkInt, 0, -1},
+	"strconv.ParseInt":        {atgconstant.StringSinkInt, 0, -1},
+	"strconv.ParseUint":       {atgconstant.StringSinkInt, 0, -1},
+	"strconv.ParseFloat":      {atgconstant.StringSinkFloat, 0, -1},
+	"strconv.ParseBool":       {atgconstant.StringSinkBool, 0, -1},
+	"net/url.Parse":           {atgconstant.StringSinkURL, 0, -1},
+	"net/url.ParseRequestURI": {atgconstant.StringSinkURL, 0, -1},
+	"time.Parse":              {atgconstant.StringSinkTime, 1, 0},
+	"time.ParseInLocation":    {atgconstant.StringSinkTime, 1, 0},
+	"regexp.MatchString":      {atgconstant.StringSinkRegexp, 1, 0},
+}
+
+// The string still keeps its format after it passes these functions.
+var stringPassThrough = map[string]struct{}{
+	"strings.TrimSpace": {},
+	"strings.ToLower":   {},
+	"strings.ToUpper":   {},
+}
+
+// maxStringSinkDepth limits how deep we follow the string into the callee of the same package
+const maxStringSinkDepth = 3
+
+// GetStringSinks finds the string parameters which flow into json.Unmarshal, strconv.Atoi, url.Parse,
+// time.Parse or regexp.MatchString. The key of the result is the parameter name.
+func GetStringSinks(function *ssa.Function) map[string]StringSink {
+	sinks := make(map[string]StringSink)
+	if function == nil {
+		return sinks
+	}
+	for _, param := range function.Params {
+		if param.Name() == "" || param.Name() == "_" {
+			continue
+		}
+		if basic, ok := param.Type().Underlying().(*types.Basic); !ok || basic.Kind() != types.String {
+			continue
+		}
+		sink, ok := traceStringSink(function.Pkg, param, map[ssa.Value]bool{}, 0)
+		if ok {
+			sinks[param.Name()] = sink
+		}
+	}
+	return sinks
+}
+
+func traceStringSink(pkg *ssa.Package, value ssa.Value, visited map[ssa.Value]bool, depth int) (StringSink, bool) {
+	if visited[value] {
+		return StringSink{}, false
+	}
+	visited[value] = true
+	referrers := value.Referrers()
+	if referrers == nil {
+		return StringSink{}, false
+	}
+	for _, instr := range *referrers {
+		switch instr := instr.(type) {
+		case *ssa.Convert, *ssa.ChangeType, *ssa.Phi:
+			if sink, ok := traceStringSink(pkg, instr.(ssa.Value), visited, depth); ok {
+				return sink, true
+			}
+		case *ssa.Call:
+			common := instr.Common()
+			callee := common.StaticCallee()
+			if callee == nil {
+				continue
+			}
+			if rule, ok := stringSinkRules[callee.String()]; ok {
+				if rule.arg < len(common.Args) && common.Args[rule.arg] == value {
+					return newStringSink(rule, common.Args), true
+				}
+				continue
+			}
+			if _, ok := stringPassThrough[callee.String()]; ok {
+				if sink, ok := traceStringSink(pkg, instr, visited, depth); ok {
+					return sink, true
+				}
+				continue
+			}
+			// follow the string into the function of the same package
+			if depth >= maxStringSinkDepth || callee.Pkg != pkg || len(callee.Params) != len(common.Args) {
+				continue
+			}
+			for i, arg := range common.Args {
+				if arg != value {
+					continue
+				}
+				if sink, ok := traceStringSink(pkg, callee.Params[i], visited, depth+1); ok {
+					return sink, true
+				}
+			}
+		}
+	}
+	return StringSink{}, false
+}
+
+func newStringSink(rule stringSinkRule, args []ssa.Value) StringSink {
+	sink := StringSink{Kind: rule.kind}
+	if rule.layoutArg >= 0 && rule.layoutArg < len(args) {
+		if c, ok := args[rule.layoutArg].(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.String {
+			sink.Layout = constant.StringVal(c.Value)
+		}
+	}
+	if rule.kind == atgconstant.StringSinkJSON && len(args) > 1 {
+		sink.Target = unmarshalTarget(args[1])
+	}
+	return sink
+}
+
+// unmarshalTarget returns the type that the pointer passed to json.Unmarshal points to
+func unmarshalTarget(value ssa.Value) types.Type {
+	if mi, ok := value.(*ssa.MakeInterface); ok {
+		value = mi.X
+	}
+	ptr, ok := value.Type().Underlying().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+	return ptr.Elem()
+}
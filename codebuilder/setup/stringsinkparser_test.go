@@ -0,0 +1,98 @@
+package setup
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const stringSinkSrc = `package sink
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type User struct {
+	Name string
+	Age  int
+}
+
+func Decode(data string, count string, link string, day string, code string, plain string) error {
+	var u User
+	if err := json.Unmarshal([]byte(data), &u); err != nil {
+		return err
+	}
+	if _, err := strconv.Atoi(strings.TrimSpace(count)); err != nil {
+		return err
+	}
+	if _, err := url.Parse(link); err != nil {
+		return err
+	}
+	if _, err := parseDay(day); err != nil {
+		return err
+	}
+	if ok, _ := regexp.MatchString("^[A-Z]{3}$", code); !ok {
+		return nil
+	}
+	return nil
+}
+
+func parseDay(day string) (time.Time, error) {
+	return time.Parse("2006-01-02", day)
+}
+`
+
+// buildSSA writes the source of the package path into the temporary directory and builds it,
+// so that the parsers which read the file again find it
+func buildSSA(t *testing.T, path, src string, mode parser.Mode) *ssa.Package {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), filepath.Base(path)+".go")
+	if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage(path, f.Name.Name)
+	ssaPkg, _, err := ssautil.BuildPackage(&types.Config{Importer: importer.Default()}, fset, pkg, []*ast.File{f}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssaPkg
+}
+
+func buildStringSinkFunction(t *testing.T) *ssa.Function {
+	return buildSSA(t, "sink", stringSinkSrc, parser.ParseComments).Func("Decode")
+}
+
+func TestGetStringSinks(t *testing.T) {
+	sinks := GetStringSinks(buildStringSinkFunction(t))
+	assert.Equal(t, 5, len(sinks))
+	assert.Equal(t, atgconstant.StringSinkJSON, sinks["data"].Kind)
+	assert.Equal(t, "sink.User", sinks["data"].Target.String())
+	assert.Equal(t, atgconstant.StringSinkInt, sinks["count"].Kind)
+	assert.Equal(t, atgconstant.StringSinkURL, sinks["link"].Kind)
+	assert.Equal(t, atgconstant.StringSinkTime, sinks["day"].Kind)
+	assert.Equal(t, "2006-01-02", sinks["day"].Layout)
+	assert.Equal(t, atgconstant.StringSinkRegexp, sinks["code"].Kind)
+	assert.Equal(t, "^[A-Z]{3}$", sinks["code"].Layout)
+	_, ok := sinks["plain"]
+	assert.False(t, ok)
+}
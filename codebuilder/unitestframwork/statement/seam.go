@@ -0,0 +1,153 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package statement
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"golang.org/x/tools/go/ssa"
+)
+
+// CreateSeamStatements returns the seams called by the function, i.e. the interface or function fields of its receiver
+// and the interface or function variables of the packages. The test replaces the seams with the fakes instead of patching them.
+// The Expression of the seam is the address of the field or the variable, e.g. &__receiver__.repo or &now.
+func CreateSeamStatements(ctx context.Context, f *ssa.Function) []*Statement {
+	statements := make([]*Statement, 0)
+	if f == nil {
+		return statements
+	}
+	visited := map[string]bool{}
+	// the closures can only use the package variables, because the receiver becomes their free variable
+	functions := []*ssa.Function{f}
+	for i := 0; i < len(functions); i++ {
+		functions = append(functions, functions[i].AnonFuncs...)
+		for _, block := range functions[i].Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				st := createSeamStatement(ctx, functions[i], call.Common())
+				if st == nil || visited[st.Expression] {
+					continue
+				}
+				visited[st.Expression] = true
+				statements = append(statements, st)
+			}
+		}
+	}
+	return statements
+}
+
+func createSeamStatement(ctx context.Context, f *ssa.Function, call *ssa.CallCommon) *Statement {
+	var seamInterface types.Type
+	if call.IsInvoke() {
+		seamInterface = call.Value.Type()
+	} else if _, ok := call.Value.Type().Underlying().(*types.Signature); !ok {
+		return nil
+	}
+	st := &Statement{SeamInterface: seamInterface}
+	switch v := call.Value.(type) {
+	case *ssa.UnOp:
+		if v.Op != token.MUL {
+			return nil
+		}
+		switch x := v.X.(type) {
+		case *ssa.Global:
+			expression, ok := globalExpression(ctx, x)
+			if !ok {
+				return nil
+			}
+			st.Name = x.Name()
+			st.Seam = atgconstant.SeamGlobal
+			st.Expression = "&" + expression
+		case *ssa.FieldAddr:
+			if !isReceiver(f, x.X) {
+				return nil
+			}
+			st.Name = fieldName(x.X.Type(), x.Field)
+			st.Seam = atgconstant.SeamField
+			st.Expression = fmt.Sprintf("&%s.%s", atgconstant.ReceiverPlaceholder, st.Name)
+		default:
+			return nil
+		}
+	case *ssa.Field:
+		if !isReceiver(f, v.X) {
+			return nil
+		}
+		st.Name = fieldName(v.X.Type(), v.Field)
+		st.Seam = atgconstant.SeamField
+		st.Expression = fmt.Sprintf("&%s.%s", atgconstant.ReceiverPlaceholder, st.Name)
+	default:
+		return nil
+	}
+	st.FunctionType = types.TypeString(call.Value.Type(), nil)
+	st.Signature, _ = call.Value.Type().Underlying().(*types.Signature)
+	return st
+}
+
+func isReceiver(f *ssa.Function, v ssa.Value) bool {
+	if f.Signature.Recv() == nil || len(f.Params) == 0 {
+		return false
+	}
+	if f.Params[0] == v {
+		return true
+	}
+	// the value receiver is copied to the local, e.g. t0 = local T (s); *t0 = s
+	alloc, ok := v.(*ssa.Alloc)
+	if !ok || alloc.Referrers() == nil {
+		return false
+	}
+	for _, instr := range *alloc.Referrers() {
+		if store, ok := instr.(*ssa.Store); ok && store.Addr == alloc && store.Val == f.Params[0] {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldName(t types.Type, index int) string {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || index >= st.NumFields() {
+		return ""
+	}
+	return st.Field(index).Name()
+}
+
+// globalExpression returns how the test refers to the variable. The unexported variable of other package is not
+// accessible.
+func globalExpression(ctx context.Context, g *ssa.Global) (string, bool) {
+	if g.Pkg == nil || g.Pkg.Pkg == nil {
+		return "", false
+	}
+	pkgPath := g.Pkg.Pkg.Path()
+	if pkgPath == duplicatepackagemanager.GetInstance(ctx).RelativePath() {
+		return g.Name(), true
+	}
+	if !g.Object().Exported() {
+		return "", false
+	}
+	pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(g.Pkg.Pkg.Name(), pkgPath)
+	return fmt.Sprintf("%s.%s", pkgName, g.Name()), true
+}
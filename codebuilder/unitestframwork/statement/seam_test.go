@@ -0,0 +1,103 @@
+package statement
+
+import (
+	"context"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const seamSrc = `package seam
+
+type Repo interface {
+	Get(id int64) (string, error)
+}
+
+var now = func() int64 { return 0 }
+
+var limit = 10
+
+var defaultRepo Repo
+
+type Service struct {
+	repo  Repo
+	clock func() int64
+	name  string
+}
+
+func (s *Service) Name(id int64) (string, error) {
+	if now() > s.clock() {
+		return "", nil
+	}
+	go func() {
+		_ = now()
+	}()
+	return s.repo.Get(id + int64(limit))
+}
+
+func (s Service) Value() int64 {
+	return s.clock()
+}
+
+func Lookup(id int64) (string, error) {
+	return defaultRepo.Get(id)
+}
+`
+
+// buildSSA builds the seam package from the source
+func buildSSA(t *testing.T, src string) *ssa.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "seam.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("seam", "seam")
+	ssaPkg, _, err := ssautil.BuildPackage(&types.Config{Importer: importer.Default()}, fset, pkg, []*ast.File{f}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssaPkg
+}
+
+func TestCreateSeamStatements(t *testing.T) {
+	ssaPkg := buildSSA(t, seamSrc)
+	pkg := ssaPkg.Pkg
+	duplicatepackagemanager.Init()
+	ctx := duplicatepackagemanager.SetInstance(context.Background())
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("seam")
+
+	service := ssaPkg.Prog.LookupMethod(types.NewPointer(pkg.Scope().Lookup("Service").Type()), pkg, "Name")
+	seams := map[string]*Statement{}
+	for _, st := range CreateSeamStatements(ctx, service) {
+		seams[st.Expression] = st
+	}
+	assert.Len(t, seams, 3)
+	assert.Equal(t, atgconstant.SeamGlobal, seams["&now"].Seam)
+	assert.Nil(t, seams["&now"].SeamInterface)
+	assert.Equal(t, atgconstant.SeamField, seams["&__receiver__.clock"].Seam)
+	assert.Equal(t, "func() int64", seams["&__receiver__.clock"].FunctionType)
+	assert.Equal(t, atgconstant.SeamField, seams["&__receiver__.repo"].Seam)
+	assert.Equal(t, "seam.Repo", seams["&__receiver__.repo"].SeamInterface.String())
+
+	value := ssaPkg.Prog.LookupMethod(pkg.Scope().Lookup("Service").Type(), pkg, "Value")
+	seamList := CreateSeamStatements(ctx, value)
+	assert.Len(t, seamList, 1)
+	assert.Equal(t, "&__receiver__.clock", seamList[0].Expression)
+
+	// the interface variable is replaced by the stub like the interface field
+	seamList = CreateSeamStatements(ctx, ssaPkg.Func("Lookup"))
+	assert.Len(t, seamList, 1)
+	assert.Equal(t, "&defaultRepo", seamList[0].Expression)
+	assert.Equal(t, atgconstant.SeamGlobal, seamList[0].Seam)
+	assert.Equal(t, "seam.Repo", seamList[0].SeamInterface.String())
+}
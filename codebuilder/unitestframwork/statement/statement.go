@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"go/types"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -36,9 +37,12 @@ type Statement struct {
 	OriginalFunction *ssa.Function // Means the original function
 	PkgPath          string
 	PkgName          string
-	Expression       string // Used for the mockito mock. Mockito.call(xxxx, xxx, xxx)
-	SpecialType      string // Type: "overpass".   //TODO: add this in the atg constant
-	FunctionType     string // Used to force transform the  function
+	Expression       string     // Used for the mockito mock. Mockito.call(xxxx, xxx, xxx)
+	SpecialType      string     // Type: "overpass".   //TODO: add this in the atg constant
+	FunctionType     string     // Used to force transform the  function
+	Seam             string     // The seam kind: field or global. Empty means the function is patched
+	SeamInterface    types.Type // The interface type of the seam whose method is called, nil means the function seam
+	Signature        *types.Signature
 }
 
 // Create Mocked Statement
@@ -66,6 +70,7 @@ func CreateMockedStatement(ctx context.Context, f *ssa.Function, testedPkgName s
 	temPkgName, temPkgPath := duplicatepackagemanager.GetInstance(ctx).PutAndGet(pkgName, pkgPath)
 	MockedStatement.Name = f.Name()
 	MockedStatement.OriginalFunction = f
+	MockedStatement.Signature = f.Signature
 	MockedStatement.PkgName = temPkgName
 	MockedStatement.PkgPath = temPkgPath
 	mockedReceiver, err := extracinfo.ConvertToReceiver(ctx, f.Signature, testedPkgName)
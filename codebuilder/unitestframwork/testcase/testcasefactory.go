@@ -53,6 +53,10 @@ func CreateTestCase(ctx context.Context, f *parsermodel.ProjectFunction) (*TestC
 	for _, function := range f.CalleeFunctionsForTargetFunction {
 		InsertRandomCall(ctx, function, testCase, len(testCase.Statements), f.Program.PkgPath)
 	}
+	// the seams are replaced by the test instead of being patched
+	for _, st := range statement.CreateSeamStatements(ctx, f.Function) {
+		testCase.Statements = append(testCase.Statements, *st)
+	}
 
 	return testCase, nil
 }
@@ -257,19 +257,23 @@ func VariableMutate(ctx context.Context, t reflect.Type, v reflect.Value) (mutat
 					// change value of field
 					// FIXME: 需要其他类型的字面量列表，目前没存，暂时使用随机生成
 					f.Set(VariableMutate(ctx, f.Type(), f).Convert(f.Type()))
-					fieldName := strings.ToLower(t.Field(i).Name)
 					var found bool
-					for k, tagFunc := range faker.MapperTag {
-						if found {
-							break
+					if k, ok := faker.MatchFieldTag(t.Field(i).Name); ok {
+						fake, err := faker.MapperTag[k](f)
+						if err == nil {
+							SafeSet(f, ctx, found, fake)
 						}
-						if strings.Contains(fieldName, k) {
-							fake, err := tagFunc(f)
-							if err == nil {
-								SafeSet(f, ctx, found, fake)
-							}
+					}
+					// the field declares its format, e.g. faker:"regex=^ORD-[0-9]{8}$"
+					if pattern, ok := faker.ExtractRegexFromTag(t.Field(i).Tag.Get("faker")); ok && f.Kind() == reflect.String {
+						if fake, err := faker.FromRegex(pattern); err == nil {
+							f.SetString(fake)
 						}
 					}
+					// the string is parsed by the tested function, so we generate the well-formed one
+					if sinkValue, ok := smartunitvariablebuild.GetStringSinkValue(ctx, t, i); ok {
+						f.SetString(sinkValue)
+					}
 					finalSet = true
 				}
 			}
@@ -279,7 +283,28 @@ func VariableMutate(ctx context.Context, t reflect.Type, v reflect.Value) (mutat
 		}
 		return v
 	case reflect.Interface:
-		return v
+		// The real implementation of the module is preferred to the stub when the user asks for it.
+		if impl, ok := smartunitvariablebuild.GetInterfaceImplementation(ctx, t); ok {
+			code, _ := smartunitvariablebuild.GetImplementationCode(ctx, impl.Type())
+			if code != "" || vtx.Level >= atgconstant.VariableMaxLevel {
+				return impl
+			}
+			if impl.Kind() == reflect.Ptr {
+				newImpl := reflect.New(impl.Type().Elem())
+				newImpl.Elem().Set(VariableMutate(ctx, impl.Type().Elem(), newImpl.Elem()))
+				return newImpl
+			}
+			return VariableMutate(ctx, impl.Type(), reflect.New(impl.Type()).Elem())
+		}
+		// If the test file declares a stub for the interface, we fill the stub's fields
+		// which are returned by its methods.
+		stubType, ok := smartunitvariablebuild.GetInterfaceStub(ctx, t)
+		if !ok || vtx.Level >= atgconstant.VariableMaxLevel {
+			return v
+		}
+		stub := reflect.New(stubType.Elem())
+		stub.Elem().Set(VariableMutate(ctx, stubType.Elem(), stub.Elem()))
+		return stub
 	case reflect.Slice:
 		sLen := v.Len()
 		if sLen == 0 {
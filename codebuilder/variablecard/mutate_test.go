@@ -475,6 +475,31 @@ func TestVariableMutateBuilder(t *testing.T) {
 	t.Log(mutateV.Interface().(Info).Logo)
 }
 
+func TestVariableMutateStringSink(t *testing.T) {
+	type Args struct {
+		Count string
+	}
+	ctx := context.Background()
+	vtx := atgconstant.VariableContext{Level: 0, ID: 0, CanBeNil: false}
+	ctx = contexthelper.SetVariableContext(ctx, vtx)
+	s := smartunitvariablebuild.NewStringSinkInjector()
+	s.Set("Args.Count", smartunitvariablebuild.StringSink{Kind: atgconstant.StringSinkBool})
+	ctx = context.WithValue(ctx, "StringSinkInjector", s)
+	mutateV := VariableMutate(ctx, reflect.TypeOf(Args{}), reflect.ValueOf(Args{}))
+	assert.Contains(t, []string{"true", "false", "smartunit"}, mutateV.Interface().(Args).Count)
+}
+
+func TestVariableMutateRegexTag(t *testing.T) {
+	type Order struct {
+		ID string `faker:"regex=^ORD-[0-9]{8}$"`
+	}
+	ctx := context.Background()
+	vtx := atgconstant.VariableContext{Level: 0, ID: 0, CanBeNil: false}
+	ctx = contexthelper.SetVariableContext(ctx, vtx)
+	mutateV := VariableMutate(ctx, reflect.TypeOf(Order{}), reflect.ValueOf(Order{}))
+	assert.Regexp(t, `^ORD-[0-9]{8}$`, mutateV.Interface().(Order).ID)
+}
+
 type TikTokContext struct {
 	ItemID int
 }
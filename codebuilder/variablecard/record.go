@@ -25,13 +25,23 @@ import (
 	"github.com/bytedance/nxt_unit/smartunitvariablebuild"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
 	util "github.com/typa01/go-utils"
 )
 
 type MocksRecord []string
-type MonkeyOutputMap map[string]string //key:functionName value is function return string
+type MonkeyOutputMap map[string][]string //key:functionName value is the code of the return values of each call
+
+// MockCallRecord records the calls of the mocked functions. key:functionName
+type MockCallRecord map[string]*MockCallCode
+
+// MockCallCode is the call count and the code of the arguments of the first calls
+type MockCallCode struct {
+	Count int
+	Args  []string
+}
 
 type SpecialValue interface {
 	ValueToCode() string
@@ -71,6 +81,9 @@ func ValueToString(ctx context.Context, v reflect.Value) (code string) {
 	if monkeyOutputMap, ok := v.Interface().(MonkeyOutputMap); ok {
 		return getMonkeyOutputStrMap(monkeyOutputMap)
 	}
+	if mockCalls, ok := v.Interface().(MockCallRecord); ok {
+		return getMockCallStrMap(ctx, mockCalls)
+	}
 	specialValue, ok := smartunitvariablebuild.RenderVariableV3(ctx, v)
 	if ok {
 		return specialValue
@@ -126,6 +139,18 @@ func ValueToString(ctx context.Context, v reflect.Value) (code string) {
 		// Special logic, to remove the current package if the struct package is the same with the current one
 		builder.Append(fmt.Sprint(trimName(ctx, t, nil), "{\n", StructFieldToString(ctx, v), "}"))
 	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		if code, ok := smartunitvariablebuild.GetImplementationCode(ctx, v.Elem().Type()); ok {
+			if code != "" {
+				return code
+			}
+			return ValueToString(ctx, v.Elem())
+		}
+		if smartunitvariablebuild.IsInterfaceStub(ctx, v.Elem().Type()) {
+			return ValueToString(ctx, v.Elem())
+		}
 		return "nil"
 	case reflect.Func:
 		builder.Append("func(")
@@ -357,6 +382,69 @@ func StructFieldToString(ctx context.Context, v reflect.Value) string {
 	return builder.ToString()
 }
 
+// RoundTrips reports whether ValueToString renders the value as it is, so that the final suite is able to compare
+// the result with it. The unexported fields and the types, the interfaces holding neither the stub nor the
+// implementation and the channels are lost.
+func RoundTrips(ctx context.Context, v reflect.Value) bool {
+	if !v.IsValid() || atghelper.IsValueNil(v) {
+		return true
+	}
+	if _, ok := v.Interface().(SpecialValue); ok {
+		return true
+	}
+	if _, ok := smartunitvariablebuild.RenderVariableV3(ctx, v); ok {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Bool, reflect.String, reflect.Float64, reflect.Float32, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Func:
+		// the functions are not compared
+		return true
+	case reflect.Ptr:
+		switch v.Type().Elem().Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Array, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128, reflect.Uintptr:
+			return false
+		}
+		return RoundTrips(ctx, v.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !RoundTrips(ctx, v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if !RoundTrips(ctx, k) || !RoundTrips(ctx, v.MapIndex(k)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if field.IsZero() {
+				continue
+			}
+			if !field.CanInterface() || !atghelper.IsTypeExported(field.Type()) || !RoundTrips(ctx, field) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		if code, ok := smartunitvariablebuild.GetImplementationCode(ctx, v.Elem().Type()); ok {
+			// the implementation is rendered by its constructor
+			return code == "" && RoundTrips(ctx, v.Elem())
+		}
+		if smartunitvariablebuild.IsInterfaceStub(ctx, v.Elem().Type()) {
+			return RoundTrips(ctx, v.Elem())
+		}
+		return false
+	}
+	return false
+}
+
 type InputVariadic struct {
 	IsVariadic      bool
 	IsVariadicParam bool
@@ -750,24 +838,45 @@ func removeSelfImported(s string) string {
 }
 
 func getMonkeyOutputStrMap(monkeyOutputMap MonkeyOutputMap) string {
-	monkeyStr := ""
-	headStr := "map[string][]interface{}{"
-	tailStr := "}"
-	cellHeadStr := "[]interface{}{"
-	cellTailStr := "},"
-
-	if len(monkeyOutputMap) > 0 {
-		monkeyStr = monkeyStr + headStr
-		for funcName, mockReturn := range monkeyOutputMap {
-			if mockReturn != "" {
-				monkeyStr = monkeyStr + fmt.Sprintf("\"%s\":%s%s%s", funcName, cellHeadStr, mockReturn, cellTailStr)
-			}
+	funcNames := make([]string, 0, len(monkeyOutputMap))
+	for funcName := range monkeyOutputMap {
+		funcNames = append(funcNames, funcName)
+	}
+	sort.Strings(funcNames)
+	builder := util.NewStringBuilder()
+	builder.Append("map[string][][]interface{}{")
+	for _, funcName := range funcNames {
+		outputs := make([]string, 0, len(monkeyOutputMap[funcName]))
+		for _, output := range monkeyOutputMap[funcName] {
+			outputs = append(outputs, fmt.Sprintf("{%s}", output))
 		}
-		monkeyStr = monkeyStr + tailStr
+		builder.Append(fmt.Sprintf("\"%s\": {%s},", funcName, strings.Join(outputs, ", ")))
 	}
-	if monkeyStr != "" {
-		return monkeyStr
-	} else {
-		return "map[string][]interface{}{}"
+	builder.Append("}")
+	return builder.ToString()
+}
+
+// getMockCallStrMap renders the record as map[string]mockfunc.ExpectedCalls, which is asserted by the final suite
+func getMockCallStrMap(ctx context.Context, mockCalls MockCallRecord) string {
+	mockName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("mockfunc", "github.com/bytedance/nxt_unit/codebuilder/mock")
+	funcNames := make([]string, 0, len(mockCalls))
+	for funcName := range mockCalls {
+		funcNames = append(funcNames, funcName)
 	}
+	sort.Strings(funcNames)
+	builder := util.NewStringBuilder()
+	builder.Append(fmt.Sprintf("map[string]%s.ExpectedCalls{", mockName))
+	for _, funcName := range funcNames {
+		call := mockCalls[funcName]
+		if call == nil {
+			continue
+		}
+		args := make([]string, 0, len(call.Args))
+		for _, arg := range call.Args {
+			args = append(args, fmt.Sprintf("{%s}", arg))
+		}
+		builder.Append(fmt.Sprintf("\n\"%s\": {Count: %d, Args: [][]interface{}{%s}},", funcName, call.Count, strings.Join(args, ", ")))
+	}
+	builder.Append("}")
+	return builder.ToString()
 }
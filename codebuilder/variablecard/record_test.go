@@ -527,3 +527,120 @@ func TestDeepTSlicerimName(t *testing.T) {
 		})
 	}
 }
+
+type Counter interface {
+	Count(key string) (int64, error)
+	Next() Counter
+}
+
+type StubCounterForRecord struct {
+	CountResult0 int64
+	CountResult1 error
+	NextResult0  Counter
+}
+
+func (s *StubCounterForRecord) Count(key string) (int64, error) {
+	return s.CountResult0, s.CountResult1
+}
+
+func (s *StubCounterForRecord) Next() Counter {
+	return s.NextResult0
+}
+
+func TestInterfaceStubToString(t *testing.T) {
+	type Args struct {
+		C Counter
+	}
+	duplicatepackagemanager.Init()
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	injector := smartunitvariablebuild.NewSpecialValueInjector()
+	injector.SetStub((*Counter)(nil), &StubCounterForRecord{})
+	ctx = context.WithValue(ctx, "SpecialValueInjector", injector)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativePath(Args{})
+	mutatedV := VariableMutate(ctx, reflect.TypeOf(Args{}), reflect.ValueOf(Args{}))
+	args := mutatedV.Interface().(Args)
+	if args.C == nil {
+		t.Fatal("the interface parameter should be filled with the stub")
+	}
+	_, ok := args.C.(*StubCounterForRecord)
+	assert.True(t, ok)
+	assert.Contains(t, ValueToString(ctx, mutatedV), "C:&StubCounterForRecord{")
+}
+
+func TestInterfaceImplementationToString(t *testing.T) {
+	type Args struct {
+		C Counter
+	}
+	duplicatepackagemanager.Init()
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	injector := smartunitvariablebuild.NewSpecialValueInjector()
+	injector.SetImplementation((*Counter)(nil), func() interface{} { return &StubCounterForRecord{CountResult0: 1} }, "NewCounter()")
+	ctx = context.WithValue(ctx, "SpecialValueInjector", injector)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativePath(Args{})
+	mutatedV := VariableMutate(ctx, reflect.TypeOf(Args{}), reflect.ValueOf(Args{}))
+	args := mutatedV.Interface().(Args)
+	counter, ok := args.C.(*StubCounterForRecord)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), counter.CountResult0)
+	assert.Contains(t, ValueToString(ctx, mutatedV), "C:NewCounter()")
+
+	// without the constructor, the fields of the implementation are generated
+	injector.SetImplementation((*Counter)(nil), func() interface{} { return &StubCounterForRecord{} }, "")
+	mutatedV = VariableMutate(ctx, reflect.TypeOf(Args{}), reflect.ValueOf(Args{}))
+	_, ok = mutatedV.Interface().(Args).C.(*StubCounterForRecord)
+	assert.True(t, ok)
+	assert.Contains(t, ValueToString(ctx, mutatedV), "C:&StubCounterForRecord{")
+}
+
+func TestMockCallRecordToString(t *testing.T) {
+	atgconstant.PkgRelativePath = "smart unit"
+	duplicatepackagemanager.Init()
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	record := MockCallRecord{
+		"b.Get": {Count: 1, Args: []string{"mockfunc.Any, \"key\""}},
+		"a.Set": {Count: 12, Args: []string{"1", "2"}},
+	}
+	res := ValueToString(ctx, reflect.ValueOf(record))
+	assert.Equal(t, "map[string]mockfunc.ExpectedCalls{"+
+		"\n\"a.Set\": {Count: 12, Args: [][]interface{}{{1}, {2}}},"+
+		"\n\"b.Get\": {Count: 1, Args: [][]interface{}{{mockfunc.Any, \"key\"}}},}", res)
+}
+
+func TestMonkeyOutputMapToString(t *testing.T) {
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	outputs := MonkeyOutputMap{
+		"b.Load":  {"0, errors.New(\"retry\")", "1, nil"},
+		"a.Close": {""},
+	}
+	res := ValueToString(ctx, reflect.ValueOf(outputs))
+	assert.Equal(t, "map[string][][]interface{}{\"a.Close\": {{}},\"b.Load\": {{0, errors.New(\"retry\")}, {1, nil}},}", res)
+}
+
+type plainCounter struct{}
+
+func (plainCounter) Count(key string) (int64, error) { return 0, nil }
+
+func (plainCounter) Next() Counter { return nil }
+
+func TestRoundTrips(t *testing.T) {
+	type Item struct {
+		Name  string
+		Tags  map[string][]int
+		C     Counter
+		count int
+	}
+	duplicatepackagemanager.Init()
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	injector := smartunitvariablebuild.NewSpecialValueInjector()
+	injector.SetStub((*Counter)(nil), &StubCounterForRecord{})
+	ctx = context.WithValue(ctx, "SpecialValueInjector", injector)
+	assert.True(t, RoundTrips(ctx, reflect.ValueOf(&Item{Name: "a", Tags: map[string][]int{"x": {1}}})))
+	assert.True(t, RoundTrips(ctx, reflect.ValueOf(Item{C: &StubCounterForRecord{CountResult0: 1}})))
+	assert.True(t, RoundTrips(ctx, reflect.ValueOf([]*Item{nil, {}})))
+	// the unexported field and the interface which holds neither the stub nor the implementation are lost
+	assert.False(t, RoundTrips(ctx, reflect.ValueOf(Item{count: 1})))
+	assert.False(t, RoundTrips(ctx, reflect.ValueOf(Item{C: plainCounter{}})))
+	var c Counter = plainCounter{}
+	assert.False(t, RoundTrips(ctx, reflect.ValueOf(&c).Elem()))
+	assert.False(t, RoundTrips(ctx, reflect.ValueOf(make(chan int))))
+}
@@ -24,6 +24,7 @@ package faker
 
 import (
 	"reflect"
+	"strings"
 )
 
 var address Addresser
@@ -45,6 +46,11 @@ func SetAddress(net Addresser) {
 type Addresser interface {
 	Latitude(v reflect.Value) (interface{}, error)
 	Longitude(v reflect.Value) (interface{}, error)
+	City(v reflect.Value) (interface{}, error)
+	State(v reflect.Value) (interface{}, error)
+	Postcode(v reflect.Value) (interface{}, error)
+	StreetAddress(v reflect.Value) (interface{}, error)
+	FullAddress(v reflect.Value) (interface{}, error)
 }
 
 // Address struct
@@ -89,3 +95,120 @@ func Latitude() float64 {
 	address := Address{}
 	return float64(address.latitude())
 }
+
+// addressLocale returns the selected locale, or en_US if the selected one does not have the addresses
+func addressLocale() *Locale {
+	if locale := selectedLocale(); locale != nil && locale.AddressFormat != "" {
+		return locale
+	}
+	return findLocale("en_US")
+}
+
+func (i Address) city() string {
+	return randomElementFromSliceString(addressLocale().Cities)
+}
+
+// City returns a city of the selected locale
+func (i Address) City(v reflect.Value) (interface{}, error) {
+	return i.city(), nil
+}
+
+// City get fake city, e.g. "Chicago" or "杭州市"
+func City() string {
+	address := Address{}
+	return address.city()
+}
+
+func (i Address) state() string {
+	return randomElementFromSliceString(addressLocale().States)
+}
+
+// State returns a state, province or prefecture of the selected locale
+func (i Address) State(v reflect.Value) (interface{}, error) {
+	return i.state(), nil
+}
+
+// State get fake state, e.g. "CA" or "浙江省"
+func State() string {
+	address := Address{}
+	return address.state()
+}
+
+func (i Address) postcode() string {
+	return numerify(randomElementFromSliceString(addressLocale().PostcodeFormats))
+}
+
+// Postcode returns a postcode in the format of the selected locale
+func (i Address) Postcode(v reflect.Value) (interface{}, error) {
+	return i.postcode(), nil
+}
+
+// Postcode get fake postcode, e.g. "94107" or "01310-100"
+func Postcode() string {
+	address := Address{}
+	return address.postcode()
+}
+
+func (i Address) streetAddress() string {
+	locale := addressLocale()
+	building := numerify(randomElementFromSliceString(locale.BuildingFormats))
+	street := randomElementFromSliceString(locale.Streets)
+	// the address format tells whether the building number comes before the street
+	if strings.Index(locale.AddressFormat, "{building}") < strings.Index(locale.AddressFormat, "{street}") {
+		return building + " " + street
+	}
+	return strings.NewReplacer("{street}", street, "{building}", building).Replace(streetPart(locale.AddressFormat))
+}
+
+// StreetAddress returns the street and the building number
+func (i Address) StreetAddress(v reflect.Value) (interface{}, error) {
+	return i.streetAddress(), nil
+}
+
+// StreetAddress get fake street address, e.g. "123 Main Street"
+func StreetAddress() string {
+	address := Address{}
+	return address.streetAddress()
+}
+
+func (i Address) fullAddress() string {
+	locale := addressLocale()
+	return strings.NewReplacer(
+		"{building}", numerify(randomElementFromSliceString(locale.BuildingFormats)),
+		"{street}", randomElementFromSliceString(locale.Streets),
+		"{city}", randomElementFromSliceString(locale.Cities),
+		"{state}", randomElementFromSliceString(locale.States),
+		"{postcode}", numerify(randomElementFromSliceString(locale.PostcodeFormats)),
+	).Replace(locale.AddressFormat)
+}
+
+// FullAddress returns the whole address formatted as the selected locale writes it
+func (i Address) FullAddress(v reflect.Value) (interface{}, error) {
+	return i.fullAddress(), nil
+}
+
+// FullAddress get fake address, e.g. "123 Main Street, Chicago, IL 60601" or "广东省广州市中山路12号"
+func FullAddress() string {
+	address := Address{}
+	return address.fullAddress()
+}
+
+// streetPart cuts the part from {street} to {building} out of the address format,
+// e.g. "{street}, {building}" of "{street}, {building} - {city} - {state}, {postcode}"
+func streetPart(format string) string {
+	start := strings.Index(format, "{street}")
+	end := strings.Index(format, "{building}")
+	if start < 0 || end < start {
+		return "{street} {building}"
+	}
+	part := format[start : end+len("{building}")]
+	// keep the suffix of the building number, e.g. 号
+	if rest := format[end+len("{building}"):]; rest != "" && !strings.ContainsAny(rest[:1], " ,-") {
+		if next := strings.Index(rest, "{"); next < 0 {
+			part += rest
+		} else {
+			part += rest[:next]
+		}
+	}
+	return part
+}
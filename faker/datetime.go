@@ -638,10 +638,36 @@ type DateTimer interface {
 	Century(v reflect.Value) (interface{}, error)
 	TimeZone(v reflect.Value) (interface{}, error)
 	TimePeriod(v reflect.Value) (interface{}, error)
+	TimeValue(v reflect.Value) (interface{}, error)
+	Duration(v reflect.Value) (interface{}, error)
+	Location(v reflect.Value) (interface{}, error)
 }
 
 var date DateTimer
 
+// referenceTime anchors the generated dates. The zero value means time.Now().
+var referenceTime time.Time
+
+// dateWindow is the distance around the reference time that generated dates fall into
+const dateWindow = 365 * 24 * time.Hour
+
+// durationUnits are the units used to build human readable durations, e.g. 3 * time.Second
+var durationUnits = []time.Duration{time.Millisecond, time.Second, time.Minute, time.Hour}
+
+// SetReferenceTime anchors the generated dates around ref, so that the generated
+// tests are reproducible. Passing the zero time falls back to time.Now().
+func SetReferenceTime(ref time.Time) {
+	referenceTime = ref
+}
+
+// ReferenceTime returns the time that the generated dates are anchored to
+func ReferenceTime() time.Time {
+	if referenceTime.IsZero() {
+		return time.Now()
+	}
+	return referenceTime
+}
+
 // GetDateTimer returns a new DateTimer interface of DateTime
 func GetDateTimer() DateTimer {
 
@@ -833,7 +859,59 @@ func Timeperiod() string {
 	return datetime.period()
 }
 
-// RandomUnixTime is a helper function returning random Unix time
+func (d DateTime) timeValue() time.Time {
+	offset := time.Duration(rand.Int63n(int64(2*dateWindow))) - dateWindow
+	return ReferenceTime().Add(offset).Truncate(time.Second).UTC()
+}
+
+// TimeValue returns a random time.Time around the reference time
+func (d DateTime) TimeValue(v reflect.Value) (interface{}, error) {
+	return d.timeValue(), nil
+}
+
+// TimeValue get a random time.Time around the reference time
+func TimeValue() time.Time {
+	datetime := DateTime{}
+	return datetime.timeValue()
+}
+
+func (d DateTime) duration() time.Duration {
+	unit := durationUnits[rand.Intn(len(durationUnits))]
+	return time.Duration(rand.Intn(120)+1) * unit
+}
+
+// Duration returns a random time.Duration which is a whole number of a common unit
+func (d DateTime) Duration(v reflect.Value) (interface{}, error) {
+	return d.duration(), nil
+}
+
+// Duration get a random time.Duration, e.g. 3 * time.Second
+func Duration() time.Duration {
+	datetime := DateTime{}
+	return datetime.duration()
+}
+
+func (d DateTime) location() *time.Location {
+	if rand.Intn(2) == 0 {
+		return time.UTC
+	}
+	// The offsets of the fixed zones range from UTC-12 to UTC+14
+	hours := rand.Intn(27) - 12
+	return time.FixedZone(fmt.Sprintf("UTC%+03d", hours), hours*60*60)
+}
+
+// Location returns a random *time.Location. It never loads the zone database.
+func (d DateTime) Location(v reflect.Value) (interface{}, error) {
+	return d.location(), nil
+}
+
+// Location get a random *time.Location, either time.UTC or a fixed zone
+func Location() *time.Location {
+	datetime := DateTime{}
+	return datetime.location()
+}
+
+// RandomUnixTime is a helper function returning random Unix time before the reference time
 func RandomUnixTime() int64 {
-	return rand.Int63n(time.Now().Unix())
+	return rand.Int63n(ReferenceTime().Unix())
 }
@@ -257,3 +257,43 @@ func TestFakeTimePeriod(t *testing.T) {
 		t.Error("function TimePeriod need return valid period")
 	}
 }
+
+func TestFakeTimeValue(t *testing.T) {
+	ref := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	SetReferenceTime(ref)
+	defer SetReferenceTime(time.Time{})
+	for i := 0; i < 100; i++ {
+		tm := TimeValue()
+		if tm.Before(ref.Add(-dateWindow)) || tm.After(ref.Add(dateWindow)) {
+			t.Errorf("TimeValue %v should be around the reference time %v", tm, ref)
+		}
+		if tm.Nanosecond() != 0 || tm.Location() != time.UTC {
+			t.Errorf("TimeValue %v should be truncated to seconds in UTC", tm)
+		}
+	}
+	if RandomUnixTime() >= ref.Unix() {
+		t.Error("RandomUnixTime should return time before the reference time")
+	}
+}
+
+func TestFakeDuration(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := Duration()
+		if d <= 0 || d%time.Millisecond != 0 {
+			t.Errorf("Duration %v should be a positive number of milliseconds", d)
+		}
+	}
+}
+
+func TestFakeLocation(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		loc, err := GetDateTimer().Location(reflect.Value{})
+		if err != nil {
+			t.Error("function Location need return valid location")
+		}
+		_, offset := time.Now().In(loc.(*time.Location)).Zone()
+		if offset < -12*60*60 || offset > 14*60*60 {
+			t.Errorf("Location %v has invalid offset %v", loc, offset)
+		}
+	}
+}
@@ -106,6 +106,10 @@ const (
 	JWT                   = "jwt"
 	LATITUDE              = "lat"
 	LONGITUDE             = "long"
+	CityTag               = "city"
+	PostcodeTag           = "postcode"
+	StreetAddressTag      = "street_address"
+	FullAddressTag        = "full_address"
 	CreditCardNumber      = "cc_number"
 	CreditCardType        = "cc_type"
 	PhoneNumber           = "phone_number"
@@ -139,6 +143,21 @@ const (
 	CurrencyTag           = "currency"
 	AmountTag             = "amount"
 	AmountWithCurrencyTag = "amount_with_currency"
+	SemVerTag             = "semver"
+	UserAgentTag          = "user_agent"
+	MimeTypeTag           = "mime_type"
+	FilePathTag           = "file_path"
+	HexColorTag           = "hex_color"
+	CronTag               = "cron"
+	Base64Tag             = "base64"
+	HTTPMethodTag         = "http_method"
+	HTTPStatusCodeTag     = "http_status_code"
+	ISBN10Tag             = "isbn10"
+	ISBN13Tag             = "isbn13"
+	IBANTag               = "iban"
+	CountryCodeTag        = "country_code"
+	CountryCodeAlpha3Tag  = "country_code_alpha3"
+	LanguageCodeTag       = "language_code"
 	SKIP                  = "-"
 	Length                = "len"
 	SliceLength           = "slice_len"
@@ -149,17 +168,22 @@ const (
 	comma                 = ","
 	colon                 = ":"
 	ONEOF                 = "oneof"
+	RegexTag              = "regex"
 	// period                = "."
 	// hyphen = "-"
 )
 
 // PriorityTags define the priority order of the tag
 var PriorityTags = []string{ID, HyphenatedID, EmailTag, MacAddressTag, DomainNameTag, UserNameTag, URLTag, IPV4Tag,
-	IPV6Tag, PASSWORD, JWT, LATITUDE, LONGITUDE, CreditCardNumber, CreditCardType, PhoneNumber, TollFreeNumber,
+	IPV6Tag, PASSWORD, JWT, LATITUDE, LONGITUDE, CityTag, PostcodeTag, StreetAddressTag, FullAddressTag, CreditCardNumber,
+	CreditCardType, PhoneNumber, TollFreeNumber,
 	E164PhoneNumberTag, TitleMaleTag, TitleFemaleTag, FirstNameTag, FirstNameMaleTag, FirstNameFemaleTag, LastNameTag,
 	NAME, ChineseFirstNameTag, ChineseLastNameTag, ChineseNameTag, GENDER, UnixTimeTag, DATE, TIME, MonthNameTag,
 	YEAR, DayOfWeekTag, DayOfMonthTag, TIMESTAMP, CENTURY, TIMEZONE, TimePeriodTag, WORD, SENTENCE, PARAGRAPH,
-	CurrencyTag, AmountTag, AmountWithCurrencyTag, SKIP, Length, SliceLength, Language, BoundaryStart, BoundaryEnd, ONEOF,
+	CurrencyTag, AmountTag, AmountWithCurrencyTag, SemVerTag, UserAgentTag, MimeTypeTag, FilePathTag, HexColorTag,
+	CronTag, Base64Tag, HTTPMethodTag, HTTPStatusCodeTag, ISBN10Tag, ISBN13Tag, IBANTag, CountryCodeTag,
+	CountryCodeAlpha3Tag, LanguageCodeTag, SKIP, Length, SliceLength, Language, BoundaryStart, BoundaryEnd, ONEOF,
+	RegexTag,
 }
 
 var defaultTag = map[string]string{
@@ -176,6 +200,10 @@ var defaultTag = map[string]string{
 	CreditCardNumber:      CreditCardNumber,
 	LATITUDE:              LATITUDE,
 	LONGITUDE:             LONGITUDE,
+	CityTag:               CityTag,
+	PostcodeTag:           PostcodeTag,
+	StreetAddressTag:      StreetAddressTag,
+	FullAddressTag:        FullAddressTag,
 	PhoneNumber:           PhoneNumber,
 	TollFreeNumber:        TollFreeNumber,
 	E164PhoneNumberTag:    E164PhoneNumberTag,
@@ -200,6 +228,21 @@ var defaultTag = map[string]string{
 	CurrencyTag:           CurrencyTag,
 	AmountTag:             AmountTag,
 	AmountWithCurrencyTag: AmountWithCurrencyTag,
+	SemVerTag:             SemVerTag,
+	UserAgentTag:          UserAgentTag,
+	MimeTypeTag:           MimeTypeTag,
+	FilePathTag:           FilePathTag,
+	HexColorTag:           HexColorTag,
+	CronTag:               CronTag,
+	Base64Tag:             Base64Tag,
+	HTTPMethodTag:         HTTPMethodTag,
+	HTTPStatusCodeTag:     HTTPStatusCodeTag,
+	ISBN10Tag:             ISBN10Tag,
+	ISBN13Tag:             ISBN13Tag,
+	IBANTag:               IBANTag,
+	CountryCodeTag:        CountryCodeTag,
+	CountryCodeAlpha3Tag:  CountryCodeAlpha3Tag,
+	LanguageCodeTag:       LanguageCodeTag,
 	ID:                    ID,
 	HyphenatedID:          HyphenatedID,
 }
@@ -230,6 +273,10 @@ var mapperTag = map[string]TaggedFunction{
 	CreditCardNumber:      GetPayment().CreditCardNumber,
 	LATITUDE:              GetAddress().Latitude,
 	LONGITUDE:             GetAddress().Longitude,
+	CityTag:               GetAddress().City,
+	PostcodeTag:           GetAddress().Postcode,
+	StreetAddressTag:      GetAddress().StreetAddress,
+	FullAddressTag:        GetAddress().FullAddress,
 	PhoneNumber:           GetPhoner().PhoneNumber,
 	TollFreeNumber:        GetPhoner().TollFreePhoneNumber,
 	E164PhoneNumberTag:    GetPhoner().E164PhoneNumber,
@@ -258,10 +305,101 @@ var mapperTag = map[string]TaggedFunction{
 	CurrencyTag:           GetPrice().Currency,
 	AmountTag:             GetPrice().Amount,
 	AmountWithCurrencyTag: GetPrice().AmountWithCurrency,
+	SemVerTag:             GetSoftware().SemVer,
+	UserAgentTag:          GetSoftware().UserAgent,
+	MimeTypeTag:           GetSoftware().MimeType,
+	FilePathTag:           GetSoftware().FilePath,
+	HexColorTag:           GetSoftware().HexColor,
+	CronTag:               GetSoftware().Cron,
+	Base64Tag:             GetSoftware().Base64,
+	HTTPMethodTag:         GetProtocol().HTTPMethod,
+	HTTPStatusCodeTag:     GetProtocol().HTTPStatusCode,
+	ISBN10Tag:             GetStandard().ISBN10,
+	ISBN13Tag:             GetStandard().ISBN13,
+	IBANTag:               GetStandard().IBAN,
+	CountryCodeTag:        GetStandard().CountryCode,
+	CountryCodeAlpha3Tag:  GetStandard().CountryCodeAlpha3,
+	LanguageCodeTag:       GetStandard().LanguageCode,
 	ID:                    GetIdentifier().Digit,
 	HyphenatedID:          GetIdentifier().Hyphenated,
 }
 
+// fieldTagAliases are the field names of the tags which are abbreviated, e.g. lat for Latitude
+var fieldTagAliases = map[string]string{
+	"latitude":           LATITUDE,
+	"longitude":          LONGITUDE,
+	"lng":                LONGITUDE,
+	"credit_card_number": CreditCardNumber,
+	"card_number":        CreditCardNumber,
+	"credit_card_type":   CreditCardType,
+	"card_type":          CreditCardType,
+}
+
+// MatchFieldTag returns the tag of mapperTag which the field name is made of, e.g. user_agent for UserAgent and
+// email for ContactEmail. The tag or its alias must match whole words of the name, so that city does not match
+// Capacity. The tag of the most words wins, e.g. first_name instead of name for FirstName.
+func MatchFieldTag(fieldName string) (string, bool) {
+	words := fieldWords(fieldName)
+	best, bestName, bestWords := "", "", 0
+	match := func(name, tag string) {
+		nameWords := strings.Count(name, "_") + 1
+		if nameWords < bestWords || (nameWords == bestWords && name > bestName) || !containsWords(words, strings.Replace(name, "_", "", -1)) {
+			return
+		}
+		best, bestName, bestWords = tag, name, nameWords
+	}
+	for tag := range mapperTag {
+		match(tag, tag)
+	}
+	for alias, tag := range fieldTagAliases {
+		match(alias, tag)
+	}
+	return best, best != ""
+}
+
+// fieldWords splits the field name into the lower case words, e.g. HTTPStatusCode into http, status and code
+func fieldWords(name string) []string {
+	words := make([]string, 0)
+	runes := []rune(name)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		split := i == len(runes) || runes[i] == '_' || runes[i] == '-'
+		if !split && i > start && unicode.IsUpper(runes[i]) {
+			prev := runes[i-1]
+			split = unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		}
+		if !split {
+			continue
+		}
+		if i > start {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+		}
+		start = i
+		if i < len(runes) && (runes[i] == '_' || runes[i] == '-') {
+			start = i + 1
+		}
+	}
+	return words
+}
+
+// containsWords reports whether some consecutive words make up the tag without the underscores
+func containsWords(words []string, tag string) bool {
+	for i := range words {
+		joined := ""
+		for _, word := range words[i:] {
+			joined += word
+			if joined == tag {
+				return true
+			}
+			if len(joined) >= len(tag) {
+				break
+			}
+		}
+	}
+	return false
+}
+
 // Generic Error Messages for tags
 // 		ErrUnsupportedKindPtr: Error when get fake from ptr
 // 		ErrUnsupportedKind: Error on passing unsupported kind
@@ -411,8 +549,7 @@ func GetValue(t reflect.Type, level int) (reflect.Value, error) {
 	case reflect.Struct:
 		switch t.String() {
 		case "time.Time":
-			ft := time.Now().Add(time.Duration(rand.Int63()))
-			return reflect.ValueOf(ft), nil
+			return reflect.ValueOf(TimeValue()), nil
 		default:
 			v := reflect.New(t).Elem()
 			if v.NumField() >= 10 {
@@ -426,17 +563,11 @@ func GetValue(t reflect.Type, level int) (reflect.Value, error) {
 				switch {
 				case tags.fieldType == "":
 					// Firstly,let's do the tag search
-					fieldName := strings.ToLower(t.Field(i).Name)
 					var found bool
-					for tName, tagFunc := range mapperTag {
-						if found {
-							break
-						}
-						if strings.Contains(fieldName, tName) {
-							fake, err := tagFunc(v)
-							if err == nil {
-								SafeSet(v, i, level, &found, fake)
-							}
+					if tName, ok := MatchFieldTag(t.Field(i).Name); ok {
+						fake, err := mapperTag[tName](v)
+						if err == nil {
+							SafeSet(v, i, level, &found, fake)
 						}
 					}
 					if !found {
@@ -752,7 +883,13 @@ func userDefinedString(v reflect.Value, tag string) error {
 	var res interface{}
 	var err error
 
-	if tagFunc, ok := mapperTag[tag]; ok {
+	// the pattern might contain len or lang, so the regex goes first
+	if pattern, ok := ExtractRegexFromTag(tag); ok {
+		res, err = FromRegex(pattern)
+		if err != nil {
+			return fmt.Errorf("[userDefinedString] has FromRegex err: %v", err)
+		}
+	} else if tagFunc, ok := mapperTag[tag]; ok {
 		res, err = tagFunc(v)
 		if err != nil {
 			return fmt.Errorf("[userDefinedString] has tagFunc err: %v", err)
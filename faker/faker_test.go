@@ -144,3 +144,31 @@ func Test_isZero(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchFieldTag(t *testing.T) {
+	for name, want := range map[string]string{
+		"UserAgent":        UserAgentTag,
+		"user_agent":       UserAgentTag,
+		"HTTPStatusCode":   HTTPStatusCodeTag,
+		"E164PhoneNumber":  E164PhoneNumberTag,
+		"ContactEmail":     EmailTag,
+		"FirstName":        FirstNameTag,
+		"City":             CityTag,
+		"Capacity":         "",
+		"Velocity":         "",
+		"Acronym":          "",
+		"Message":          "",
+		"Latitude":         LATITUDE,
+		"Longitude":        LONGITUDE,
+		"Lat":              LATITUDE,
+		"Lng":              LONGITUDE,
+		"CreditCardNumber": CreditCardNumber,
+		"CardNumber":       CreditCardNumber,
+		"CreditCardType":   CreditCardType,
+		"PhoneNumber":      PhoneNumber,
+	} {
+		tag, ok := MatchFieldTag(name)
+		assert.Equal(t, want, tag, name)
+		assert.Equal(t, want != "", ok, name)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package faker
+
+import (
+	"reflect"
+	"strconv"
+)
+
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"}
+
+var httpStatusCodes = []int{
+	100, 101, 200, 201, 202, 204, 206, 301, 302, 303, 304, 307, 308, 400, 401, 403, 404, 405, 408, 409,
+	410, 413, 415, 422, 429, 500, 501, 502, 503, 504,
+}
+
+// Protocol is logical layer for the values of the http requests
+type Protocol interface {
+	HTTPMethod(v reflect.Value) (interface{}, error)
+	HTTPStatusCode(v reflect.Value) (interface{}, error)
+}
+
+// HTTP struct
+type HTTP struct{}
+
+var protocol Protocol
+
+// GetProtocol returns a new Protocol interface of HTTP
+func GetProtocol() Protocol {
+	if protocol == nil {
+		protocol = &HTTP{}
+	}
+	return protocol
+}
+
+// SetProtocol sets custom Protocol
+func SetProtocol(p Protocol) {
+	protocol = p
+}
+
+// HTTPMethod generates random http method
+func (h HTTP) HTTPMethod(v reflect.Value) (interface{}, error) {
+	return randomElementFromSliceString(httpMethods), nil
+}
+
+// HTTPMethod get http method randomly in string
+func HTTPMethod() string {
+	return randomElementFromSliceString(httpMethods)
+}
+
+// HTTPStatusCode generates random http status code. The string field gets the code in decimal,
+// and the number field gets the code in its own type.
+func (h HTTP) HTTPStatusCode(v reflect.Value) (interface{}, error) {
+	code := HTTPStatusCode()
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Itoa(code), nil
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return reflect.ValueOf(code).Convert(v.Type()).Interface(), nil
+	}
+	return code, nil
+}
+
+// HTTPStatusCode get http status code randomly in int
+func HTTPStatusCode() int {
+	return httpStatusCodes[rand.Intn(len(httpStatusCodes))]
+}
@@ -0,0 +1,37 @@
+package faker
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestHTTPMethod(t *testing.T) {
+	method, err := GetProtocol().HTTPMethod(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if !Contains(httpMethods, method.(string)) {
+		t.Error("Expected http method")
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	code, err := GetProtocol().HTTPStatusCode(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if code.(int) < 100 || code.(int) > 599 {
+		t.Errorf("Expected http status code, got %d", code)
+	}
+	var s string
+	code, _ = GetProtocol().HTTPStatusCode(reflect.ValueOf(s))
+	if _, err := strconv.Atoi(code.(string)); err != nil {
+		t.Errorf("Expected http status code in string, got %v", code)
+	}
+	var i32 int32
+	code, _ = GetProtocol().HTTPStatusCode(reflect.ValueOf(i32))
+	if _, ok := code.(int32); !ok {
+		t.Errorf("Expected http status code in int32, got %T", code)
+	}
+}
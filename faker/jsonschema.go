@@ -0,0 +1,677 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package faker
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bytedance/nxt_unit/atghelper"
+)
+
+const (
+	// jsonSchemaMaxDepth stops the recursive schemas, only the required properties are generated below it
+	jsonSchemaMaxDepth = 5
+	// jsonSchemaMaxRetries is how many times we generate the value until it is valid
+	jsonSchemaMaxRetries = 10
+	// the default range of the numbers and the lengths if the schema does not limit them
+	jsonSchemaNumberRange = 100
+	jsonSchemaMaxLength   = 10
+	jsonSchemaMaxItems    = 3
+)
+
+// JSONSchema is the part of JSON Schema (draft 7 and later) that faker understands:
+// types, required, enum, const, pattern, format, the bounds of numbers, strings and arrays,
+// and the local $ref to the definitions.
+type JSONSchema struct {
+	Ref              string                 `json:"$ref"`
+	Type             interface{}            `json:"type"` // a type name or a list of them
+	Properties       map[string]*JSONSchema `json:"properties"`
+	Required         []string               `json:"required"`
+	Items            *JSONSchema            `json:"items"`
+	Enum             []interface{}          `json:"enum"`
+	Const            interface{}            `json:"const"`
+	Pattern          string                 `json:"pattern"`
+	Format           string                 `json:"format"`
+	Minimum          *float64               `json:"minimum"`
+	Maximum          *float64               `json:"maximum"`
+	ExclusiveMinimum *float64               `json:"exclusiveMinimum"`
+	ExclusiveMaximum *float64               `json:"exclusiveMaximum"`
+	MinLength        *int                   `json:"minLength"`
+	MaxLength        *int                   `json:"maxLength"`
+	MinItems         *int                   `json:"minItems"`
+	MaxItems         *int                   `json:"maxItems"`
+	Definitions      map[string]*JSONSchema `json:"definitions"`
+	Defs             map[string]*JSONSchema `json:"$defs"`
+}
+
+// jsonSchemaFormats reuse the providers of faker to generate the strings of the formats
+var jsonSchemaFormats = map[string]func() string{
+	"email":     Email,
+	"uuid":      UUIDHyphenated,
+	"date-time": func() string { return strings.Replace(Timestamp(), " ", "T", 1) + "Z" },
+	"date":      Date,
+	"time":      TimeString,
+	"uri":       URL,
+	"url":       URL,
+	"hostname":  DomainName,
+	"ipv4":      IPv4,
+	"ipv6":      IPv6,
+}
+
+var (
+	emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	uuidRegexp  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// jsonSchemaValidators check the strings of the formats, the unknown formats are not checked
+var jsonSchemaValidators = map[string]func(s string) bool{
+	"email": emailRegexp.MatchString,
+	"uuid":  uuidRegexp.MatchString,
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(BaseDateFormat, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse(TimeFormat, s)
+		return err == nil
+	},
+	"uri": isAbsoluteURL,
+	"url": isAbsoluteURL,
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+}
+
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// ParseJSONSchema decodes the JSON Schema document
+func ParseJSONSchema(document []byte) (*JSONSchema, error) {
+	schema := &JSONSchema{}
+	if err := json.Unmarshal(document, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// FakeJSONSchema generates a value which honours the JSON Schema document.
+// The objects are map[string]interface{} and the arrays are []interface{}.
+func FakeJSONSchema(document []byte) (interface{}, error) {
+	schema, err := ParseJSONSchema(document)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Generate()
+}
+
+// FakeMapFromJSONSchema generates the object described by the JSON Schema document
+func FakeMapFromJSONSchema(document []byte) (map[string]interface{}, error) {
+	value, err := FakeJSONSchema(document)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the schema generates %T instead of an object", value)
+	}
+	return object, nil
+}
+
+// FakeStructFromJSONSchema fills the struct that ptr points to with the object described by the JSON Schema document.
+// The fields are matched by the json tag or the field name.
+func FakeStructFromJSONSchema(document []byte, ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf(ErrValueNotPtr)
+	}
+	value, err := FakeJSONSchema(document)
+	if err != nil {
+		return err
+	}
+	return assignJSONValue(v.Elem(), value)
+}
+
+// Generate returns a value which is valid against the schema
+func (s *JSONSchema) Generate() (interface{}, error) {
+	var err error
+	for i := 0; i < jsonSchemaMaxRetries; i++ {
+		var value interface{}
+		value, err = s.generate(s, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err = s.Validate(value); err == nil {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot generate a valid value: %v", err)
+}
+
+// Validate checks the value against the schema
+func (s *JSONSchema) Validate(value interface{}) error {
+	return s.validate(s, value, "$")
+}
+
+// resolve follows the $ref to the definitions of the root schema, e.g. #/definitions/User or #/$defs/User
+func (s *JSONSchema) resolve(root *JSONSchema) (*JSONSchema, error) {
+	for visited := 0; s.Ref != ""; visited++ {
+		if visited > jsonSchemaMaxDepth {
+			return nil, fmt.Errorf("the $ref %s is circular", s.Ref)
+		}
+		var definitions map[string]*JSONSchema
+		var name string
+		switch {
+		case strings.HasPrefix(s.Ref, "#/definitions/"):
+			definitions, name = root.Definitions, strings.TrimPrefix(s.Ref, "#/definitions/")
+		case strings.HasPrefix(s.Ref, "#/$defs/"):
+			definitions, name = root.Defs, strings.TrimPrefix(s.Ref, "#/$defs/")
+		case s.Ref == "#":
+			s = root
+			continue
+		default:
+			return nil, fmt.Errorf("unsupported $ref %s", s.Ref)
+		}
+		definition, ok := definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot find the $ref %s", s.Ref)
+		}
+		s = definition
+	}
+	return s, nil
+}
+
+// types returns the type names of the schema. The type is inferred if the schema does not declare it.
+func (s *JSONSchema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		res := make([]string, 0, len(t))
+		for _, name := range t {
+			if str, ok := name.(string); ok {
+				res = append(res, str)
+			}
+		}
+		return res
+	}
+	switch {
+	case s.Properties != nil:
+		return []string{"object"}
+	case s.Items != nil:
+		return []string{"array"}
+	case s.Minimum != nil || s.Maximum != nil || s.ExclusiveMinimum != nil || s.ExclusiveMaximum != nil:
+		return []string{"number"}
+	}
+	return []string{"string"}
+}
+
+func (s *JSONSchema) generate(root *JSONSchema, depth int) (interface{}, error) {
+	s, err := s.resolve(root)
+	if err != nil {
+		return nil, err
+	}
+	if s.Const != nil {
+		return s.Const, nil
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[rand.Intn(len(s.Enum))], nil
+	}
+	types := s.types()
+	// null is only chosen if it is the only type
+	candidates := make([]string, 0, len(types))
+	for _, t := range types {
+		if t != "null" {
+			candidates = append(candidates, t)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	switch t := candidates[rand.Intn(len(candidates))]; t {
+	case "object":
+		return s.generateObject(root, depth)
+	case "array":
+		return s.generateArray(root, depth)
+	case "string":
+		return s.generateString()
+	case "integer":
+		min, max := s.bounds()
+		low, high := int64(math.Ceil(min)), int64(math.Floor(max))
+		if low > high {
+			return nil, fmt.Errorf("no integer between %v and %v", min, max)
+		}
+		return int(low + rand.Int63n(high-low+1)), nil
+	case "number":
+		min, max := s.bounds()
+		return precision(min+rand.Float64()*(max-min), 2), nil
+	case "boolean":
+		return rand.Intn(2) == 0, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func (s *JSONSchema) generateObject(root *JSONSchema, depth int) (interface{}, error) {
+	object := make(map[string]interface{}, len(s.Properties))
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	// the map is out of order, sort it to keep the result reproducible with the same random source
+	sort.Strings(names)
+	for _, name := range names {
+		if !required[name] && (depth >= jsonSchemaMaxDepth || rand.Intn(2) == 0) {
+			continue
+		}
+		value, err := s.Properties[name].generate(root, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		object[name] = value
+	}
+	return object, nil
+}
+
+func (s *JSONSchema) generateArray(root *JSONSchema, depth int) (interface{}, error) {
+	min, max := 0, jsonSchemaMaxItems
+	if s.MinItems != nil {
+		min = *s.MinItems
+		if s.MaxItems == nil {
+			max = min + jsonSchemaMaxItems
+		}
+	}
+	if s.MaxItems != nil {
+		max = *s.MaxItems
+	}
+	if depth >= jsonSchemaMaxDepth {
+		max = min
+	}
+	if min > max {
+		return nil, fmt.Errorf("minItems %d is bigger than maxItems %d", min, max)
+	}
+	size := min + rand.Intn(max-min+1)
+	array := make([]interface{}, 0, size)
+	for i := 0; i < size; i++ {
+		if s.Items == nil {
+			array = append(array, Word())
+			continue
+		}
+		item, err := s.Items.generate(root, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, item)
+	}
+	return array, nil
+}
+
+func (s *JSONSchema) generateString() (interface{}, error) {
+	if format, ok := jsonSchemaFormats[s.Format]; ok {
+		return format(), nil
+	}
+	if s.Pattern != "" {
+		return FromRegex(s.Pattern)
+	}
+	min, max := 1, jsonSchemaMaxLength
+	if s.MinLength != nil {
+		min = *s.MinLength
+		if s.MaxLength == nil {
+			max = min + jsonSchemaMaxLength
+		}
+	}
+	if s.MaxLength != nil {
+		max = *s.MaxLength
+	}
+	if min > max {
+		return nil, fmt.Errorf("minLength %d is bigger than maxLength %d", min, max)
+	}
+	return atghelper.RandStringBytes(min + rand.Intn(max-min+1)), nil
+}
+
+// bounds returns the inclusive range of the numbers
+func (s *JSONSchema) bounds() (float64, float64) {
+	const epsilon = 0.01
+	min, max := math.Inf(-1), math.Inf(1)
+	if s.Minimum != nil {
+		min = *s.Minimum
+	}
+	if s.ExclusiveMinimum != nil && *s.ExclusiveMinimum+epsilon > min {
+		min = *s.ExclusiveMinimum + epsilon
+	}
+	if s.Maximum != nil {
+		max = *s.Maximum
+	}
+	if s.ExclusiveMaximum != nil && *s.ExclusiveMaximum-epsilon < max {
+		max = *s.ExclusiveMaximum - epsilon
+	}
+	switch {
+	case math.IsInf(min, -1) && math.IsInf(max, 1):
+		min, max = 0, jsonSchemaNumberRange
+	case math.IsInf(min, -1):
+		min = max - jsonSchemaNumberRange
+	case math.IsInf(max, 1):
+		max = min + jsonSchemaNumberRange
+	}
+	return min, max
+}
+
+func (s *JSONSchema) validate(root *JSONSchema, value interface{}, path string) error {
+	s, err := s.resolve(root)
+	if err != nil {
+		return err
+	}
+	if s.Const != nil && !jsonEqual(s.Const, value) {
+		return fmt.Errorf("%s: %v is not the const %v", path, value, s.Const)
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if jsonEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, s.Enum)
+		}
+	}
+	if s.Const != nil || len(s.Enum) > 0 {
+		return nil
+	}
+	var errs []string
+	for _, t := range s.types() {
+		err := s.validateType(root, t, value, path)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+func (s *JSONSchema) validateType(root *JSONSchema, t string, value interface{}, path string) error {
+	switch t {
+	case "null":
+		if value != nil {
+			return fmt.Errorf("%s: %v is not null", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: %v is not a boolean", path, value)
+		}
+	case "integer", "number":
+		number, ok := toFloat(value)
+		if !ok || (t == "integer" && number != math.Trunc(number)) {
+			return fmt.Errorf("%s: %v is not an %s", path, value, t)
+		}
+		if (s.Minimum != nil && number < *s.Minimum) || (s.ExclusiveMinimum != nil && number <= *s.ExclusiveMinimum) ||
+			(s.Maximum != nil && number > *s.Maximum) || (s.ExclusiveMaximum != nil && number >= *s.ExclusiveMaximum) {
+			return fmt.Errorf("%s: %v is out of range", path, value)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: %v is not a string", path, value)
+		}
+		length := utf8.RuneCountInString(str)
+		if (s.MinLength != nil && length < *s.MinLength) || (s.MaxLength != nil && length > *s.MaxLength) {
+			return fmt.Errorf("%s: the length of %q is out of range", path, str)
+		}
+		if s.Pattern != "" {
+			matched, err := regexp.MatchString(s.Pattern, str)
+			if err != nil || !matched {
+				return fmt.Errorf("%s: %q does not match %s", path, str, s.Pattern)
+			}
+		}
+		if validator, ok := jsonSchemaValidators[s.Format]; ok && !validator(str) {
+			return fmt.Errorf("%s: %q is not a valid %s", path, str, s.Format)
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: %v is not an array", path, value)
+		}
+		if (s.MinItems != nil && len(array) < *s.MinItems) || (s.MaxItems != nil && len(array) > *s.MaxItems) {
+			return fmt.Errorf("%s: the size %d is out of range", path, len(array))
+		}
+		if s.Items != nil {
+			for i, item := range array {
+				if err := s.Items.validate(root, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: %v is not an object", path, value)
+		}
+		for _, name := range s.Required {
+			if _, exist := object[name]; !exist {
+				return fmt.Errorf("%s: the required property %s is missing", path, name)
+			}
+		}
+		for name, property := range s.Properties {
+			if v, exist := object[name]; exist {
+				if err := property.validate(root, v, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("%s: unsupported type %s", path, t)
+	}
+	return nil
+}
+
+// jsonEqual compares the values regardless of the go type of the numbers
+func jsonEqual(a, b interface{}) bool {
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if okA && okB {
+		return fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// assignJSONValue sets the decoded JSON value to v like json.Unmarshal does
+func assignJSONValue(v reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return assignJSONValue(v.Elem(), value)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+		}
+		v.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return assignJSONTime(v, value)
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+		}
+		return assignJSONObject(v, object)
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(object))
+		for key, item := range object {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assignJSONValue(elem, item); err != nil {
+				return fmt.Errorf("%s: %v", key, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(array), len(array)))
+		}
+		for i := 0; i < len(array) && i < v.Len(); i++ {
+			if err := assignJSONValue(v.Index(i), array[i]); err != nil {
+				return fmt.Errorf("[%d]: %v", i, err)
+			}
+		}
+		return nil
+	case reflect.String:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+		}
+		v.SetString(str)
+		return nil
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) || v.OverflowInt(int64(number)) {
+			return fmt.Errorf("cannot assign %v to %s", value, v.Type())
+		}
+		v.SetInt(int64(number))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := toFloat(value)
+		if !ok || number < 0 || number != math.Trunc(number) || v.OverflowUint(uint64(number)) {
+			return fmt.Errorf("cannot assign %v to %s", value, v.Type())
+		}
+		v.SetUint(uint64(number))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		number, ok := toFloat(value)
+		if !ok || v.OverflowFloat(number) {
+			return fmt.Errorf("cannot assign %v to %s", value, v.Type())
+		}
+		v.SetFloat(number)
+		return nil
+	}
+	return fmt.Errorf(ErrUnsupportedKind, v.Kind())
+}
+
+func assignJSONObject(v reflect.Value, object map[string]interface{}) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		} else if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			// the fields of the embedded struct are promoted
+			if err := assignJSONObject(v.Field(i), object); err != nil {
+				return err
+			}
+			continue
+		}
+		value, exist := object[name]
+		if !exist {
+			// json.Unmarshal matches the keys case-insensitively as well
+			for key, item := range object {
+				if strings.EqualFold(key, name) {
+					value, exist = item, true
+					break
+				}
+			}
+		}
+		if !exist {
+			continue
+		}
+		if err := assignJSONValue(v.Field(i), value); err != nil {
+			return fmt.Errorf("%s: %v", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func assignJSONTime(v reflect.Value, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot assign %T to %s", value, v.Type())
+	}
+	for _, layout := range []string{time.RFC3339, BaseDateFormat} {
+		if tm, err := time.Parse(layout, str); err == nil {
+			v.Set(reflect.ValueOf(tm))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as time", str)
+}
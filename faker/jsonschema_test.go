@@ -0,0 +1,127 @@
+package faker
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+const orderSchema = `{
+	"type": "object",
+	"required": ["id", "email", "status", "amount", "items", "created_at", "buyer"],
+	"properties": {
+		"id": {"type": "string", "pattern": "^ORD-[0-9]{8}$"},
+		"email": {"type": "string", "format": "email"},
+		"trace_id": {"type": "string", "format": "uuid"},
+		"status": {"enum": ["created", "paid", "closed"]},
+		"amount": {"type": "number", "minimum": 1, "maximum": 1000},
+		"count": {"type": "integer", "exclusiveMinimum": 0, "maximum": 5},
+		"note": {"type": ["string", "null"], "minLength": 3, "maxLength": 6},
+		"items": {"type": "array", "minItems": 1, "maxItems": 3, "items": {"$ref": "#/definitions/item"}},
+		"created_at": {"type": "string", "format": "date-time"},
+		"buyer": {"$ref": "#/$defs/buyer"}
+	},
+	"definitions": {
+		"item": {"type": "object", "required": ["sku", "paid"], "properties": {"sku": {"type": "string"}, "paid": {"type": "boolean"}}}
+	},
+	"$defs": {
+		"buyer": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string", "minLength": 2}}}
+	}
+}`
+
+type schemaItem struct {
+	SKU  string `json:"sku"`
+	Paid bool   `json:"paid"`
+}
+
+type schemaOrder struct {
+	ID        string       `json:"id"`
+	Email     string       `json:"email"`
+	Status    string       `json:"status"`
+	Amount    float64      `json:"amount"`
+	Count     *int         `json:"count"`
+	Items     []schemaItem `json:"items"`
+	CreatedAt time.Time    `json:"created_at"`
+	Buyer     struct {
+		Name string
+	} `json:"buyer"`
+}
+
+func TestFakeMapFromJSONSchema(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(orderSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		order, err := FakeMapFromJSONSchema([]byte(orderSchema))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = schema.Validate(order); err != nil {
+			t.Error("Expected the valid order, got", err)
+		}
+		if !regexp.MustCompile(`^ORD-[0-9]{8}$`).MatchString(order["id"].(string)) {
+			t.Error("Expected the id to match the pattern, got", order["id"])
+		}
+	}
+}
+
+func TestFakeStructFromJSONSchema(t *testing.T) {
+	order := schemaOrder{}
+	if err := FakeStructFromJSONSchema([]byte(orderSchema), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.ID == "" || order.Email == "" || len(order.Items) == 0 || order.CreatedAt.IsZero() || order.Buyer.Name == "" {
+		t.Error("Expected the required fields to be filled, got", order)
+	}
+	if order.Amount < 1 || order.Amount > 1000 {
+		t.Error("Expected the amount in [1, 1000], got", order.Amount)
+	}
+	if order.Count != nil && (*order.Count <= 0 || *order.Count > 5) {
+		t.Error("Expected the count in (0, 5], got", *order.Count)
+	}
+	if err := FakeStructFromJSONSchema([]byte(orderSchema), order); err == nil {
+		t.Error("Expected error for the non-pointer value")
+	}
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(orderSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := []map[string]interface{}{
+		{},
+		{"id": "ORD-1", "email": "a@b.com", "status": "paid", "amount": 10, "items": []interface{}{map[string]interface{}{"sku": "a", "paid": true}},
+			"created_at": "2023-05-01T10:00:00Z", "buyer": map[string]interface{}{"name": "Bob"}},
+		{"id": "ORD-12345678", "email": "a@b.com", "status": "lost", "amount": 10, "items": []interface{}{map[string]interface{}{"sku": "a", "paid": true}},
+			"created_at": "2023-05-01T10:00:00Z", "buyer": map[string]interface{}{"name": "Bob"}},
+		{"id": "ORD-12345678", "email": "a@b.com", "status": "paid", "amount": 10, "items": []interface{}{},
+			"created_at": "2023-05-01T10:00:00Z", "buyer": map[string]interface{}{"name": "Bob"}},
+		{"id": "ORD-12345678", "email": "a@b.com", "status": "paid", "amount": 10, "items": []interface{}{map[string]interface{}{"sku": "a", "paid": true}},
+			"created_at": "2023-05-01 10:00:00", "buyer": map[string]interface{}{"name": "Bob"}},
+	}
+	for i, order := range invalid {
+		if err := schema.Validate(order); err == nil {
+			t.Errorf("Expected case %d to be invalid", i)
+		}
+	}
+	valid := map[string]interface{}{"id": "ORD-12345678", "email": "a@b.com", "status": "paid", "amount": 10,
+		"items": []interface{}{map[string]interface{}{"sku": "a", "paid": true}}, "created_at": "2023-05-01T10:00:00Z",
+		"buyer": map[string]interface{}{"name": "Bob"}, "note": nil}
+	if err := schema.Validate(valid); err != nil {
+		t.Error("Expected the valid order, got", err)
+	}
+}
+
+func TestFakeJSONSchemaError(t *testing.T) {
+	if _, err := FakeJSONSchema([]byte(`{"type": "string", "minLength": 5, "maxLength": 1}`)); err == nil {
+		t.Error("Expected error for the impossible length")
+	}
+	if _, err := FakeJSONSchema([]byte(`{"$ref": "#/definitions/missing"}`)); err == nil {
+		t.Error("Expected error for the missing $ref")
+	}
+	if _, err := FakeMapFromJSONSchema([]byte(`{"type": "integer"}`)); err == nil {
+		t.Error("Expected error for the schema which is not an object")
+	}
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package faker
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// Locale is the bundle of the data which differs from one country to another.
+// The formats use # for a digit, ^ for 1-9 and % for 2-9, e.g. "(%##) %##-####".
+type Locale struct {
+	Code             string   `json:"code"`
+	NameFormat       string   `json:"name_format"` // e.g. "{first} {last}" or "{last}{first}"
+	FirstNamesMale   []string `json:"first_names_male"`
+	FirstNamesFemale []string `json:"first_names_female"`
+	LastNames        []string `json:"last_names"`
+	LastNamesFemale  []string `json:"last_names_female"` // for the languages whose last names have genders
+	States           []string `json:"states"`
+	Cities           []string `json:"cities"`
+	Streets          []string `json:"streets"`
+	BuildingFormats  []string `json:"building_formats"`
+	PostcodeFormats  []string `json:"postcode_formats"`
+	AddressFormat    string   `json:"address_format"` // e.g. "{building} {street}, {city}, {state} {postcode}"
+	PhoneFormats     []string `json:"phone_formats"`
+	TollFreeFormats  []string `json:"toll_free_formats"`
+	E164Formats      []string `json:"e164_formats"`
+	Currencies       []string `json:"currencies"`
+	Words            []string `json:"words"`
+	WordSeparator    *string  `json:"word_separator"` // nil means a space
+	SentenceEnd      string   `json:"sentence_end"`   // empty means a period
+}
+
+var (
+	// localeLock guards locales and currentLocale, since the values are generated in parallel
+	localeLock sync.RWMutex
+	// locales holds the embedded bundles and the ones registered by RegisterLocale
+	locales = map[string]*Locale{}
+	// currentLocale is nil until SetLocale is called, then the built-in English data is replaced
+	currentLocale *Locale
+)
+
+func init() {
+	files, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		f, err := localeFS.Open(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		locale, err := LoadLocale(f)
+		f.Close()
+		if err != nil {
+			panic(fmt.Sprintf("invalid locale bundle %s: %v", file.Name(), err))
+		}
+		locales[locale.Code] = locale
+	}
+}
+
+// LoadLocale decodes a locale bundle in JSON, so that the users can bring their own data
+func LoadLocale(r io.Reader) (*Locale, error) {
+	locale := &Locale{}
+	if err := json.NewDecoder(r).Decode(locale); err != nil {
+		return nil, err
+	}
+	locale.Code = normalizeLocaleCode(locale.Code)
+	if locale.Code == "" {
+		return nil, fmt.Errorf("the locale code is empty")
+	}
+	return locale, nil
+}
+
+// RegisterLocale adds or replaces a locale bundle. The empty fields fall back to the built-in data.
+func RegisterLocale(locale *Locale) error {
+	if locale == nil || normalizeLocaleCode(locale.Code) == "" {
+		return fmt.Errorf("the locale code is empty")
+	}
+	locale.Code = normalizeLocaleCode(locale.Code)
+	localeLock.Lock()
+	defer localeLock.Unlock()
+	locales[locale.Code] = locale
+	return nil
+}
+
+// SetLocale selects the locale bundle by its code, e.g. zh_CN or zh-CN.
+// The empty code restores the built-in data.
+func SetLocale(code string) error {
+	code, err := FindLocale(code)
+	if err != nil {
+		return err
+	}
+	localeLock.Lock()
+	defer localeLock.Unlock()
+	currentLocale = locales[code]
+	return nil
+}
+
+// FindLocale returns the code of the locale bundle as SetLocale selects it, or the error if there is no such bundle.
+// The empty code is the built-in data.
+func FindLocale(code string) (string, error) {
+	code = normalizeLocaleCode(code)
+	if code == "" {
+		return "", nil
+	}
+	if findLocale(code) == nil {
+		return "", fmt.Errorf("unsupported locale %s, the supported locales are %v", code, Locales())
+	}
+	return code, nil
+}
+
+// GetLocale returns the code of the selected locale, empty if none is selected
+func GetLocale() string {
+	if locale := selectedLocale(); locale != nil {
+		return locale.Code
+	}
+	return ""
+}
+
+// selectedLocale returns the locale selected by SetLocale, nil for the built-in data
+func selectedLocale() *Locale {
+	localeLock.RLock()
+	defer localeLock.RUnlock()
+	return currentLocale
+}
+
+func findLocale(code string) *Locale {
+	localeLock.RLock()
+	defer localeLock.RUnlock()
+	return locales[code]
+}
+
+// Locales returns the codes of all the available locales
+func Locales() []string {
+	localeLock.RLock()
+	defer localeLock.RUnlock()
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func normalizeLocaleCode(code string) string {
+	return strings.ReplaceAll(strings.TrimSpace(code), "-", "_")
+}
+
+// localeStrings returns the data of the selected locale, or the fallback if the locale does not have it
+func localeStrings(get func(l *Locale) []string, fallback []string) []string {
+	if locale := selectedLocale(); locale != nil {
+		if data := get(locale); len(data) > 0 {
+			return data
+		}
+	}
+	return fallback
+}
+
+// numerify replaces # with a digit, ^ with 1-9 and % with 2-9
+func numerify(format string) string {
+	var builder strings.Builder
+	for _, r := range format {
+		switch r {
+		case '#':
+			builder.WriteByte(byte('0' + rand.Intn(10)))
+		case '^':
+			builder.WriteByte(byte('1' + rand.Intn(9)))
+		case '%':
+			builder.WriteByte(byte('2' + rand.Intn(8)))
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
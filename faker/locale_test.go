@@ -0,0 +1,157 @@
+package faker
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+func TestLocales(t *testing.T) {
+	for _, code := range []string{"en_US", "ja_JP", "pt_BR", "ru_RU", "zh_CN"} {
+		if err := SetLocale(code); err != nil {
+			t.Error("Expected the embedded locale", code, err)
+		}
+	}
+	defer SetLocale("")
+	if err := SetLocale("zh-CN"); err != nil || GetLocale() != "zh_CN" {
+		t.Error("Expected zh-CN to be the same as zh_CN")
+	}
+	if err := SetLocale("xx_XX"); err == nil {
+		t.Error("Expected error for the unsupported locale")
+	}
+	if err := SetLocale(""); err != nil || GetLocale() != "" {
+		t.Error("Expected the empty code to restore the built-in data")
+	}
+}
+
+var e164Patterns = map[string]*regexp.Regexp{
+	"en_US": regexp.MustCompile(`^\+1[2-9]\d{2}[2-9]\d{6}$`),
+	"zh_CN": regexp.MustCompile(`^\+86(1[358]\d{9}|10[1-9]\d{7})$`),
+	"ja_JP": regexp.MustCompile(`^\+81(\d{10}|\d{9})$`),
+	"ru_RU": regexp.MustCompile(`^\+7\d{10}$`),
+	"pt_BR": regexp.MustCompile(`^\+55\d{10,11}$`),
+}
+
+func TestLocaleE164PhoneNumber(t *testing.T) {
+	defer SetLocale("")
+	for code, pattern := range e164Patterns {
+		if err := SetLocale(code); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 20; i++ {
+			if ph := E164PhoneNumber(); !pattern.MatchString(ph) {
+				t.Errorf("Expected E164 phone number of %s, got %s", code, ph)
+			}
+		}
+	}
+}
+
+func TestLocalePhoneNumber(t *testing.T) {
+	defer SetLocale("")
+	SetLocale("ru_RU")
+	if ph := Phonenumber(); !strings.HasPrefix(ph, "8 (") {
+		t.Error("Expected the russian phone number to start with 8, got", ph)
+	}
+	if ph := TollFreePhoneNumber(); !strings.HasPrefix(ph, "8 (800)") {
+		t.Error("Expected the russian toll free number to start with 8 (800), got", ph)
+	}
+	SetLocale("pt_BR")
+	if ph := Phonenumber(); !regexp.MustCompile(`^\(\d{2}\) \d{4,5}-\d{4}$`).MatchString(ph) {
+		t.Error("Expected the brazilian phone number, got", ph)
+	}
+}
+
+func TestLocaleAddress(t *testing.T) {
+	defer SetLocale("")
+	if address := FullAddress(); !regexp.MustCompile(`^\d+ .+, .+, [A-Z]{2} \d{5}(-\d{4})?$`).MatchString(address) {
+		t.Error("Expected en_US address without locale, got", address)
+	}
+	SetLocale("zh_CN")
+	if address := FullAddress(); !strings.HasSuffix(address, "号") || strings.Contains(address, " ") {
+		t.Error("Expected the chinese address, got", address)
+	}
+	if street := StreetAddress(); !strings.HasSuffix(street, "号") {
+		t.Error("Expected the chinese street address to end with 号, got", street)
+	}
+	SetLocale("ja_JP")
+	if address := FullAddress(); !regexp.MustCompile(`^〒\d{3}-\d{4} `).MatchString(address) {
+		t.Error("Expected the japanese address to start with the postcode, got", address)
+	}
+	SetLocale("pt_BR")
+	if postcode := Postcode(); !regexp.MustCompile(`^\d{5}-\d{3}$`).MatchString(postcode) {
+		t.Error("Expected the brazilian CEP, got", postcode)
+	}
+	if street := StreetAddress(); !regexp.MustCompile(`^.+, \d+$`).MatchString(street) {
+		t.Error("Expected the brazilian street address, got", street)
+	}
+}
+
+func TestLocaleNameAndCurrency(t *testing.T) {
+	defer SetLocale("")
+	SetLocale("zh_CN")
+	name := Name()
+	if strings.Contains(name, " ") || !unicode.Is(unicode.Han, []rune(name)[0]) {
+		t.Error("Expected the chinese name, got", name)
+	}
+	if Currency() != "CNY" {
+		t.Error("Expected CNY for zh_CN")
+	}
+	if sentence := Sentence(); !strings.HasSuffix(sentence, "。") {
+		t.Error("Expected the chinese sentence, got", sentence)
+	}
+	SetLocale("ru_RU")
+	if !unicode.Is(unicode.Cyrillic, []rune(LastName())[0]) {
+		t.Error("Expected the russian last name")
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	defer SetLocale("")
+	locale, err := LoadLocale(strings.NewReader(`{"code": "de-DE", "currencies": ["EUR"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = RegisterLocale(locale); err != nil {
+		t.Fatal(err)
+	}
+	if err = SetLocale("de_DE"); err != nil {
+		t.Fatal(err)
+	}
+	if Currency() != "EUR" {
+		t.Error("Expected EUR for de_DE")
+	}
+	// the missing data falls back to the built-in one
+	if Word() == "" || FullAddress() == "" {
+		t.Error("Expected the built-in data for the missing fields")
+	}
+	delete(locales, "de_DE")
+	if _, err = LoadLocale(strings.NewReader(`{}`)); err == nil {
+		t.Error("Expected error for the bundle without code")
+	}
+}
+
+func TestLocaleConcurrency(t *testing.T) {
+	defer SetLocale("")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				SetLocale("zh_CN")
+				RegisterLocale(&Locale{Code: "yy_YY", Words: []string{"smart"}})
+				SetLocale("")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = Name() + Word() + Phonenumber() + Currency() + GetLocale()
+				_ = Locales()
+			}
+		}()
+	}
+	wg.Wait()
+}
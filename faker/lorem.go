@@ -93,7 +93,7 @@ type Lorem struct {
 }
 
 func (l Lorem) word() string {
-	return randomElementFromSliceString(wordList)
+	return randomElementFromSliceString(localeStrings(func(l *Locale) []string { return l.Words }, wordList))
 }
 
 // Word returns a word from the wordList const
@@ -108,20 +108,22 @@ func Word() string {
 }
 
 func (l Lorem) sentence() string {
+	words := localeStrings(func(l *Locale) []string { return l.Words }, wordList)
+	separator, end := l.punctuation()
 	sentence := ""
 	r, _ := RandomInt(1, 6)
 	size := len(r)
 	for key, val := range r {
 		if key == 0 {
-			sentence += strings.Title(wordList[val])
+			sentence += strings.Title(words[val%len(words)])
 		} else {
-			sentence += wordList[val]
+			sentence += words[val%len(words)]
 		}
 		if key != size-1 {
-			sentence += " "
+			sentence += separator
 		}
 	}
-	return fmt.Sprintf("%s.", sentence)
+	return fmt.Sprintf("%s%s", sentence, end)
 }
 
 // Sentence returns a sentence using the wordList const
@@ -136,13 +138,30 @@ func Sentence() string {
 	return i.sentence()
 }
 
+// punctuation returns the word separator and the sentence end of the words in use
+func (l Lorem) punctuation() (string, string) {
+	separator, end := " ", "."
+	locale := selectedLocale()
+	if locale == nil || len(locale.Words) == 0 {
+		return separator, end
+	}
+	if locale.WordSeparator != nil {
+		separator = *locale.WordSeparator
+	}
+	if locale.SentenceEnd != "" {
+		end = locale.SentenceEnd
+	}
+	return separator, end
+}
+
 func (l Lorem) paragraph() string {
 	paragraph := ""
+	separator, _ := l.punctuation()
 	size := rand.Intn(10) + 1
 	for i := 0; i < size; i++ {
 		paragraph += l.sentence()
 		if i != size-1 {
-			paragraph += " "
+			paragraph += separator
 		}
 	}
 	return paragraph
@@ -24,6 +24,7 @@ package faker
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // Dowser provides interfaces to generate random logical Names with their initials
@@ -238,6 +239,12 @@ func TitleFemale() string {
 }
 
 func (p Person) firstname() string {
+	if selectedLocale() != nil {
+		if rand.Intn(2) == 0 {
+			return p.firstnamefemale()
+		}
+		return p.firstnamemale()
+	}
 	return randomElementFromSliceString(firstNames)
 }
 
@@ -253,7 +260,7 @@ func FirstName() string {
 }
 
 func (p Person) firstnamemale() string {
-	return randomElementFromSliceString(firstNamesMale)
+	return randomElementFromSliceString(localeStrings(func(l *Locale) []string { return l.FirstNamesMale }, firstNamesMale))
 }
 
 // FirstNameMale returns first names for males
@@ -268,7 +275,7 @@ func FirstNameMale() string {
 }
 
 func (p Person) firstnamefemale() string {
-	return randomElementFromSliceString(firstNamesFemale)
+	return randomElementFromSliceString(localeStrings(func(l *Locale) []string { return l.FirstNamesFemale }, firstNamesFemale))
 }
 
 // FirstNameFemale returns first names for females
@@ -283,7 +290,15 @@ func FirstNameFemale() string {
 }
 
 func (p Person) lastname() string {
-	return randomElementFromSliceString(lastNames)
+	return randomElementFromSliceString(localeStrings(func(l *Locale) []string { return l.LastNames }, lastNames))
+}
+
+func (p Person) lastnamefemale() string {
+	locale := selectedLocale()
+	if locale == nil || len(locale.LastNamesFemale) == 0 {
+		return p.lastname()
+	}
+	return randomElementFromSliceString(locale.LastNamesFemale)
 }
 
 // LastName returns last name
@@ -298,6 +313,18 @@ func LastName() string {
 }
 
 func (p Person) name() string {
+	// the titles are English, thus the name of the locale does not have one
+	if locale := selectedLocale(); locale != nil {
+		first, last := p.firstnamemale(), p.lastname()
+		if rand.Intn(2) == 0 {
+			first, last = p.firstnamefemale(), p.lastnamefemale()
+		}
+		format := locale.NameFormat
+		if format == "" {
+			format = "{first} {last}"
+		}
+		return strings.NewReplacer("{first}", first, "{last}", last).Replace(format)
+	}
 	if randNameFlag > 50 {
 		return fmt.Sprintf("%s %s %s", randomElementFromSliceString(titlesFemale), randomElementFromSliceString(firstNamesFemale), randomElementFromSliceString(lastNames))
 	}
@@ -54,6 +54,9 @@ type Phone struct {
 }
 
 func (p Phone) phonenumber() string {
+	if formats := localeStrings(func(l *Locale) []string { return l.PhoneFormats }, nil); len(formats) > 0 {
+		return numerify(randomElementFromSliceString(formats))
+	}
 	randInt, _ := RandomInt(1, 10)
 	str := strings.Join(IntToString(randInt), "")
 	return fmt.Sprintf("%s-%s-%s", str[:3], str[3:6], str[6:10])
@@ -71,6 +74,9 @@ func Phonenumber() string {
 }
 
 func (p Phone) tollfreephonenumber() string {
+	if formats := localeStrings(func(l *Locale) []string { return l.TollFreeFormats }, nil); len(formats) > 0 {
+		return numerify(randomElementFromSliceString(formats))
+	}
 	out := ""
 	boxDigitsStart := []string{"777", "888"}
 
@@ -96,6 +102,9 @@ func TollFreePhoneNumber() string {
 }
 
 func (p Phone) e164PhoneNumber() string {
+	if formats := localeStrings(func(l *Locale) []string { return l.E164Formats }, nil); len(formats) > 0 {
+		return numerify(randomElementFromSliceString(formats))
+	}
 	out := ""
 	boxDigitsStart := []string{"7", "8"}
 	ints, _ := RandomInt(1, 10)
@@ -78,7 +78,7 @@ func SetPrice(p Money) {
 }
 
 func (p Price) currency() string {
-	return randomElementFromSliceString(currencies)
+	return randomElementFromSliceString(localeStrings(func(l *Locale) []string { return l.Currencies }, currencies))
 }
 
 // Currency returns a random currency from currencies
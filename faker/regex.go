@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package faker
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+const (
+	// regexMaxRepeat bounds the unlimited repetitions such as *, + and {n,}
+	regexMaxRepeat = 10
+	// regexMaxRetries is how many times we generate the string until it matches the pattern
+	regexMaxRetries = 10
+)
+
+// printableRange is preferred when the character class contains it, so that the strings are readable
+var printableRange = [2]rune{0x20, 0x7e}
+
+// FromRegex generates a string which matches the pattern in the RE2 syntax, e.g. ^ORD-[0-9]{8}$.
+// The unlimited repetitions such as * and + are repeated at most regexMaxRepeat times.
+func FromRegex(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	re = re.Simplify()
+	for i := 0; i < regexMaxRetries; i++ {
+		var builder strings.Builder
+		if err = generateRegex(&builder, re); err != nil {
+			return "", err
+		}
+		if matcher.MatchString(builder.String()) {
+			return builder.String(), nil
+		}
+	}
+	return "", fmt.Errorf("cannot generate the string matching %s", pattern)
+}
+
+func generateRegex(builder *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("the regexp %s matches nothing", re.String())
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nil
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			builder.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		builder.WriteRune(randomRuneFromClass(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		builder.WriteRune(randomRuneFromClass([]rune{'0', '9', 'A', 'Z', 'a', 'z'}))
+	case syntax.OpCapture:
+		return generateRegex(builder, re.Sub[0])
+	case syntax.OpStar:
+		return repeatRegex(builder, re.Sub[0], 0, regexMaxRepeat)
+	case syntax.OpPlus:
+		return repeatRegex(builder, re.Sub[0], 1, regexMaxRepeat)
+	case syntax.OpQuest:
+		return repeatRegex(builder, re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		max := re.Max
+		if max < 0 {
+			max = re.Min + regexMaxRepeat
+		}
+		return repeatRegex(builder, re.Sub[0], re.Min, max)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := generateRegex(builder, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		return generateRegex(builder, re.Sub[rand.Intn(len(re.Sub))])
+	default:
+		return fmt.Errorf("unsupported regexp %s", re.String())
+	}
+	return nil
+}
+
+func repeatRegex(builder *strings.Builder, re *syntax.Regexp, min, max int) error {
+	count := min
+	if max > min {
+		count += rand.Intn(max - min + 1)
+	}
+	for i := 0; i < count; i++ {
+		if err := generateRegex(builder, re); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// randomRuneFromClass picks a rune from the ranges [lo, hi, lo, hi, ...] of the character class.
+// The printable ASCII characters are preferred, because the negated class covers the whole unicode.
+func randomRuneFromClass(ranges []rune) rune {
+	printable := make([]rune, 0, len(ranges))
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < printableRange[0] {
+			lo = printableRange[0]
+		}
+		if hi > printableRange[1] {
+			hi = printableRange[1]
+		}
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	}
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	if total == 0 {
+		return 'a'
+	}
+	n := rand.Intn(total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n)
+		}
+		n -= size
+	}
+	return ranges[0]
+}
+
+// ExtractRegexFromTag returns the pattern of the faker tag, e.g. faker:"regex=^ORD-[0-9]{8}$"
+func ExtractRegexFromTag(tag string) (string, bool) {
+	if !strings.HasPrefix(tag, RegexTag+Equals) {
+		return "", false
+	}
+	return strings.TrimPrefix(tag, RegexTag+Equals), true
+}
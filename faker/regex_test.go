@@ -0,0 +1,46 @@
+package faker
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFromRegex(t *testing.T) {
+	patterns := []string{`^ORD-[0-9]{8}$`, `[a-f0-9]{4}-\d+`, `^(foo|bar)_[^a-z]{2,4}$`, `^\w+@example\.com$`, `^.?x*$`}
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(pattern)
+		for i := 0; i < 20; i++ {
+			s, err := FromRegex(pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !re.MatchString(s) {
+				t.Errorf("Expected %q to match %s", s, pattern)
+			}
+		}
+	}
+	if _, err := FromRegex(`[`); err == nil {
+		t.Error("Expected error for the invalid pattern")
+	}
+}
+
+func TestRegexTag(t *testing.T) {
+	type Order struct {
+		ID string `faker:"regex=^ORD-[0-9]{8}$"`
+	}
+	value, err := GetValue(reflect.TypeOf(Order{}), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := value.Interface().(Order)
+	if !regexp.MustCompile(`^ORD-[0-9]{8}$`).MatchString(order.ID) {
+		t.Errorf("Expected %q to match the regex tag", order.ID)
+	}
+	if pattern, ok := ExtractRegexFromTag("regex=^a,b$"); !ok || pattern != "^a,b$" {
+		t.Errorf("Expected the pattern ^a,b$, got %q", pattern)
+	}
+	if _, ok := ExtractRegexFromTag("email"); ok {
+		t.Error("Expected no pattern in the email tag")
+	}
+}
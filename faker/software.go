@@ -0,0 +1,201 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package faker
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var userAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+	"curl/8.4.0",
+	"Go-http-client/1.1",
+	"okhttp/4.12.0",
+	"python-requests/2.31.0",
+}
+
+var mimeTypes = []string{
+	"application/json", "application/xml", "application/pdf", "application/zip", "application/octet-stream",
+	"application/x-www-form-urlencoded", "application/javascript", "application/grpc", "application/x-protobuf",
+	"multipart/form-data", "text/plain", "text/html", "text/css", "text/csv", "image/png", "image/jpeg",
+	"image/gif", "image/webp", "image/svg+xml", "audio/mpeg", "video/mp4",
+}
+
+var fileDirs = []string{"usr", "local", "var", "log", "home", "tmp", "opt", "data", "etc", "conf", "cache", "output"}
+var fileNames = []string{"config", "index", "main", "data", "report", "backup", "service", "app", "access", "error"}
+var fileExtensions = []string{"go", "json", "yaml", "txt", "log", "csv", "xml", "conf", "tar.gz", "png"}
+
+// cronFields are the ranges of minute, hour, day of month, month and day of week
+var cronFields = [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// Softwarer is logical layer for the values of the software, e.g. versions and file paths
+type Softwarer interface {
+	SemVer(v reflect.Value) (interface{}, error)
+	UserAgent(v reflect.Value) (interface{}, error)
+	MimeType(v reflect.Value) (interface{}, error)
+	FilePath(v reflect.Value) (interface{}, error)
+	HexColor(v reflect.Value) (interface{}, error)
+	Cron(v reflect.Value) (interface{}, error)
+	Base64(v reflect.Value) (interface{}, error)
+}
+
+// Software struct
+type Software struct{}
+
+var software Softwarer
+
+// GetSoftware returns a new Softwarer interface of Software
+func GetSoftware() Softwarer {
+	if software == nil {
+		software = &Software{}
+	}
+	return software
+}
+
+// SetSoftware sets custom Softwarer
+func SetSoftware(s Softwarer) {
+	software = s
+}
+
+func (s Software) semVer() string {
+	version := fmt.Sprintf("%d.%d.%d", rand.Intn(10), rand.Intn(20), rand.Intn(50))
+	if rand.Intn(4) == 0 {
+		version += fmt.Sprintf("-%s.%d", randomElementFromSliceString([]string{"alpha", "beta", "rc"}), rand.Intn(5)+1)
+	}
+	return version
+}
+
+// SemVer generates random semantic version, e.g. 1.4.2 or 2.0.0-rc.1
+func (s Software) SemVer(v reflect.Value) (interface{}, error) {
+	return s.semVer(), nil
+}
+
+// SemVer get semantic version randomly in string
+func SemVer() string {
+	s := Software{}
+	return s.semVer()
+}
+
+// UserAgent generates random user agent of the browsers and the http clients
+func (s Software) UserAgent(v reflect.Value) (interface{}, error) {
+	return randomElementFromSliceString(userAgents), nil
+}
+
+// UserAgent get user agent randomly in string
+func UserAgent() string {
+	return randomElementFromSliceString(userAgents)
+}
+
+// MimeType generates random MIME type
+func (s Software) MimeType(v reflect.Value) (interface{}, error) {
+	return randomElementFromSliceString(mimeTypes), nil
+}
+
+// MimeType get MIME type randomly in string
+func MimeType() string {
+	return randomElementFromSliceString(mimeTypes)
+}
+
+func (s Software) filePath() string {
+	depth := rand.Intn(3) + 1
+	parts := make([]string, 0, depth+1)
+	for i := 0; i < depth; i++ {
+		parts = append(parts, randomElementFromSliceString(fileDirs))
+	}
+	parts = append(parts, randomElementFromSliceString(fileNames)+"."+randomElementFromSliceString(fileExtensions))
+	return "/" + strings.Join(parts, "/")
+}
+
+// FilePath generates random absolute unix file path
+func (s Software) FilePath(v reflect.Value) (interface{}, error) {
+	return s.filePath(), nil
+}
+
+// FilePath get absolute unix file path randomly in string
+func FilePath() string {
+	s := Software{}
+	return s.filePath()
+}
+
+func (s Software) hexColor() string {
+	return fmt.Sprintf("#%06x", rand.Intn(0x1000000))
+}
+
+// HexColor generates random hex color, e.g. #1a2b3c
+func (s Software) HexColor(v reflect.Value) (interface{}, error) {
+	return s.hexColor(), nil
+}
+
+// HexColor get hex color randomly in string
+func HexColor() string {
+	s := Software{}
+	return s.hexColor()
+}
+
+func (s Software) cron() string {
+	fields := make([]string, 0, len(cronFields))
+	for _, field := range cronFields {
+		min, max := field[0], field[1]
+		switch rand.Intn(4) {
+		case 0:
+			fields = append(fields, "*")
+		case 1:
+			fields = append(fields, fmt.Sprintf("%d", min+rand.Intn(max-min+1)))
+		case 2:
+			fields = append(fields, fmt.Sprintf("*/%d", rand.Intn(max-min)+1))
+		default:
+			start := min + rand.Intn(max-min)
+			fields = append(fields, fmt.Sprintf("%d-%d", start, start+1+rand.Intn(max-start)))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// Cron generates random cron expression with five fields, e.g. */5 0-6 * * 1
+func (s Software) Cron(v reflect.Value) (interface{}, error) {
+	return s.cron(), nil
+}
+
+// CronExpression get cron expression randomly in string
+func CronExpression() string {
+	s := Software{}
+	return s.cron()
+}
+
+func (s Software) base64() string {
+	b := make([]byte, rand.Intn(32)+16)
+	rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// Base64 generates random base64 blob in the standard encoding
+func (s Software) Base64(v reflect.Value) (interface{}, error) {
+	return s.base64(), nil
+}
+
+// Base64 get base64 blob randomly in string
+func Base64() string {
+	s := Software{}
+	return s.base64()
+}
@@ -0,0 +1,114 @@
+package faker
+
+import (
+	"encoding/base64"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSemVer(t *testing.T) {
+	re := regexp.MustCompile(`^\d+\.\d+\.\d+(-(alpha|beta|rc)\.\d+)?$`)
+	for i := 0; i < 20; i++ {
+		version, err := GetSoftware().SemVer(reflect.Value{})
+		if err != nil {
+			t.Error("Expected  not error, got err", err)
+		}
+		if !re.MatchString(version.(string)) {
+			t.Errorf("Expected semantic version, got %s", version)
+		}
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	ua, err := GetSoftware().UserAgent(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if !Contains(userAgents, ua.(string)) {
+		t.Error("Expected user agent")
+	}
+	if UserAgent() == "" {
+		t.Error("Expected user agent")
+	}
+}
+
+func TestMimeType(t *testing.T) {
+	mime, err := GetSoftware().MimeType(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if strings.Count(mime.(string), "/") != 1 {
+		t.Error("Expected MIME type")
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	path, err := GetSoftware().FilePath(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if !strings.HasPrefix(path.(string), "/") || !strings.Contains(path.(string), ".") {
+		t.Errorf("Expected file path, got %s", path)
+	}
+}
+
+func TestHexColor(t *testing.T) {
+	color, err := GetSoftware().HexColor(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if !regexp.MustCompile(`^#[0-9a-f]{6}$`).MatchString(color.(string)) {
+		t.Errorf("Expected hex color, got %s", color)
+	}
+}
+
+func TestCron(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		cron, err := GetSoftware().Cron(reflect.Value{})
+		if err != nil {
+			t.Error("Expected  not error, got err", err)
+		}
+		fields := strings.Fields(cron.(string))
+		if len(fields) != len(cronFields) {
+			t.Fatalf("Expected cron expression with five fields, got %s", cron)
+		}
+		for j, field := range fields {
+			if field == "*" {
+				continue
+			}
+			var numbers []string
+			if strings.HasPrefix(field, "*/") {
+				numbers = []string{strings.TrimPrefix(field, "*/")}
+			} else {
+				numbers = strings.Split(field, "-")
+			}
+			for _, number := range numbers {
+				n, err := strconv.Atoi(number)
+				if err != nil || n > cronFields[j][1] {
+					t.Fatalf("Expected valid cron field, got %s in %s", field, cron)
+				}
+			}
+		}
+	}
+}
+
+func TestBase64(t *testing.T) {
+	blob, err := GetSoftware().Base64(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if _, err := base64.StdEncoding.DecodeString(blob.(string)); err != nil {
+		t.Errorf("Expected base64 blob, got %s", blob)
+	}
+}
+
+func TestSetSoftware(t *testing.T) {
+	SetSoftware(Software{})
+	if _, ok := GetSoftware().(Software); !ok {
+		t.Error("Expected the custom Softwarer")
+	}
+	SetSoftware(&Software{})
+}
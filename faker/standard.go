@@ -0,0 +1,215 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package faker
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Country Codes | Source: https://en.wikipedia.org/wiki/ISO_3166-1
+var countryCodes = [][2]string{
+	{"US", "USA"}, {"CN", "CHN"}, {"JP", "JPN"}, {"KR", "KOR"}, {"SG", "SGP"}, {"IN", "IND"}, {"ID", "IDN"},
+	{"TH", "THA"}, {"VN", "VNM"}, {"MY", "MYS"}, {"PH", "PHL"}, {"AU", "AUS"}, {"NZ", "NZL"}, {"CA", "CAN"},
+	{"MX", "MEX"}, {"BR", "BRA"}, {"AR", "ARG"}, {"GB", "GBR"}, {"IE", "IRL"}, {"FR", "FRA"}, {"DE", "DEU"},
+	{"IT", "ITA"}, {"ES", "ESP"}, {"PT", "PRT"}, {"NL", "NLD"}, {"BE", "BEL"}, {"CH", "CHE"}, {"SE", "SWE"},
+	{"NO", "NOR"}, {"FI", "FIN"}, {"PL", "POL"}, {"RU", "RUS"}, {"TR", "TUR"}, {"AE", "ARE"}, {"SA", "SAU"},
+	{"EG", "EGY"}, {"ZA", "ZAF"}, {"NG", "NGA"}, {"KE", "KEN"},
+}
+
+// Language Codes | Source: https://en.wikipedia.org/wiki/List_of_ISO_639-1_codes
+var languageCodes = []string{
+	"en", "zh", "ja", "ko", "ru", "pt", "es", "fr", "de", "it", "nl", "sv", "pl", "tr", "ar", "hi", "id", "th", "vi",
+}
+
+// ibanFormats are the BBAN of the countries, # is a digit and A is an upper letter
+// Source: https://en.wikipedia.org/wiki/International_Bank_Account_Number
+var ibanFormats = [][2]string{
+	{"DE", "##################"},
+	{"GB", "AAAA##############"},
+	{"NL", "AAAA##########"},
+	{"FR", "#######################"},
+	{"ES", "####################"},
+	{"IT", "A######################"},
+}
+
+// Standard is logical layer for the codes defined by the standards, e.g. ISBN, IBAN and ISO 3166
+type Standard interface {
+	ISBN10(v reflect.Value) (interface{}, error)
+	ISBN13(v reflect.Value) (interface{}, error)
+	IBAN(v reflect.Value) (interface{}, error)
+	CountryCode(v reflect.Value) (interface{}, error)
+	CountryCodeAlpha3(v reflect.Value) (interface{}, error)
+	LanguageCode(v reflect.Value) (interface{}, error)
+}
+
+// StandardCode struct
+type StandardCode struct{}
+
+var standard Standard
+
+// GetStandard returns a new Standard interface of StandardCode
+func GetStandard() Standard {
+	if standard == nil {
+		standard = &StandardCode{}
+	}
+	return standard
+}
+
+// SetStandard sets custom Standard
+func SetStandard(s Standard) {
+	standard = s
+}
+
+func randomDigits(n int) []int {
+	digits := make([]int, n)
+	for i := range digits {
+		digits[i] = rand.Intn(10)
+	}
+	return digits
+}
+
+func joinDigits(digits []int) string {
+	var builder strings.Builder
+	for _, d := range digits {
+		builder.WriteByte(byte('0' + d))
+	}
+	return builder.String()
+}
+
+func (s StandardCode) isbn10() string {
+	digits := randomDigits(9)
+	sum := 0
+	for i, d := range digits {
+		sum += (10 - i) * d
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return joinDigits(digits) + "X"
+	}
+	return joinDigits(digits) + fmt.Sprint(check)
+}
+
+// ISBN10 generates random ISBN-10 with the valid check digit
+func (s StandardCode) ISBN10(v reflect.Value) (interface{}, error) {
+	return s.isbn10(), nil
+}
+
+// ISBN10 get ISBN-10 randomly in string
+func ISBN10() string {
+	s := StandardCode{}
+	return s.isbn10()
+}
+
+func (s StandardCode) isbn13() string {
+	digits := append([]int{9, 7, 8 + rand.Intn(2)}, randomDigits(9)...)
+	sum := 0
+	for i, d := range digits {
+		if i%2 == 0 {
+			sum += d
+		} else {
+			sum += 3 * d
+		}
+	}
+	return joinDigits(digits) + fmt.Sprint((10-sum%10)%10)
+}
+
+// ISBN13 generates random ISBN-13 with the valid check digit
+func (s StandardCode) ISBN13(v reflect.Value) (interface{}, error) {
+	return s.isbn13(), nil
+}
+
+// ISBN13 get ISBN-13 randomly in string
+func ISBN13() string {
+	s := StandardCode{}
+	return s.isbn13()
+}
+
+func (s StandardCode) iban() string {
+	format := ibanFormats[rand.Intn(len(ibanFormats))]
+	country := format[0]
+	var bban strings.Builder
+	for _, r := range format[1] {
+		if r == 'A' {
+			bban.WriteByte(byte('A' + rand.Intn(26)))
+		} else {
+			bban.WriteByte(byte('0' + rand.Intn(10)))
+		}
+	}
+	return country + fmt.Sprintf("%02d", ibanCheckDigits(country, bban.String())) + bban.String()
+}
+
+// ibanCheckDigits computes the check digits by ISO 7064 MOD 97-10
+func ibanCheckDigits(country, bban string) int {
+	mod := ibanMod97(bban + country + "00")
+	return 98 - mod
+}
+
+func ibanMod97(s string) int {
+	var numeric strings.Builder
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(fmt.Sprint(r - 'A' + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(numeric.String(), 10)
+	return int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
+
+// IBAN generates random IBAN with the valid check digits
+func (s StandardCode) IBAN(v reflect.Value) (interface{}, error) {
+	return s.iban(), nil
+}
+
+// IBAN get IBAN randomly in string
+func IBAN() string {
+	s := StandardCode{}
+	return s.iban()
+}
+
+// CountryCode generates random ISO 3166-1 alpha-2 country code
+func (s StandardCode) CountryCode(v reflect.Value) (interface{}, error) {
+	return CountryCode(), nil
+}
+
+// CountryCode get ISO 3166-1 alpha-2 country code randomly in string, e.g. CN
+func CountryCode() string {
+	return countryCodes[rand.Intn(len(countryCodes))][0]
+}
+
+// CountryCodeAlpha3 generates random ISO 3166-1 alpha-3 country code
+func (s StandardCode) CountryCodeAlpha3(v reflect.Value) (interface{}, error) {
+	return CountryCodeAlpha3(), nil
+}
+
+// CountryCodeAlpha3 get ISO 3166-1 alpha-3 country code randomly in string, e.g. CHN
+func CountryCodeAlpha3() string {
+	return countryCodes[rand.Intn(len(countryCodes))][1]
+}
+
+// LanguageCode generates random ISO 639-1 language code
+func (s StandardCode) LanguageCode(v reflect.Value) (interface{}, error) {
+	return LanguageCode(), nil
+}
+
+// LanguageCode get ISO 639-1 language code randomly in string, e.g. zh
+func LanguageCode() string {
+	return randomElementFromSliceString(languageCodes)
+}
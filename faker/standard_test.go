@@ -0,0 +1,102 @@
+package faker
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestISBN10(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		isbn, err := GetStandard().ISBN10(reflect.Value{})
+		if err != nil {
+			t.Error("Expected  not error, got err", err)
+		}
+		sum := 0
+		for j, r := range isbn.(string) {
+			d := int(r - '0')
+			if r == 'X' {
+				d = 10
+			}
+			sum += (10 - j) * d
+		}
+		if len(isbn.(string)) != 10 || sum%11 != 0 {
+			t.Errorf("Expected valid ISBN-10, got %s", isbn)
+		}
+	}
+}
+
+func TestISBN13(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		isbn, err := GetStandard().ISBN13(reflect.Value{})
+		if err != nil {
+			t.Error("Expected  not error, got err", err)
+		}
+		sum := 0
+		for j, r := range isbn.(string) {
+			if j%2 == 0 {
+				sum += int(r - '0')
+			} else {
+				sum += 3 * int(r-'0')
+			}
+		}
+		if len(isbn.(string)) != 13 || !strings.HasPrefix(isbn.(string), "97") || sum%10 != 0 {
+			t.Errorf("Expected valid ISBN-13, got %s", isbn)
+		}
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	re := regexp.MustCompile(`^[A-Z]{2}\d{2}[0-9A-Z]+$`)
+	for i := 0; i < 20; i++ {
+		iban, err := GetStandard().IBAN(reflect.Value{})
+		if err != nil {
+			t.Error("Expected  not error, got err", err)
+		}
+		s := iban.(string)
+		if !re.MatchString(s) || ibanMod97(s[4:]+s[:4]) != 1 {
+			t.Errorf("Expected valid IBAN, got %s", s)
+		}
+	}
+}
+
+func TestCountryAndLanguageCode(t *testing.T) {
+	code, err := GetStandard().CountryCode(reflect.Value{})
+	if err != nil {
+		t.Error("Expected  not error, got err", err)
+	}
+	if !regexp.MustCompile(`^[A-Z]{2}$`).MatchString(code.(string)) {
+		t.Errorf("Expected alpha-2 country code, got %s", code)
+	}
+	code, _ = GetStandard().CountryCodeAlpha3(reflect.Value{})
+	if !regexp.MustCompile(`^[A-Z]{3}$`).MatchString(code.(string)) {
+		t.Errorf("Expected alpha-3 country code, got %s", code)
+	}
+	code, _ = GetStandard().LanguageCode(reflect.Value{})
+	if !Contains(languageCodes, code.(string)) {
+		t.Errorf("Expected language code, got %s", code)
+	}
+}
+
+func TestStandardTags(t *testing.T) {
+	type Book struct {
+		ISBN     string `faker:"isbn13"`
+		Account  string `faker:"iban"`
+		Country  string `faker:"country_code"`
+		Method   string `faker:"http_method"`
+		Status   int    `faker:"http_status_code"`
+		Version  string `faker:"semver"`
+		Schedule string `faker:"cron"`
+		Color    string `faker:"hex_color"`
+	}
+	value, err := GetValue(reflect.TypeOf(Book{}), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	book := value.Interface().(Book)
+	if len(book.ISBN) != 13 || len(book.Country) != 2 || !Contains(httpMethods, book.Method) || book.Status < 100 ||
+		book.Version == "" || book.Schedule == "" || !strings.HasPrefix(book.Color, "#") || book.Account == "" {
+		t.Errorf("Expected the tagged fields, got %+v", book)
+	}
+}
@@ -22,9 +22,14 @@ import (
 	"os/exec"
 	"path"
 	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bytedance/nxt_unit/atgconstant"
 	"github.com/bytedance/nxt_unit/atghelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/graph"
+	"github.com/bytedance/nxt_unit/faker"
 	matePkgManager "github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
 	"github.com/bytedance/nxt_unit/manager/lifemanager"
 	"github.com/bytedance/nxt_unit/manager/logextractor"
@@ -42,7 +47,24 @@ var (
 	ReceiverName   = flag.String("receiver_name", "", "used to receive the receiver name")
 	ReceiverIsStar = flag.Bool("receiver_is_start", false, "used to know the receiver has a pointer")
 	templateType   = flag.Int("template_type", 0, "special template type")
-	UseMockType    = flag.Int("use_mock_type", atgconstant.UseMockUnknown, "default is mockito. use nomock=1,mockito=2, gomonkey=3. gomonkey support go>=1.17")
+	UseMockType    = mockTypeFlag("use_mock_type", atgconstant.UseMockUnknown, "default is mockito. use nomock=1,mockito=2, gomonkey=3, interface=4, or the names none, mockito, gomonkey, interface. gomonkey support go>=1.17. interface mocks the interface dependencies without patching. none patches nothing and fills the interfaces, function fields and function variables with the plain stubs")
+	referenceTime  = flag.String("reference_time", "", "anchor the generated dates to a fixed time in RFC3339, e.g. 2023-05-01T10:00:00Z")
+	fakerLocale    = flag.String("locale", "", "locale of the fake names, addresses and phone numbers, e.g. en_US, zh_CN, ja_JP, ru_RU, pt_BR")
+	mockCallAssert = flag.String("assert_mock_calls", atgconstant.MockCallAssertArgs, "how the gomonkey final suite asserts the calls of the mocked functions. use args, count or none")
+	strictCtxArg   = flag.Bool("strict_context_arg", false, "the context arguments of the mocked functions must not be nil")
+	strictPtrArg   = flag.Bool("strict_pointer_arg", false, "compare the pointer arguments of the mocked functions by the pointed values instead of the nil-ness")
+	httpStandIn    = flag.Bool("http_stand_in", false, "serve the net/http client calls of the tested functions from an httptest server instead of the network")
+	sqlStandIn     = flag.Bool("sql_stand_in", false, "generate the *sql.DB inputs as in-memory databases which return the generated rows and driver errors")
+	redisStandIn   = flag.Bool("redis_stand_in", false, "serve the go-redis clients of the tested functions from an in-process redis stand-in with generated hits and misses")
+	fakeClock      = flag.Bool("fake_clock", false, "freeze or step the clock of the tested functions which call time.Now, time.Since or time.Sleep. gomonkey only")
+	sandbox        = flag.Bool("sandbox", false, "run the tested functions which read the environment or the files with the generated environment keys and files in a temporary working directory")
+	snapGlobals    = flag.Bool("snapshot_globals", false, "snapshot the package variables which the tested functions read or write before each case, generate the ones of the basic types and restore them after")
+	mockPolicy     = flag.String("mock_policy", "", "JSON file of the packages to always mock, never mock or mock beyond a call depth, and the default depth. the direct callees are mocked without it")
+	errorAssert    = flag.String("assert_errors", "none", "how the final suite checks the returned errors, tried in order: is checks the sentinel errors by errors.Is, as checks the error types by errors.As and message compares the message, which might change between runs. none only checks whether there is an error")
+	reruns         = flag.Int("reruns", 0, "re-run each case this many times in the middle code and ignore the result fields which differ between the runs, e.g. the timestamps and the random IDs. 0 compares every field")
+	panicTests     = flag.String("panic_tests", "", "keep one case per panic site of the tested functions: expect asserts the value and the type of the panic, reproduce keeps the skipped reproducer with a TODO for bug hunting. the cases which panic are dropped without it")
+	faultInjection = flag.Bool("fault_injection", false, "after the random cases, re-run the tested functions once per error result of each mocked callee with the error injected, including the sentinel errors of the callee's package, and keep the cases which reach new branches")
+	realImpl       = flag.Bool("use_real_implementation", false, "satisfy the interface params with the implementations of the module instead of the stubs")
 	versionFlag    = flag.Bool("v", false, "Print the current version and exit")
 	currentTag     = "unknown"
 )
@@ -152,20 +174,40 @@ func Plugin() error {
 	if *ReceiverIsStar && *ReceiverName != "" {
 		*ReceiverName = fmt.Sprint("*", *ReceiverName)
 	}
+	policy, err := GetMockPolicy()
+	if err != nil {
+		return err
+	}
 	option := atgconstant.Options{
-		FilePath:      *filePath,
-		Level:         1,
-		Maxtime:       4,
-		MinUnit:       *minUnit,
-		Uid:           atghelper.RandStringBytes(10),
-		FuncName:      *funcName,
-		DebugMode:     *debugMode,
-		Usage:         *usage,
-		DirectoryPath: dir,
-		ReceiverName:  *ReceiverName,
-		UseMockType:   GetUseMockType(dir),
-	}
-	var err error
+		FilePath:              *filePath,
+		Level:                 1,
+		Maxtime:               4,
+		MinUnit:               *minUnit,
+		Uid:                   atghelper.RandStringBytes(10),
+		FuncName:              *funcName,
+		DebugMode:             *debugMode,
+		Usage:                 *usage,
+		DirectoryPath:         dir,
+		ReceiverName:          *ReceiverName,
+		UseMockType:           GetUseMockType(dir),
+		ReferenceTime:         GetReferenceTime(),
+		UseRealImplementation: *realImpl,
+		Locale:                GetLocale(),
+		MockCallAssertion:     GetMockCallAssertion(),
+		StrictContextArg:      *strictCtxArg,
+		StrictPointerArg:      *strictPtrArg,
+		HTTPStandIn:           *httpStandIn,
+		SQLStandIn:            *sqlStandIn,
+		RedisStandIn:          *redisStandIn,
+		FakeClock:             *fakeClock,
+		Sandbox:               *sandbox,
+		SnapshotGlobals:       *snapGlobals,
+		MockPolicy:            policy,
+		FaultInjection:        *faultInjection,
+		ErrorAssertion:        GetErrorAssertion(),
+		Reruns:                *reruns,
+		PanicTests:            GetPanicTests(),
+	}
 	// warning :not delete println,plugin get necessary msg
 	// logextractor.ExecutionLog.Log(fmt.Sprintf("plugin sdk use UID is %v\n", option.Uid))
 	err = staticcase.UpdateSmartUnit(dir)
@@ -212,21 +254,41 @@ func Template() error {
 	if *ReceiverIsStar && *ReceiverName != "" {
 		*ReceiverName = fmt.Sprint("*", *ReceiverName)
 	}
+	policy, err := GetMockPolicy()
+	if err != nil {
+		return err
+	}
 	option := atgconstant.Options{
-		FilePath:      *filePath,
-		Level:         1,
-		Maxtime:       4,
-		MinUnit:       *minUnit,
-		Uid:           atghelper.RandStringBytes(10),
-		FuncName:      *funcName,
-		DebugMode:     *debugMode,
-		Usage:         *usage,
-		DirectoryPath: dir,
-		ReceiverName:  *ReceiverName,
-		TemplateType:  *templateType,
-		UseMockType:   GetUseMockType(dir),
-	}
-	var err error
+		FilePath:              *filePath,
+		Level:                 1,
+		Maxtime:               4,
+		MinUnit:               *minUnit,
+		Uid:                   atghelper.RandStringBytes(10),
+		FuncName:              *funcName,
+		DebugMode:             *debugMode,
+		Usage:                 *usage,
+		DirectoryPath:         dir,
+		ReceiverName:          *ReceiverName,
+		TemplateType:          *templateType,
+		UseMockType:           GetUseMockType(dir),
+		ReferenceTime:         GetReferenceTime(),
+		UseRealImplementation: *realImpl,
+		Locale:                GetLocale(),
+		MockCallAssertion:     GetMockCallAssertion(),
+		StrictContextArg:      *strictCtxArg,
+		StrictPointerArg:      *strictPtrArg,
+		HTTPStandIn:           *httpStandIn,
+		SQLStandIn:            *sqlStandIn,
+		RedisStandIn:          *redisStandIn,
+		FakeClock:             *fakeClock,
+		Sandbox:               *sandbox,
+		SnapshotGlobals:       *snapGlobals,
+		MockPolicy:            policy,
+		FaultInjection:        *faultInjection,
+		ErrorAssertion:        GetErrorAssertion(),
+		Reruns:                *reruns,
+		PanicTests:            GetPanicTests(),
+	}
 	// fmt.Errorf("the error belongs to %w, the detail is %v", logextractor.MiddleCodeGenerateError, err.Error())
 	// warning :not delete println,plugin get necessary msg
 	logextractor.ExecutionLog.Log(fmt.Sprintf("plugin sdk use UID is %v\n", option.Uid))
@@ -274,10 +336,123 @@ func SplitFunctionTask() {
 	fmt.Println("Successfully generate the unit test!")
 }
 
+// GetReferenceTime returns the unix time that the generated dates are anchored to.
+// It returns 0 if the flag is empty or invalid, which means time.Now() is used.
+func GetReferenceTime() int64 {
+	if *referenceTime == "" {
+		return 0
+	}
+	ref, err := time.Parse(time.RFC3339, *referenceTime)
+	if err != nil {
+		logextractor.ExecutionLog.Log(fmt.Sprintf("invalid reference_time %v: %v", *referenceTime, err))
+		return 0
+	}
+	return ref.Unix()
+}
+
+// GetLocale returns the faker locale of the generated values, which the middle code selects. It returns empty
+// if the locale is not supported, which means the built-in English data is used.
+func GetLocale() string {
+	code, err := faker.FindLocale(*fakerLocale)
+	if err != nil {
+		logextractor.ExecutionLog.Log(err.Error())
+		return ""
+	}
+	return code
+}
+
+// GetMockCallAssertion returns how the final suite asserts the calls of the mocked functions.
+// It falls back to args if the flag is unknown.
+func GetMockCallAssertion() string {
+	switch *mockCallAssert {
+	case atgconstant.MockCallAssertArgs, atgconstant.MockCallAssertCount, atgconstant.MockCallAssertNone:
+		return *mockCallAssert
+	}
+	logextractor.ExecutionLog.Log(fmt.Sprintf("invalid assert_mock_calls %v, use %v", *mockCallAssert, atgconstant.MockCallAssertArgs))
+	return atgconstant.MockCallAssertArgs
+}
+
+// mockTypeNames are the names of the mock types which -use_mock_type takes besides the numbers
+var mockTypeNames = map[string]int{
+	"none":      atgconstant.UseNoMock,
+	"nomock":    atgconstant.UseNoMock,
+	"mockito":   atgconstant.UseMockitoMock,
+	"gomonkey":  atgconstant.UseGoMonkeyMock,
+	"interface": atgconstant.UseInterfaceMock,
+}
+
+type mockType int
+
+func (m *mockType) String() string {
+	return strconv.Itoa(int(*m))
+}
+
+func (m *mockType) Set(s string) error {
+	if value, ok := mockTypeNames[s]; ok {
+		*m = mockType(value)
+		return nil
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("unknown mock type %v", s)
+	}
+	*m = mockType(value)
+	return nil
+}
+
+// mockTypeFlag defines the mock type flag, which takes the number or the name
+func mockTypeFlag(name string, value int, usage string) *int {
+	p := &value
+	flag.Var((*mockType)(p), name, usage)
+	return p
+}
+
+// GetErrorAssertion returns the strategies by which the final suite checks the returned errors.
+// The invalid ones are left out.
+func GetErrorAssertion() []string {
+	strategies := make([]string, 0)
+	for _, strategy := range strings.Split(*errorAssert, ",") {
+		switch strategy = strings.TrimSpace(strategy); strategy {
+		case atgconstant.ErrorAssertIs, atgconstant.ErrorAssertAs, atgconstant.ErrorAssertMessage:
+			strategies = append(strategies, strategy)
+		case atgconstant.ErrorAssertNone, "":
+		default:
+			logextractor.ExecutionLog.Log(fmt.Sprintf("invalid assert_errors %v, use is, as, message or none", strategy))
+		}
+	}
+	return strategies
+}
+
+// GetPanicTests returns how the cases which panic are kept, empty if the mode is invalid
+func GetPanicTests() string {
+	switch *panicTests {
+	case "", atgconstant.PanicTestExpect, atgconstant.PanicTestReproduce:
+		return *panicTests
+	}
+	logextractor.ExecutionLog.Log(fmt.Sprintf("invalid panic_tests %v, use expect or reproduce", *panicTests))
+	return ""
+}
+
+// GetMockPolicy loads the mock policy file. It returns nil without the file, which means the direct callees are mocked.
+func GetMockPolicy() (*atgconstant.MockPolicy, error) {
+	if *mockPolicy == "" {
+		return nil, nil
+	}
+	return graph.LoadMockPolicy(*mockPolicy)
+}
+
 func GetUseMockType(dir string) int {
 	// switch *UseMockType {
 	// case mateAtgconstant.UseMockUnknown:
 	// 	return mateAtgconstant.UseGoMonkeyMock
 	// }
+	switch *UseMockType {
+	// the interface mock needs neither -gcflags=all=-N -l nor the arch-specific patching
+	case atgconstant.UseInterfaceMock:
+		return atgconstant.UseInterfaceMock
+	// neither does none, which works under -race as well
+	case atgconstant.UseNoMock:
+		return atgconstant.UseNoMock
+	}
 	return atgconstant.UseGoMonkeyMock
 }
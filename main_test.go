@@ -172,3 +172,19 @@ func TestDeferGoExistCallGraph(t *testing.T) {
 	defer patch.Reset()
 	Plugin()
 }
+
+func TestMockTypeFlag(t *testing.T) {
+	defer func(old int) {
+		*UseMockType = old
+	}(*UseMockType)
+	convey.Convey("TestMockTypeFlag", t, func() {
+		m := (*mockType)(UseMockType)
+		convey.So(m.Set("none"), convey.ShouldBeNil)
+		convey.So(GetUseMockType(""), convey.ShouldEqual, atgconstant.UseNoMock)
+		convey.So(m.Set("4"), convey.ShouldBeNil)
+		convey.So(GetUseMockType(""), convey.ShouldEqual, atgconstant.UseInterfaceMock)
+		convey.So(m.Set("mockito"), convey.ShouldBeNil)
+		convey.So(GetUseMockType(""), convey.ShouldEqual, atgconstant.UseGoMonkeyMock)
+		convey.So(m.Set("patch"), convey.ShouldNotBeNil)
+	})
+}
@@ -30,6 +30,7 @@ func NewPackageManager() *DuplicatePackageManager {
 		CheckPkgMap:   make(map[string]string, 0),
 		GlobalBuilder: []string{},
 		MiddleBuilder: []string{},
+		StubBuilder:   []string{},
 	}
 }
 
@@ -55,6 +56,7 @@ type DuplicatePackageManager struct {
 	tempImports    atgconstant.ImportInfo
 	GlobalBuilder  []string
 	MiddleBuilder  []string
+	StubBuilder    []string
 	UniquePkgMap   sync.Map          //key:pkg_name,key:pkg_path
 	CheckPkgMap    map[string]string //key:pkg_path,key:pkg_name
 }
@@ -116,6 +118,15 @@ func (d *DuplicatePackageManager) GetMiddleBuilder() []string {
 	return d.MiddleBuilder
 }
 
+// SetStubBuilder records the declaration of the interface stub, the final suite declares it again
+func (d *DuplicatePackageManager) SetStubBuilder(builder string) {
+	d.StubBuilder = append(d.StubBuilder, builder)
+}
+
+func (d *DuplicatePackageManager) GetStubBuilder() []string {
+	return d.StubBuilder
+}
+
 // PutAndGet PkgName are supposed to be not empty. if that's empty, let's parse its pkg name.
 func (d *DuplicatePackageManager) PutAndGet(pkgName string, pkgPath string) (string, string) {
 	if pkgPath == "" {
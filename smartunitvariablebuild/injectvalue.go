@@ -1,17 +1,29 @@
 package smartunitvariablebuild
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 func NewSpecialValueInjector() *SpecialValueInjector {
 	return &SpecialValueInjector{
 		ValueMap:   map[string]reflect.Value{},
 		BuilderMap: map[string]string{},
+		StubMap:    map[string]reflect.Type{},
+		ImplMap:    map[string]func() interface{}{},
+		ImplCode:   map[reflect.Type]string{},
 	}
 }
 
 type SpecialValueInjector struct {
 	ValueMap   map[string]reflect.Value
 	BuilderMap map[string]string
+	// key: interface type, value: the stub struct implementing the interface
+	StubMap map[string]reflect.Type
+	// key: interface type, value: the builder of the implementation of the module
+	ImplMap map[string]func() interface{}
+	// key: implementation type, value: how the test case builds it, empty if the fields are generated
+	ImplCode map[reflect.Type]string
 }
 
 func (s *SpecialValueInjector) Set(key string, value reflect.Value) {
@@ -29,7 +41,106 @@ func (s *SpecialValueInjector) GetCode(value reflect.Value) (string, bool) {
 	return v, exist
 }
 
+// GetBuilderCode returns how the test case builds the pointer which SetBuilder registered, e.g. the client
+// of the redis stand-in. The other values of the same type are not built by the code.
+func GetBuilderCode(ctx context.Context, v reflect.Value) (string, bool) {
+	injector, ok := ctx.Value("SpecialValueInjector").(*SpecialValueInjector)
+	if !ok || !v.IsValid() || v.Kind() != reflect.Ptr || v.IsNil() {
+		return "", false
+	}
+	registered, exist := injector.ValueMap[v.Type().String()]
+	if !exist || registered.Kind() != reflect.Ptr || registered.Pointer() != v.Pointer() {
+		return "", false
+	}
+	return injector.GetCode(v)
+}
+
 func (s *SpecialValueInjector) Get(key string) (reflect.Value, bool) {
 	v, exist := s.ValueMap[key]
 	return v, exist
 }
+
+// SetStub registers the stub for the interface. iface is a nil pointer to the interface,
+// e.g. injector.SetStub((*DBInterface)(nil), &StubDBInterface{})
+func (s *SpecialValueInjector) SetStub(iface interface{}, stub interface{}) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || reflect.TypeOf(stub) == nil {
+		return
+	}
+	s.StubMap[t.Elem().String()] = reflect.TypeOf(stub)
+}
+
+func (s *SpecialValueInjector) GetStub(t reflect.Type) (reflect.Type, bool) {
+	stub, exist := s.StubMap[t.String()]
+	return stub, exist
+}
+
+// GetInterfaceStub returns the stub type registered for the interface t
+func GetInterfaceStub(ctx context.Context, t reflect.Type) (reflect.Type, bool) {
+	injector, ok := ctx.Value("SpecialValueInjector").(*SpecialValueInjector)
+	if !ok || t == nil {
+		return nil, false
+	}
+	return injector.GetStub(t)
+}
+
+// IsInterfaceStub reports whether t is one of the registered stub types
+func IsInterfaceStub(ctx context.Context, t reflect.Type) bool {
+	injector, ok := ctx.Value("SpecialValueInjector").(*SpecialValueInjector)
+	if !ok {
+		return false
+	}
+	for _, stub := range injector.StubMap {
+		if stub == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SetImplementation registers the real implementation of the interface. iface is a nil pointer to the interface.
+// build returns a new implementation each time, so that the cases do not share one.
+// code is how the test case builds the implementation,
+// e.g. injector.SetImplementation((*Store)(nil), func() interface{} { return NewStore() }, "NewStore()").
+// If code is empty, the fields of the implementation are generated like the stubs.
+func (s *SpecialValueInjector) SetImplementation(iface interface{}, build func() interface{}, code string) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || build == nil {
+		return
+	}
+	impl := reflect.TypeOf(build())
+	if impl == nil {
+		return
+	}
+	s.ImplMap[t.Elem().String()] = build
+	s.ImplCode[impl] = code
+}
+
+// GetImplementation builds a new implementation of the interface t
+func (s *SpecialValueInjector) GetImplementation(t reflect.Type) (reflect.Value, bool) {
+	build, exist := s.ImplMap[t.String()]
+	if !exist {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(build()), true
+}
+
+// GetInterfaceImplementation returns the real implementation registered for the interface t
+func GetInterfaceImplementation(ctx context.Context, t reflect.Type) (reflect.Value, bool) {
+	injector, ok := ctx.Value("SpecialValueInjector").(*SpecialValueInjector)
+	if !ok || t == nil {
+		return reflect.Value{}, false
+	}
+	return injector.GetImplementation(t)
+}
+
+// GetImplementationCode reports whether t is one of the registered implementation types
+// and returns how the test case builds it
+func GetImplementationCode(ctx context.Context, t reflect.Type) (string, bool) {
+	injector, ok := ctx.Value("SpecialValueInjector").(*SpecialValueInjector)
+	if !ok {
+		return "", false
+	}
+	code, exist := injector.ImplCode[t]
+	return code, exist
+}
@@ -1,6 +1,8 @@
 package smartunitvariablebuild
 
 import (
+	"context"
+	"io"
 	"reflect"
 	"testing"
 )
@@ -33,3 +35,63 @@ func TestSpecialValueInjector_Bulider(t *testing.T) {
 	}
 	t.Log(code)
 }
+
+type stubCloser struct {
+	CloseResult0 error
+}
+
+func (s *stubCloser) Close() error {
+	return s.CloseResult0
+}
+
+func TestSpecialValueInjector_Stub(t *testing.T) {
+	s := NewSpecialValueInjector()
+	s.SetStub((*io.Closer)(nil), &stubCloser{})
+	ctx := context.WithValue(context.Background(), "SpecialValueInjector", s)
+	stub, ok := GetInterfaceStub(ctx, reflect.TypeOf((*io.Closer)(nil)).Elem())
+	if !ok || stub != reflect.TypeOf(&stubCloser{}) {
+		t.Fatal("stub of io.Closer is not registered")
+	}
+	if !IsInterfaceStub(ctx, stub) || IsInterfaceStub(ctx, reflect.TypeOf(1)) {
+		t.Fatal("IsInterfaceStub is not correct")
+	}
+}
+
+func TestSpecialValueInjector_Implementation(t *testing.T) {
+	s := NewSpecialValueInjector()
+	s.SetImplementation((*io.Closer)(nil), func() interface{} { return &stubCloser{} }, "&stubCloser{}")
+	ctx := context.WithValue(context.Background(), "SpecialValueInjector", s)
+	impl, ok := GetInterfaceImplementation(ctx, reflect.TypeOf((*io.Closer)(nil)).Elem())
+	if !ok || impl.Type() != reflect.TypeOf(&stubCloser{}) {
+		t.Fatal("implementation of io.Closer is not registered")
+	}
+	// each case gets its own implementation
+	if other, _ := GetInterfaceImplementation(ctx, reflect.TypeOf((*io.Closer)(nil)).Elem()); other.Pointer() == impl.Pointer() {
+		t.Fatal("the implementation is shared")
+	}
+	code, ok := GetImplementationCode(ctx, impl.Type())
+	if !ok || code != "&stubCloser{}" {
+		t.Fatal("GetImplementationCode is not correct")
+	}
+	if _, ok := GetImplementationCode(ctx, reflect.TypeOf(1)); ok {
+		t.Fatal("int is not an implementation")
+	}
+}
+
+type redisClient struct {
+	Addr string
+}
+
+func TestGetBuilderCode(t *testing.T) {
+	s := NewSpecialValueInjector()
+	client := &redisClient{Addr: "stand-in"}
+	s.SetBuilder(client, `NewClient("stand-in")`)
+	ctx := context.WithValue(context.Background(), "SpecialValueInjector", s)
+	if code, ok := GetBuilderCode(ctx, reflect.ValueOf(client)); !ok || code != `NewClient("stand-in")` {
+		t.Errorf("GetBuilderCode() = %v, %v", code, ok)
+	}
+	// the other client is not built by the code
+	if _, ok := GetBuilderCode(ctx, reflect.ValueOf(&redisClient{})); ok {
+		t.Error("GetBuilderCode() should ignore the other pointer")
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package smartunitvariablebuild
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Mock is implemented by the typed mocks which the test file declares for the interface dependencies
+type Mock interface {
+	MockRecorder() *MockRecorder
+}
+
+// MockCall is one call of the mocked method
+type MockCall struct {
+	Method string
+	Args   []interface{}
+}
+
+// MockRecorder records the calls of a typed mock. The zero value is ready to use.
+// The tested function might call the mock in other goroutines, so it is guarded by a lock.
+type MockRecorder struct {
+	lock  sync.Mutex
+	calls []MockCall
+}
+
+// Record is called by the methods of the mock, e.g. m.MockRecorder().Record("Get", arg0, arg1)
+func (r *MockRecorder) Record(method string, args ...interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = append(r.calls, MockCall{Method: method, Args: args})
+}
+
+// Calls returns the arguments of each call of the method in order
+func (r *MockRecorder) Calls(method string) [][]interface{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	res := make([][]interface{}, 0)
+	for _, call := range r.calls {
+		if call.Method == method {
+			res = append(res, call.Args)
+		}
+	}
+	return res
+}
+
+// CallCount returns how many times the method is called
+func (r *MockRecorder) CallCount(method string) int {
+	return len(r.Calls(method))
+}
+
+// AllCalls returns all the calls of the mock in order
+func (r *MockRecorder) AllCalls() []MockCall {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]MockCall{}, r.calls...)
+}
+
+// Reset forgets the recorded calls
+func (r *MockRecorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = nil
+}
+
+// FindMocks returns the typed mocks in the values, e.g. the arguments and the fields of the receiver of the test case.
+// The same mock is returned once.
+func FindMocks(values ...interface{}) []Mock {
+	mocks := make([]Mock, 0)
+	visited := map[uintptr]bool{}
+	for _, value := range values {
+		findMocks(reflect.ValueOf(value), visited, &mocks)
+	}
+	return mocks
+}
+
+func findMocks(v reflect.Value, visited map[uintptr]bool, mocks *[]Mock) {
+	if !v.IsValid() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		// the mocks are the pointers, and their fields might hold the mocks too
+		if v.CanInterface() {
+			if mock, ok := v.Interface().(Mock); ok {
+				*mocks = append(*mocks, mock)
+			}
+		}
+		findMocks(v.Elem(), visited, mocks)
+	case reflect.Interface:
+		if !v.IsNil() {
+			findMocks(v.Elem(), visited, mocks)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			findMocks(v.Field(i), visited, mocks)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			findMocks(v.Index(i), visited, mocks)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			findMocks(iter.Value(), visited, mocks)
+		}
+	}
+}
+
+// MockName is the name of the mock by which its calls are recorded, e.g. MockStoreForLoad.Get
+func MockName(mock Mock, method string) string {
+	t := reflect.TypeOf(mock)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name() + "." + method
+}
@@ -0,0 +1,55 @@
+package smartunitvariablebuild
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStore struct {
+	recorder MockRecorder
+}
+
+func (m *mockStore) Get(key string) string {
+	m.recorder.Record("Get", key)
+	return key
+}
+
+func (m *mockStore) MockRecorder() *MockRecorder {
+	return &m.recorder
+}
+
+func TestMockRecorder(t *testing.T) {
+	var mock Mock = &mockStore{}
+	store := mock.(*mockStore)
+	wg := sync.WaitGroup{}
+	for _, key := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			store.Get(key)
+		}(key)
+	}
+	wg.Wait()
+	assert.Equal(t, 2, mock.MockRecorder().CallCount("Get"))
+	assert.ElementsMatch(t, [][]interface{}{{"a"}, {"b"}}, mock.MockRecorder().Calls("Get"))
+	assert.Equal(t, 0, mock.MockRecorder().CallCount("Set"))
+	assert.Equal(t, 2, len(mock.MockRecorder().AllCalls()))
+	mock.MockRecorder().Reset()
+	assert.Equal(t, 0, len(mock.MockRecorder().AllCalls()))
+}
+
+func TestFindMocks(t *testing.T) {
+	type args struct {
+		Store  interface{}
+		Stores []*mockStore
+		Other  *int
+	}
+	store := &mockStore{}
+	mocks := FindMocks(args{Store: store, Stores: []*mockStore{store, {}}}, nil)
+	// the same mock is found once
+	assert.Equal(t, 2, len(mocks))
+	assert.True(t, mocks[0] == Mock(store))
+	assert.Equal(t, "mockStore.Get", MockName(store, "Get"))
+}
@@ -0,0 +1,565 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package smartunitvariablebuild
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/faker"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var sqlDBType = reflect.TypeOf(&sql.DB{})
+
+// sqlErrors are the driver errors which the stand-in returns
+var sqlErrors = []string{
+	"driver: bad connection",
+	"Error 1062: Duplicate entry '1' for key 'PRIMARY'",
+	"Error 1213: Deadlock found when trying to get lock; try restarting transaction",
+	"Error 1205: Lock wait timeout exceeded; try restarting transaction",
+	"dial tcp 127.0.0.1:3306: connect: connection refused",
+}
+
+// sqlDefaultColumns are the columns of select *
+var sqlDefaultColumns = []string{"id", "name", "created_at"}
+
+// SQLExpect is one statement which the stand-in database expects, and the result which it returns.
+// The rows are returned by the query and RowsAffected and LastInsertID are returned by the exec.
+// The nil Args matches any arguments of the statement.
+type SQLExpect struct {
+	Query        string
+	Args         []interface{}
+	Columns      []string
+	Rows         [][]interface{}
+	RowsAffected int64
+	LastInsertID int64
+	Err          string
+}
+
+// sqlOutcome is how the statement of the middle code ends: the number of rows or the error
+type sqlOutcome struct {
+	rows int
+	err  string
+}
+
+// sqlStandIn is the in-memory database behind the *sql.DB.
+// The middle code records the statements with the generated results, and the final suite
+// replays them and reports the statements which differ from the records.
+type sqlStandIn struct {
+	lock       sync.Mutex
+	replay     bool
+	plan       []sqlOutcome
+	expects    []SQLExpect
+	calls      int
+	mismatches []string
+}
+
+var (
+	sqlStandInLock sync.Mutex
+	sqlStandIns    = map[*sql.DB]*sqlStandIn{}
+)
+
+func openSQLStandIn(s *sqlStandIn) *sql.DB {
+	db := sql.OpenDB(sqlConnector{s: s})
+	sqlStandInLock.Lock()
+	defer sqlStandInLock.Unlock()
+	sqlStandIns[db] = s
+	return db
+}
+
+func getSQLStandIn(db *sql.DB) (*sqlStandIn, bool) {
+	sqlStandInLock.Lock()
+	defer sqlStandInLock.Unlock()
+	s, ok := sqlStandIns[db]
+	return s, ok
+}
+
+// StandInSQL returns the *sql.DB which expects the statements in order and returns their results.
+// After the expected statements, it returns the result of the last one.
+func StandInSQL(expects ...SQLExpect) *sql.DB {
+	return openSQLStandIn(&sqlStandIn{replay: true, expects: expects})
+}
+
+// CheckSQLStandIns returns the differences between the expected statements and the executed ones
+// of the stand-in databases in v, e.g. the test case.
+func CheckSQLStandIns(v interface{}) []string {
+	res := make([]string, 0)
+	collectSQLMismatches(reflect.ValueOf(v), 0, &res)
+	return res
+}
+
+func collectSQLMismatches(v reflect.Value, level int, res *[]string) {
+	if !v.IsValid() || level > atgconstant.VariableMaxLevel {
+		return
+	}
+	if v.Type() == sqlDBType && v.CanInterface() {
+		db := v.Interface().(*sql.DB)
+		if s, ok := getSQLStandIn(db); ok {
+			*res = append(*res, s.unmet()...)
+		}
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			collectSQLMismatches(v.Elem(), level+1, res)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			collectSQLMismatches(v.Field(i), level+1, res)
+		}
+	}
+}
+
+// next returns the result of the statement
+func (s *sqlStandIn) next(query string, args []driver.NamedValue, exec bool) SQLExpect {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	query = normalizeSQL(query)
+	index := s.calls
+	s.calls++
+	if s.replay {
+		if len(s.expects) == 0 {
+			s.mismatches = append(s.mismatches, fmt.Sprintf("unexpected query %q", query))
+			return SQLExpect{Query: query, Err: "stand-in: unexpected query"}
+		}
+		if index >= len(s.expects) {
+			return s.expects[len(s.expects)-1]
+		}
+		if s.expects[index].Query != query {
+			s.mismatches = append(s.mismatches, fmt.Sprintf("query %d is %q, want %q", index, query, s.expects[index].Query))
+		} else if want := s.expects[index].Args; want != nil && !equalSQLArgs(sqlArgs(args), want) {
+			s.mismatches = append(s.mismatches, fmt.Sprintf("query %d has the arguments %v, want %v", index, sqlArgs(args), want))
+		}
+		return s.expects[index]
+	}
+	// the final suite repeats the last record, so does the middle code
+	if index >= atgconstant.MaxRecordedMockCalls {
+		return s.expects[len(s.expects)-1]
+	}
+	outcome := s.plan[len(s.plan)-1]
+	if index < len(s.plan) {
+		outcome = s.plan[index]
+	}
+	expect := SQLExpect{Query: query, Args: sqlArgs(args), Err: outcome.err}
+	if outcome.err == "" {
+		if exec {
+			expect.RowsAffected = int64(outcome.rows)
+			expect.LastInsertID = rand.Int63n(100000) + 1
+		} else {
+			expect.Columns = parseSQLColumns(query)
+			for i := 0; i < outcome.rows; i++ {
+				row := make([]interface{}, 0, len(expect.Columns))
+				for _, column := range expect.Columns {
+					row = append(row, sqlColumnValue(column))
+				}
+				expect.Rows = append(expect.Rows, row)
+			}
+		}
+	}
+	s.expects = append(s.expects, expect)
+	return expect
+}
+
+// unmet returns the mismatched statements and the expected statements which are not executed
+func (s *sqlStandIn) unmet() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	res := append([]string{}, s.mismatches...)
+	if !s.replay {
+		return res
+	}
+	for i := s.calls; i < len(s.expects); i++ {
+		res = append(res, fmt.Sprintf("query %q is not executed", s.expects[i].Query))
+	}
+	return res
+}
+
+// sqlArgs gets the values of the arguments, the empty arguments are not nil so that they are checked
+func sqlArgs(args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+	return values
+}
+
+// equalSQLArgs compares the driver values, whose times may be in the different locations
+func equalSQLArgs(got, want []interface{}) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		switch g := got[i].(type) {
+		case time.Time:
+			if w, ok := want[i].(time.Time); !ok || !g.Equal(w) {
+				return false
+			}
+		case []byte:
+			if w, ok := want[i].([]byte); !ok || !bytes.Equal(g, w) {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(got[i], want[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func normalizeSQL(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// parseSQLColumns gets the column names of the select, so that the rows match the scan
+func parseSQLColumns(query string) []string {
+	q := strings.ToLower(query)
+	start := strings.Index(q, "select ")
+	if start < 0 {
+		return sqlDefaultColumns
+	}
+	q = strings.TrimPrefix(q[start+len("select "):], "distinct ")
+	if end := strings.Index(q, " from "); end >= 0 {
+		q = q[:end]
+	}
+	columns := make([]string, 0)
+	depth, begin := 0, 0
+	for i := 0; i <= len(q); i++ {
+		if i < len(q) {
+			switch q[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if q[i] != ',' || depth != 0 {
+				continue
+			}
+		}
+		column := strings.TrimSpace(q[begin:i])
+		begin = i + 1
+		if column == "*" || strings.HasSuffix(column, ".*") {
+			columns = append(columns, sqlDefaultColumns...)
+			continue
+		}
+		if fields := strings.Fields(column); len(fields) > 1 && !strings.HasSuffix(column, ")") {
+			column = fields[len(fields)-1]
+		}
+		if dot := strings.LastIndex(column, "."); dot >= 0 && !strings.Contains(column, "(") {
+			column = column[dot+1:]
+		}
+		columns = append(columns, strings.Trim(column, "`\""))
+	}
+	return columns
+}
+
+// sqlColumnValue generates the value by the words of the column name, e.g. int64 for user_id and time.Time
+// for created_at. The aggregate, e.g. count(*), goes by its function.
+func sqlColumnValue(column string) interface{} {
+	words := sqlColumnWords(column)
+	if len(words) == 0 {
+		return faker.Word()
+	}
+	first, last := words[0], words[len(words)-1]
+	switch {
+	case first == "is" || first == "has":
+		return int64(rand.Intn(2))
+	case last == "at" || hasSQLWord(words, "time", "date", "datetime", "timestamp"):
+		return faker.ReferenceTime().Add(-time.Duration(rand.Intn(30*24*60*60)) * time.Second).Truncate(time.Second).UTC()
+	case first == "avg" || hasSQLWord(words, "price", "amount", "rate", "score"):
+		return float64(rand.Intn(100000)) / 100
+	case first == "count" || first == "sum" ||
+		hasSQLWord(words, "id", "uid", "count", "num", "age", "status", "type", "version", "level"):
+		return int64(rand.Intn(1000) + 1)
+	}
+	return faker.Word()
+}
+
+// sqlColumnWords splits the column name into the lower case words, e.g. userId and user_id are both user and id
+func sqlColumnWords(column string) []string {
+	words := make([]string, 0)
+	word := make([]rune, 0)
+	flush := func() {
+		if len(word) != 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for _, r := range column {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(word) != 0 && !unicode.IsUpper(word[len(word)-1]):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+func hasSQLWord(words []string, candidates ...string) bool {
+	for _, word := range words {
+		for _, candidate := range candidates {
+			if word == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewSQLVariable generates the stand-in *sql.DB, whose statements return the rows or the driver errors.
+// The second result is false if t is not *sql.DB or the stand-in is off.
+func NewSQLVariable(ctx context.Context, t reflect.Type) (reflect.Value, bool) {
+	if t != sqlDBType {
+		return reflect.Value{}, false
+	}
+	if _, ok := contexthelper.GetStandIn(ctx, contexthelper.SQLStandIn); !ok {
+		return reflect.Value{}, false
+	}
+	vtx, _ := contexthelper.GetVariableContext(ctx)
+	if vtx.CanBeNil && atghelper.RandomBool(atgconstant.SpecialValueBeNil) {
+		return reflect.Zero(t), true
+	}
+	plan := make([]sqlOutcome, rand.Intn(atgconstant.MaxMockOutputSeq)+1)
+	for i := range plan {
+		switch rand.Intn(4) {
+		case 0:
+			plan[i].err = sqlErrors[rand.Intn(len(sqlErrors))]
+		case 1:
+			// no rows
+		default:
+			plan[i].rows = rand.Intn(3) + 1
+		}
+	}
+	duplicatepackagemanager.GetInstance(ctx).PutAndGet("smartunitvariablebuild", "github.com/bytedance/nxt_unit/smartunitvariablebuild")
+	return reflect.ValueOf(openSQLStandIn(&sqlStandIn{plan: plan})), true
+}
+
+// RenderSQLVariable renders the stand-in *sql.DB with the statements which it executed, e.g.
+// smartunitvariablebuild.StandInSQL(smartunitvariablebuild.SQLExpect{Query: "SELECT id FROM user", Columns: []string{"id"}, Rows: [][]interface{}{{int64(7)}}})
+func RenderSQLVariable(ctx context.Context, v reflect.Value) (string, bool) {
+	if !v.IsValid() || v.Type() != sqlDBType || !v.CanInterface() {
+		return "", false
+	}
+	s, ok := getSQLStandIn(v.Interface().(*sql.DB))
+	if !ok {
+		return "nil", true
+	}
+	pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("smartunitvariablebuild", "github.com/bytedance/nxt_unit/smartunitvariablebuild")
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%s.StandInSQL(", pkgName))
+	for _, expect := range s.expects {
+		builder.WriteString(fmt.Sprintf("\n%s.SQLExpect{Query: %q", pkgName, expect.Query))
+		if expect.Args != nil {
+			args := make([]string, 0, len(expect.Args))
+			for _, arg := range expect.Args {
+				args = append(args, renderSQLValue(ctx, arg))
+			}
+			builder.WriteString(fmt.Sprintf(", Args: []interface{}{%s}", strings.Join(args, ", ")))
+		}
+		if expect.Err != "" {
+			builder.WriteString(fmt.Sprintf(", Err: %q", expect.Err))
+		}
+		if len(expect.Columns) != 0 {
+			builder.WriteString(fmt.Sprintf(", Columns: %#v", expect.Columns))
+		}
+		if len(expect.Rows) != 0 {
+			rows := make([]string, 0, len(expect.Rows))
+			for _, row := range expect.Rows {
+				values := make([]string, 0, len(row))
+				for _, value := range row {
+					values = append(values, renderSQLValue(ctx, value))
+				}
+				rows = append(rows, "{"+strings.Join(values, ", ")+"}")
+			}
+			builder.WriteString(fmt.Sprintf(", Rows: [][]interface{}{%s}", strings.Join(rows, ", ")))
+		}
+		if expect.RowsAffected != 0 {
+			builder.WriteString(fmt.Sprintf(", RowsAffected: %d", expect.RowsAffected))
+		}
+		if expect.LastInsertID != 0 {
+			builder.WriteString(fmt.Sprintf(", LastInsertID: %d", expect.LastInsertID))
+		}
+		builder.WriteString("},")
+	}
+	builder.WriteString(")")
+	return builder.String(), true
+}
+
+func renderSQLValue(ctx context.Context, value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return fmt.Sprintf("int64(%d)", v)
+	case float64:
+		return fmt.Sprintf("float64(%v)", v)
+	case time.Time:
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("", "time")
+		return renderTime(pkgName, v)
+	case nil:
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// sqlConnector connects the *sql.DB to the stand-in
+type sqlConnector struct {
+	s *sqlStandIn
+}
+
+func (c sqlConnector) Connect(context.Context) (driver.Conn, error) {
+	return &sqlConn{s: c.s}, nil
+}
+
+func (c sqlConnector) Driver() driver.Driver {
+	return sqlDriver{}
+}
+
+type sqlDriver struct{}
+
+func (sqlDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("the stand-in database is opened by StandInSQL")
+}
+
+type sqlConn struct {
+	s *sqlStandIn
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return &sqlStmt{conn: c, query: query}, nil
+}
+
+func (c *sqlConn) Close() error {
+	return nil
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return sqlTx{}, nil
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	expect := c.s.next(query, args, false)
+	if expect.Err != "" {
+		return nil, errors.New(expect.Err)
+	}
+	return &sqlRows{columns: expect.Columns, rows: expect.Rows}, nil
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	expect := c.s.next(query, args, true)
+	if expect.Err != "" {
+		return nil, errors.New(expect.Err)
+	}
+	return sqlResult{lastInsertID: expect.LastInsertID, rowsAffected: expect.RowsAffected}, nil
+}
+
+type sqlStmt struct {
+	conn  *sqlConn
+	query string
+}
+
+func (s *sqlStmt) Close() error {
+	return nil
+}
+
+func (s *sqlStmt) NumInput() int {
+	return -1
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, namedSQLArgs(args))
+}
+
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, namedSQLArgs(args))
+}
+
+func namedSQLArgs(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		named = append(named, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+	return named
+}
+
+type sqlTx struct{}
+
+func (sqlTx) Commit() error {
+	return nil
+}
+
+func (sqlTx) Rollback() error {
+	return nil
+}
+
+type sqlRows struct {
+	columns []string
+	rows    [][]interface{}
+	index   int
+}
+
+func (r *sqlRows) Columns() []string {
+	return r.columns
+}
+
+func (r *sqlRows) Close() error {
+	return nil
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.rows) {
+		return io.EOF
+	}
+	for i := range dest {
+		if i < len(r.rows[r.index]) {
+			dest[i] = r.rows[r.index][i]
+		}
+	}
+	r.index++
+	return nil
+}
+
+type sqlResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r sqlResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r sqlResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
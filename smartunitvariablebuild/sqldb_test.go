@@ -0,0 +1,127 @@
+package smartunitvariablebuild
+
+import (
+	"context"
+	"database/sql"
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSQLVariable(t *testing.T) {
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	if _, ok := NewSQLVariable(ctx, sqlDBType); ok {
+		t.Fatal("NewSQLVariable should be off without the stand-in functions")
+	}
+	ctx = contexthelper.SetStandIn(ctx, contexthelper.SQLStandIn, []string{"Query"})
+	value, ok := GetSpecialVariableV3(ctx, sqlDBType)
+	if !ok || value.Type() != sqlDBType {
+		t.Fatal("GetSpecialVariableV3 should generate *sql.DB")
+	}
+	db := value.Interface().(*sql.DB)
+	query := "SELECT id, name, created_at FROM user WHERE id = ?"
+	rows, err := db.Query(query, 1)
+	if err == nil {
+		for rows.Next() {
+			var id int
+			var name string
+			var createdAt time.Time
+			if err := rows.Scan(&id, &name, &createdAt); err != nil {
+				t.Fatalf("the generated rows should match the columns, %v", err)
+			}
+		}
+		rows.Close()
+	}
+	code, ok := RenderVariableV3(ctx, value)
+	if !ok || !strings.HasPrefix(code, "smartunitvariablebuild.StandInSQL(\nsmartunitvariablebuild.SQLExpect{Query: \""+query+"\", Args: []interface{}{int64(1)}") {
+		t.Errorf("RenderVariableV3() = %v", code)
+	}
+}
+
+func TestStandInSQL(t *testing.T) {
+	db := StandInSQL(
+		SQLExpect{Query: "SELECT id, name FROM user WHERE id = ?", Columns: []string{"id", "name"}, Rows: [][]interface{}{{int64(7), "tom"}}},
+		SQLExpect{Query: "UPDATE user SET name = ? WHERE id = ?", Args: []interface{}{"jerry", int64(7)}, RowsAffected: 1},
+		SQLExpect{Query: "DELETE FROM user WHERE id = ?", Err: "Error 1205: Lock wait timeout exceeded"},
+	)
+	var id int
+	var name string
+	if err := db.QueryRow("SELECT id, name\n FROM user WHERE id = ?", 7).Scan(&id, &name); err != nil || id != 7 || name != "tom" {
+		t.Fatalf("QueryRow() = %v, %v, %v", id, name, err)
+	}
+	res, err := db.Exec("UPDATE user SET name = ? WHERE id = ?", "jerry", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		t.Errorf("RowsAffected() = %v, want 1", affected)
+	}
+	test := struct {
+		Args struct{ DB *sql.DB }
+	}{}
+	test.Args.DB = db
+	if diff := CheckSQLStandIns(test); !reflect.DeepEqual(diff, []string{`query "DELETE FROM user WHERE id = ?" is not executed`}) {
+		t.Errorf("CheckSQLStandIns() = %v", diff)
+	}
+	if _, err := db.Exec("DELETE FROM user WHERE name = ?", "tom"); err == nil || err.Error() != "Error 1205: Lock wait timeout exceeded" {
+		t.Errorf("Exec() = %v", err)
+	}
+	if diff := CheckSQLStandIns(test); len(diff) != 1 || !strings.HasPrefix(diff[0], "query 2 is") {
+		t.Errorf("CheckSQLStandIns() = %v", diff)
+	}
+
+	db = StandInSQL(SQLExpect{Query: "UPDATE user SET name = ? WHERE id = ?", Args: []interface{}{"jerry", int64(7)}})
+	test.Args.DB = db
+	if _, err := db.Exec("UPDATE user SET name = ? WHERE id = ?", "jerry", 8); err != nil {
+		t.Fatal(err)
+	}
+	if diff := CheckSQLStandIns(test); !reflect.DeepEqual(diff, []string{"query 0 has the arguments [jerry 8], want [jerry 7]"}) {
+		t.Errorf("CheckSQLStandIns() = %v", diff)
+	}
+}
+
+func TestSQLColumnValue(t *testing.T) {
+	tests := []struct {
+		column string
+		want   reflect.Type
+	}{
+		{"user_id", reflect.TypeOf(int64(0))},
+		{"userId", reflect.TypeOf(int64(0))},
+		{"count(*)", reflect.TypeOf(int64(0))},
+		{"created_at", reflect.TypeOf(time.Time{})},
+		{"avg(price)", reflect.TypeOf(float64(0))},
+		{"message", reflect.TypeOf("")},
+		{"page", reflect.TypeOf("")},
+		{"image", reflect.TypeOf("")},
+		{"valid", reflect.TypeOf("")},
+		{"paid", reflect.TypeOf("")},
+	}
+	for _, tt := range tests {
+		if got := reflect.TypeOf(sqlColumnValue(tt.column)); got != tt.want {
+			t.Errorf("sqlColumnValue(%q) is %v, want %v", tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestParseSQLColumns(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"SELECT id, name FROM user", []string{"id", "name"}},
+		{"select u.id, u.user_name AS name, count(*) from user u", []string{"id", "name", "count(*)"}},
+		{"SELECT DISTINCT `status` FROM orders", []string{"status"}},
+		{"SELECT * FROM user", sqlDefaultColumns},
+		{"SELECT IFNULL(SUM(amount), 0) total, created_at FROM orders", []string{"total", "created_at"}},
+	}
+	for _, tt := range tests {
+		if got := parseSQLColumns(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSQLColumns(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
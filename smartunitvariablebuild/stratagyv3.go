@@ -55,6 +55,12 @@ func GetSpecialVariableV3(ctx context.Context, t reflect.Type) (reflect.Value, b
 		}
 
 	default:
+		if value, ok := NewTimeVariable(ctx, t); ok {
+			return value, true
+		}
+		if value, ok := NewSQLVariable(ctx, t); ok {
+			return value, true
+		}
 		spv := ctx.Value("SpecialValueInjector")
 		injector, ok := spv.(*SpecialValueInjector)
 		if !ok {
@@ -75,6 +81,15 @@ func RenderVariableV3(ctx context.Context, v reflect.Value) (string, bool) {
 	if t == nil {
 		return "", false
 	}
+	if code, ok := RenderTimeVariable(ctx, v); ok {
+		return code, true
+	}
+	if code, ok := RenderSQLVariable(ctx, v); ok {
+		return code, true
+	}
+	if code, ok := GetBuilderCode(ctx, v); ok {
+		return code, true
+	}
 	pkgPath := ""
 	switch t.Kind() {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice:
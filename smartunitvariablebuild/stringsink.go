@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package smartunitvariablebuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper"
+	"github.com/bytedance/nxt_unit/faker"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// StringSink tells how to generate a string parameter which is parsed by the tested function.
+type StringSink struct {
+	Kind   string       // one of atgconstant.StringSinkXXX
+	Layout string       // the time layout or the regexp pattern
+	Target reflect.Type // the type that json.Unmarshal decodes into
+}
+
+// malformedStrings are the strings that the parser of each kind cannot accept
+var malformedStrings = map[string]string{
+	atgconstant.StringSinkJSON:  `{"smart unit":`,
+	atgconstant.StringSinkInt:   "12smartunit",
+	atgconstant.StringSinkFloat: "1.2.3",
+	atgconstant.StringSinkBool:  "smartunit",
+	atgconstant.StringSinkURL:   "http://smart unit/%zz",
+	atgconstant.StringSinkTime:  "2006-13-45 25:61:61",
+}
+
+// malformedRegexpCandidates are tried in order until one of them does not match the pattern
+var malformedRegexpCandidates = []string{"", "smartunit", "0", " ", "smart unit\n#%"}
+
+// Generate returns a well-formed string for the parser. A fraction of the strings are malformed
+// on purpose to cover the error branches.
+func (s StringSink) Generate() string {
+	if atghelper.RandomBool(atgconstant.MalformedStringRatio) {
+		if malformed, ok := s.malformed(); ok {
+			return malformed
+		}
+	}
+	switch s.Kind {
+	case atgconstant.StringSinkJSON:
+		return s.generateJSON()
+	case atgconstant.StringSinkInt:
+		return strconv.Itoa(rand.Intn(atgconstant.MaxInt))
+	case atgconstant.StringSinkFloat:
+		return strconv.FormatFloat(rand.Float64()*float64(atgconstant.MaxInt), 'f', 2, 64)
+	case atgconstant.StringSinkBool:
+		return strconv.FormatBool(atghelper.RandomBool(0.5))
+	case atgconstant.StringSinkURL:
+		return faker.URL()
+	case atgconstant.StringSinkTime:
+		layout := s.Layout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return faker.TimeValue().Format(layout)
+	case atgconstant.StringSinkRegexp:
+		return s.generateRegexp()
+	}
+	return atghelper.RandStringBytes(atgconstant.RandomStringLen)
+}
+
+// malformed returns the string which the parser cannot accept, false if every string is accepted, e.g. by .*
+func (s StringSink) malformed() (string, bool) {
+	if s.Kind != atgconstant.StringSinkRegexp {
+		malformed, ok := malformedStrings[s.Kind]
+		return malformed, ok
+	}
+	re, err := regexp.Compile(s.Layout)
+	if err != nil {
+		return "", false
+	}
+	for _, candidate := range malformedRegexpCandidates {
+		if !re.MatchString(candidate) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+func (s StringSink) generateJSON() string {
+	if s.Target != nil {
+		value, err := faker.GetValue(s.Target, 0)
+		if err == nil {
+			data, err := json.Marshal(value.Interface())
+			if err == nil {
+				return string(data)
+			}
+		}
+	}
+	return fmt.Sprintf(`{"%s":"%s"}`, faker.Word(), faker.Word())
+}
+
+func (s StringSink) generateRegexp() string {
+	if fake, err := faker.FromRegex(s.Layout); err == nil {
+		return fake
+	}
+	re, err := regexp.Compile(s.Layout)
+	if err != nil {
+		return atghelper.RandStringBytes(atgconstant.RandomStringLen)
+	}
+	prefix, complete := re.LiteralPrefix()
+	if complete {
+		return prefix
+	}
+	return prefix + atghelper.RandStringBytes(atgconstant.RandomStringLen)
+}
+
+func NewStringSinkInjector() *StringSinkInjector {
+	return &StringSinkInjector{
+		SinkMap: map[string]StringSink{},
+	}
+}
+
+// StringSinkInjector records the string fields of the test case which are parsed by the tested function.
+// The key is the struct name and the field name, e.g. Args.Data
+type StringSinkInjector struct {
+	SinkMap map[string]StringSink
+}
+
+func (s *StringSinkInjector) Set(key string, sink StringSink) {
+	s.SinkMap[key] = sink
+}
+
+func (s *StringSinkInjector) Get(key string) (StringSink, bool) {
+	sink, exist := s.SinkMap[key]
+	return sink, exist
+}
+
+// GetStringSinkValue generates the value of the index-th field of the struct t if the field is parsed
+// by the tested function.
+func GetStringSinkValue(ctx context.Context, t reflect.Type, index int) (string, bool) {
+	injector, ok := ctx.Value("StringSinkInjector").(*StringSinkInjector)
+	if !ok || t.Kind() != reflect.Struct || index >= t.NumField() {
+		return "", false
+	}
+	if t.Field(index).Type.Kind() != reflect.String {
+		return "", false
+	}
+	sink, exist := injector.Get(fmt.Sprintf("%s.%s", t.Name(), t.Field(index).Name))
+	if !exist {
+		return "", false
+	}
+	return sink.Generate(), true
+}
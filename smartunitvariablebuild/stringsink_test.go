@@ -0,0 +1,108 @@
+package smartunitvariablebuild
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+)
+
+type sinkUser struct {
+	Name string
+	Age  int
+}
+
+func TestStringSinkGenerate(t *testing.T) {
+	var wellFormed, malformed int
+	for i := 0; i < 200; i++ {
+		sinks := []StringSink{
+			{Kind: atgconstant.StringSinkJSON, Target: reflect.TypeOf(sinkUser{})},
+			{Kind: atgconstant.StringSinkInt},
+			{Kind: atgconstant.StringSinkTime, Layout: "2006-01-02"},
+		}
+		var u sinkUser
+		errs := []error{
+			json.Unmarshal([]byte(sinks[0].Generate()), &u),
+			func() error { _, err := strconv.Atoi(sinks[1].Generate()); return err }(),
+			func() error { _, err := time.Parse("2006-01-02", sinks[2].Generate()); return err }(),
+		}
+		for _, err := range errs {
+			if err == nil {
+				wellFormed++
+			} else {
+				malformed++
+			}
+		}
+	}
+	if wellFormed == 0 || malformed == 0 {
+		t.Fatalf("Generate should return both well-formed and malformed strings, got %v and %v", wellFormed, malformed)
+	}
+	if malformed > wellFormed {
+		t.Fatalf("Generate should mostly return well-formed strings, got %v malformed", malformed)
+	}
+}
+
+func TestStringSinkGenerateURL(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		value := StringSink{Kind: atgconstant.StringSinkURL}.Generate()
+		if _, err := url.Parse(value); err != nil && value != malformedStrings[atgconstant.StringSinkURL] {
+			t.Fatalf("Generate should return valid url, got %v", value)
+		}
+	}
+}
+
+func TestStringSinkGenerateRegexp(t *testing.T) {
+	for _, pattern := range []string{"^smartunit$", `^ORD-[0-9]{8}$`, `\d+`, `^[a-z]+@[a-z]+\.com$`} {
+		re := regexp.MustCompile(pattern)
+		sink := StringSink{Kind: atgconstant.StringSinkRegexp, Layout: pattern}
+		malformed, ok := sink.malformed()
+		if !ok || re.MatchString(malformed) {
+			t.Fatalf("malformed(%q) = %q, %v, want the string which does not match", pattern, malformed, ok)
+		}
+		var matched, unmatched int
+		for i := 0; i < 200; i++ {
+			value := sink.Generate()
+			switch {
+			case re.MatchString(value):
+				matched++
+			case value == malformed:
+				unmatched++
+			default:
+				t.Fatalf("Generate(%q) = %q, want the matching string or %q", pattern, value, malformed)
+			}
+		}
+		if matched == 0 || unmatched == 0 || unmatched > matched {
+			t.Fatalf("Generate(%q) should mostly match, got %v matched and %v malformed", pattern, matched, unmatched)
+		}
+	}
+	// every string matches .*, so there is no malformed one
+	if _, ok := (StringSink{Kind: atgconstant.StringSinkRegexp, Layout: ".*"}).malformed(); ok {
+		t.Fatal("malformed(.*) should find no string")
+	}
+}
+
+func TestGetStringSinkValue(t *testing.T) {
+	type Args struct {
+		Data  string
+		Count int
+	}
+	injector := NewStringSinkInjector()
+	injector.Set("Args.Data", StringSink{Kind: atgconstant.StringSinkBool})
+	injector.Set("Args.Count", StringSink{Kind: atgconstant.StringSinkInt})
+	ctx := context.WithValue(context.Background(), "StringSinkInjector", injector)
+	if _, ok := GetStringSinkValue(ctx, reflect.TypeOf(Args{}), 0); !ok {
+		t.Fatal("GetStringSinkValue should generate the string field")
+	}
+	if _, ok := GetStringSinkValue(ctx, reflect.TypeOf(Args{}), 1); ok {
+		t.Fatal("GetStringSinkValue should ignore the int field")
+	}
+	if _, ok := GetStringSinkValue(context.Background(), reflect.TypeOf(Args{}), 0); ok {
+		t.Fatal("GetStringSinkValue should ignore the context without injector")
+	}
+}
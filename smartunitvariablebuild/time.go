@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package smartunitvariablebuild
+
+import (
+	"context"
+	"fmt"
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/faker"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	timePtrType  = reflect.TypeOf(&time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	locationType = reflect.TypeOf(time.UTC)
+)
+
+// durationUnits is ordered from the biggest unit to the smallest one, so that
+// the rendered duration is as readable as possible.
+var durationUnits = []struct {
+	unit time.Duration
+	name string
+}{
+	{time.Hour, "Hour"},
+	{time.Minute, "Minute"},
+	{time.Second, "Second"},
+	{time.Millisecond, "Millisecond"},
+	{time.Microsecond, "Microsecond"},
+}
+
+// NewTimeVariable generates time.Time, *time.Time, time.Duration and *time.Location
+// from the faker's DateTimer. The second result is false if t is not one of them.
+func NewTimeVariable(ctx context.Context, t reflect.Type) (reflect.Value, bool) {
+	vtx, _ := contexthelper.GetVariableContext(ctx)
+	dateTimer := faker.GetDateTimer()
+	var value interface{}
+	var err error
+	switch t {
+	case timeType:
+		value, err = dateTimer.TimeValue(reflect.Value{})
+	case timePtrType:
+		if vtx.CanBeNil && atghelper.RandomBool(atgconstant.SpecialValueBeNil) {
+			return reflect.ValueOf(nil), true
+		}
+		value, err = dateTimer.TimeValue(reflect.Value{})
+		if err == nil {
+			tm := value.(time.Time)
+			value = &tm
+		}
+	case durationType:
+		value, err = dateTimer.Duration(reflect.Value{})
+	case locationType:
+		if vtx.CanBeNil && atghelper.RandomBool(atgconstant.SpecialValueBeNil) {
+			return reflect.ValueOf(nil), true
+		}
+		value, err = dateTimer.Location(reflect.Value{})
+	default:
+		return reflect.Value{}, false
+	}
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	duplicatepackagemanager.GetInstance(ctx).PutAndGet("", "time")
+	return reflect.ValueOf(value), true
+}
+
+// RenderTimeVariable renders the time values as the code people write by hand.
+// For example: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), 3 * time.Second and time.FixedZone("UTC+08", 28800)
+func RenderTimeVariable(ctx context.Context, v reflect.Value) (string, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return "", false
+	}
+	switch v.Type() {
+	case timeType, timePtrType, durationType, locationType:
+	default:
+		return "", false
+	}
+	pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("", "time")
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return renderTime(pkgName, value), true
+	case *time.Time:
+		if value == nil {
+			return "nil", true
+		}
+		return fmt.Sprintf("func() *%s.Time {tmp := %s;return &tmp}()", pkgName, renderTime(pkgName, *value)), true
+	case time.Duration:
+		return renderDuration(pkgName, value), true
+	case *time.Location:
+		if value == nil {
+			return "nil", true
+		}
+		_, offset := faker.ReferenceTime().In(value).Zone()
+		return renderLocation(pkgName, value, offset), true
+	}
+	return "", false
+}
+
+func renderTime(pkgName string, tm time.Time) string {
+	if tm.Location() == time.Local {
+		tm = tm.UTC()
+	}
+	_, offset := tm.Zone()
+	return fmt.Sprintf("%s.Date(%d, %d, %d, %d, %d, %d, %d, %s)", pkgName, tm.Year(), tm.Month(), tm.Day(),
+		tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), renderLocation(pkgName, tm.Location(), offset))
+}
+
+func renderDuration(pkgName string, d time.Duration) string {
+	if d == 0 {
+		return fmt.Sprintf("%s.Duration(0)", pkgName)
+	}
+	for _, u := range durationUnits {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s.%s", d/u.unit, pkgName, u.name)
+		}
+	}
+	return fmt.Sprintf("%s.Duration(%d)", pkgName, int64(d))
+}
+
+// renderLocation renders the named zone by its name, so that it keeps the daylight saving time. The zone database
+// may be absent where the test runs, thus the named zone falls back to the fixed zone of the offset. Local depends on
+// the machine, and it is rendered as UTC.
+func renderLocation(pkgName string, loc *time.Location, offset int) string {
+	switch {
+	case loc == time.UTC || loc == time.Local || loc.String() == "UTC":
+		return fmt.Sprintf("%s.UTC", pkgName)
+	}
+	if _, err := time.LoadLocation(loc.String()); err == nil {
+		return fmt.Sprintf("func() *%s.Location {loc, err := %s.LoadLocation(%q);if err != nil {return %s.FixedZone(%q, %d)};return loc}()",
+			pkgName, pkgName, loc.String(), pkgName, loc.String(), offset)
+	}
+	return fmt.Sprintf("%s.FixedZone(%q, %d)", pkgName, loc.String(), offset)
+}
@@ -0,0 +1,68 @@
+package smartunitvariablebuild
+
+import (
+	"context"
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTimeVariable(t *testing.T) {
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	for _, typ := range []reflect.Type{timeType, timePtrType, durationType, locationType} {
+		value, ok := NewTimeVariable(ctx, typ)
+		if !ok || value.Type() != typ {
+			t.Fatalf("NewTimeVariable should generate %v", typ)
+		}
+	}
+	if _, ok := NewTimeVariable(ctx, reflect.TypeOf(int64(0))); ok {
+		t.Fatal("NewTimeVariable should ignore int64")
+	}
+	value, ok := GetSpecialVariableV3(ctx, durationType)
+	if !ok || value.Type() != durationType {
+		t.Fatal("GetSpecialVariableV3 should generate time.Duration")
+	}
+}
+
+func TestRenderTimeVariable(t *testing.T) {
+	ctx := contexthelper.SetVariableContext(context.Background(), atgconstant.VariableContext{})
+	tm := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"time", tm, "time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)"},
+		{"time ptr", &tm, "func() *time.Time {tmp := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC);return &tmp}()"},
+		{"fixed zone", tm.In(time.FixedZone("UTC+08", 8*60*60)), "time.Date(2023, 5, 1, 18, 0, 0, 0, time.FixedZone(\"UTC+08\", 28800))"},
+		{"second", 3 * time.Second, "3 * time.Second"},
+		{"minute", 90 * time.Minute, "90 * time.Minute"},
+		{"nanosecond", time.Duration(1500), "time.Duration(1500)"},
+		{"zero duration", time.Duration(0), "time.Duration(0)"},
+		{"utc", time.UTC, "time.UTC"},
+		{"location", time.FixedZone("UTC-03", -3*60*60), "time.FixedZone(\"UTC-03\", -10800)"},
+		{"local", time.Local, "time.UTC"},
+		{"local time", tm.In(time.Local), "time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RenderVariableV3(ctx, reflect.ValueOf(tt.value))
+			if !ok || got != tt.want {
+				t.Errorf("RenderVariableV3() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		// the named zone keeps the daylight saving time
+		got, _ := RenderVariableV3(ctx, reflect.ValueOf(loc))
+		if !strings.HasPrefix(got, "func() *time.Location {loc, err := time.LoadLocation(\"America/New_York\");") {
+			t.Errorf("RenderVariableV3() = %v", got)
+		}
+	}
+	if _, ok := RenderTimeVariable(ctx, reflect.ValueOf(int64(3))); ok {
+		t.Error("RenderTimeVariable should ignore int64")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/bytedance/nxt_unit/atgconstant"
 	"github.com/bytedance/nxt_unit/atghelper"
 	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup"
 	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/testcase"
 	"golang.org/x/tools/imports"
 )
@@ -118,9 +119,6 @@ func (t *testsuiteEntry) Code(ctx context.Context) ([]byte, error) {
 
 func GetAllMock(ctx context.Context, useMockType int) map[string]map[string]int {
 	functionMockMap := make(map[string]map[string]int, 0)
-	if useMockType == atgconstant.UseNoMock {
-		return functionMockMap
-	}
 	functionMap, _ := contexthelper.GetSetupFuncMap(ctx)
 	for funcName, functions := range functionMap {
 		ts, err := testcase.CreateTestCase(ctx, functions.TestFunction)
@@ -130,6 +128,27 @@ func GetAllMock(ctx context.Context, useMockType int) map[string]map[string]int
 		mock := make(map[string]int, 0)
 		for _, stat := range ts.Statements {
 			randomMock := ""
+			if stat.Seam != "" {
+				// with gomonkey the function seam is replaced by the fake whose return values are replayed by the
+				// final suite like the patches. Otherwise the test cases hold the stubs of the seams, i.e. the fields
+				// of the receiver and the package variables, see getSeamGlobals
+				if isMadeCall(stat, useMockType) {
+					variable := strings.TrimPrefix(stat.Expression, "&")
+					randomMock = fmt.Sprintf("mockfunc.Replace(t,%s,mockfunc.MakeCall(smartUnitCtx,\"%s\",mockRender,%s,%d))", stat.Expression, stat.Expression, variable, useMockType)
+					mock[randomMock] = 1
+				}
+				continue
+			}
+			// nothing is patched without the mocks, the calls are left in the isolation report
+			if useMockType == atgconstant.UseNoMock {
+				continue
+			}
+			// the interface mock does not patch any function, the mocks take the place of the interface dependencies,
+			// so the other callees run for real
+			if useMockType == atgconstant.UseInterfaceMock {
+				logerror.ExecutionLog.Log(fmt.Sprintf("%s calls %s for real, because the interface mock only replaces the interfaces. use -use_mock_type=gomonkey to patch it", funcName, stat.Expression))
+				continue
+			}
 			switch stat.SpecialType {
 			case "overpass":
 				mockStatement := fmt.Sprintf("%s(mockfunc.OverPassMakeCall(smartUnitCtx,\"%s\",mockRender,%s).(%s));", stat.Expression, stat.Expression, stat.Expression, stat.FunctionType)
@@ -165,6 +184,10 @@ func GetBaseMockByTempleType(ctx context.Context, useMockType int) map[string]ma
 		mock := make(map[string]int, 0)
 		for _, stat := range ts.Statements {
 			randomMock := ""
+			// the seams are not patched
+			if stat.Seam != "" {
+				continue
+			}
 			switch stat.SpecialType {
 			case "overpass":
 				randomMock = fmt.Sprint("// Please fill out the overpass mock yourself \n", "// ", stat.Expression, "(", stat.FunctionType, "{\n", "// \treturn [please fill the return here]\n", "//})")
@@ -295,11 +318,102 @@ func GetSpecialValueBuilder(ctx context.Context) map[string][]string {
 				}
 			}
 		}
+		// the *redis.Client parameters and fields talk to the redis stand-in
+		if redisBuilders := getRedisClientBuilder(ctx, functions.TestFunction.Function); len(redisBuilders) != 0 {
+			InjectorBuilder.Do(initInjector)
+			builders = append(builders, redisBuilders...)
+		}
+		// register the real implementations of the interface parameters, they take the place of the stubs
+		implementations := getInterfaceImplementations(ctx, functions.TestFunction)
+		for _, key := range sortedImplementationKeys(implementations) {
+			InjectorBuilder.Do(initInjector)
+			implementation := implementations[key]
+			builders = append(builders, fmt.Sprintf("injector.SetImplementation((*%s)(nil), func() interface{} { return %s }, %q)", implementation.iface, implementation.value, implementation.code))
+		}
+		// register the stubs of the interface parameters, the test file declares them
+		stubs := getInterfaceStubs(ctx, functions.TestFunction.Function)
+		for key := range implementations {
+			delete(stubs, key)
+		}
+		for _, stub := range sortInterfaceStubs(stubs) {
+			InjectorBuilder.Do(initInjector)
+			builders = append(builders, fmt.Sprintf("injector.SetStub((*%s)(nil), &%s{})", stub.iface, stub.name))
+		}
+		builders = append(builders, getStringSinkBuilder(ctx, functions.TestFunction.Function)...)
 		functionBuilder[funcName] = builders
 	}
 	return functionBuilder
 }
 
+// getStringSinkBuilder tells the middle code which string parameters are parsed by the tested function,
+// e.g. sinkInjector.Set("Args.Data", smartunitvariablebuild.StringSink{Kind: "json"})
+func getStringSinkBuilder(ctx context.Context, function *ssa.Function) []string {
+	builders := make([]string, 0)
+	sinks := setup.GetStringSinks(function)
+	// the format declared by the user is more accurate than the detected one
+	for paramName, pattern := range setup.GetRegexDirectives(function) {
+		sinks[paramName] = setup.StringSink{Kind: atgconstant.StringSinkRegexp, Layout: pattern}
+	}
+	if len(sinks) == 0 {
+		return builders
+	}
+	duplicatepackagemanager.GetInstance(ctx).PutAndGet("smartunitvariablebuild", "github.com/bytedance/nxt_unit/smartunitvariablebuild")
+	builders = append(builders, "sinkInjector := smartunitvariablebuild.NewStringSinkInjector();")
+	builders = append(builders, "smartUnitCtx = context.WithValue(smartUnitCtx,\"StringSinkInjector\",sinkInjector)")
+	for paramName, sink := range sinks {
+		target := "nil"
+		if sink.Target != nil && isTypeAccessible(ctx, sink.Target) {
+			reflectName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("", "reflect")
+			typeName := types.TypeString(sink.Target, func(p *types.Package) string {
+				pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(p.Name(), p.Path())
+				return pkgName
+			})
+			target = fmt.Sprintf("%s.TypeOf(new(%s)).Elem()", reflectName, typeName)
+		}
+		builders = append(builders, fmt.Sprintf("sinkInjector.Set(\"Args.%s\", smartunitvariablebuild.StringSink{Kind: %q, Layout: %q, Target: %s})",
+			strings.Title(paramName), sink.Kind, sink.Layout, target))
+	}
+	return builders
+}
+
+// isTypeAccessible reports whether the middle code is able to refer to the type
+func isTypeAccessible(ctx context.Context, t types.Type) bool {
+	switch typ := t.(type) {
+	case *types.Named:
+		if typ.Obj().Pkg() == nil {
+			return true
+		}
+		return typ.Obj().Exported() || typ.Obj().Pkg().Path() == duplicatepackagemanager.GetInstance(ctx).RelativePath()
+	case *types.Pointer:
+		return isTypeAccessible(ctx, typ.Elem())
+	case *types.Slice:
+		return isTypeAccessible(ctx, typ.Elem())
+	case *types.Array:
+		return isTypeAccessible(ctx, typ.Elem())
+	case *types.Map:
+		return isTypeAccessible(ctx, typ.Key()) && isTypeAccessible(ctx, typ.Elem())
+	case *types.Chan:
+		return isTypeAccessible(ctx, typ.Elem())
+	case *types.Interface:
+		return typ.Empty()
+	case *types.Signature:
+		for i := 0; i < typ.Params().Len(); i++ {
+			if !isTypeAccessible(ctx, typ.Params().At(i).Type()) {
+				return false
+			}
+		}
+		for i := 0; i < typ.Results().Len(); i++ {
+			if !isTypeAccessible(ctx, typ.Results().At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Basic:
+		return true
+	}
+	return false
+}
+
 func GetGlobalValueBuilder(ctx context.Context) ([]string, []string) {
 	functionMap, _ := contexthelper.GetSetupFuncMap(ctx)
 	initBuilder := make([]string, 0)
@@ -443,3 +557,13 @@ func CheckValueExis(ctx context.Context, p *ssa.Function, globalValue, globalNam
 	// }
 	return false
 }
+
+// GetMockCallMatcher returns how the final suite asserts the calls of the mocked functions
+func GetMockCallMatcher(option atgconstant.Options) atgconstant.MockCallMatcher {
+	return atgconstant.MockCallMatcher{
+		Disabled:      option.MockCallAssertion == atgconstant.MockCallAssertNone,
+		CountOnly:     option.MockCallAssertion == atgconstant.MockCallAssertCount,
+		StrictContext: option.StrictContextArg,
+		StrictPointer: option.StrictPointerArg,
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"golang.org/x/tools/go/ssa"
+)
+
+// getErrorMatcher renders the mockfunc.ErrorMatcher of the errors returned by the function. The sentinel errors and
+// the error types come from the package of the function and the packages of its callees.
+func getErrorMatcher(ctx context.Context, function *parsermodel.ProjectFunction, strategies []string) string {
+	pkgs := make([]*ssa.Package, 0)
+	visited := map[*ssa.Package]bool{}
+	addPkg := func(pkg *ssa.Package) {
+		if pkg != nil && !visited[pkg] {
+			visited[pkg] = true
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	addPkg(function.Function.Pkg)
+	for _, callee := range function.CalleeFunctionsForTargetFunction {
+		if callee != nil {
+			addPkg(callee.Pkg)
+		}
+	}
+	sentinels := make([]sentinelError, 0)
+	errTypes := make([]errorType, 0)
+	for _, pkg := range pkgs {
+		sentinels = append(sentinels, getSentinelErrors(ctx, pkg)...)
+		errTypes = append(errTypes, getErrorTypes(ctx, pkg)...)
+	}
+	sort.Slice(sentinels, func(i, j int) bool {
+		return sentinels[i].code < sentinels[j].code
+	})
+	sort.Slice(errTypes, func(i, j int) bool {
+		return errTypes[i].code < errTypes[j].code
+	})
+	var b strings.Builder
+	fmt.Fprintf(&b, "mockfunc.ErrorMatcher{Strategies: %#v", strategies)
+	if len(sentinels) != 0 {
+		b.WriteString(", Sentinels: []mockfunc.Sentinel{")
+		for _, sentinel := range sentinels {
+			fmt.Fprintf(&b, "{Err: %s, Code: %q, PkgName: %q, PkgPath: %q}, ", sentinel.code, sentinel.code, sentinel.pkgName, sentinel.pkgPath)
+		}
+		b.WriteString("}")
+	}
+	if len(errTypes) != 0 {
+		b.WriteString(", Types: []mockfunc.ErrorType{")
+		for _, errType := range errTypes {
+			fmt.Fprintf(&b, "{Target: %s, Code: %q, PkgName: %q, PkgPath: %q}, ", errType.code, errType.code, errType.pkgName, errType.pkgPath)
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// errorType is the type which implements error, code points to it, e.g. new(*dao.NotFoundError)
+type errorType struct {
+	code    string
+	pkgName string
+	pkgPath string
+}
+
+// getErrorTypes finds the types of the package which implement error and which the test is able to refer to.
+// The pointer is used if only its method set has Error.
+func getErrorTypes(ctx context.Context, pkg *ssa.Package) []errorType {
+	errTypes := make([]errorType, 0)
+	if pkg == nil {
+		return errTypes
+	}
+	errorIface := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	relativePath := duplicatepackagemanager.GetInstance(ctx).RelativePath()
+	for _, member := range pkg.Members {
+		typ, ok := member.(*ssa.Type)
+		if !ok || (!typ.Object().Exported() && pkg.Pkg.Path() != relativePath) {
+			continue
+		}
+		var target types.Type = typ.Type()
+		if types.IsInterface(target) {
+			continue
+		}
+		if !types.Implements(target, errorIface) {
+			target = types.NewPointer(target)
+			if !types.Implements(target, errorIface) {
+				continue
+			}
+		}
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(pkg.Pkg.Name(), pkg.Pkg.Path())
+		errType := errorType{code: fmt.Sprintf("new(%s)", types.TypeString(target, func(*types.Package) string { return pkgName }))}
+		if pkgName != "" {
+			errType.pkgName, errType.pkgPath = pkgName, pkg.Pkg.Path()
+		}
+		errTypes = append(errTypes, errType)
+	}
+	sort.Slice(errTypes, func(i, j int) bool {
+		return errTypes[i].code < errTypes[j].code
+	})
+	return errTypes
+}
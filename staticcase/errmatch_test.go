@@ -0,0 +1,64 @@
+package staticcase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/stretchr/testify/assert"
+)
+
+const errMatchSrc = `package dao
+
+var ErrNotFound error = &NotFoundError{}
+
+type NotFoundError struct {
+	ID int
+}
+
+func (e *NotFoundError) Error() string { return "not found" }
+
+type Code int
+
+func (c Code) Error() string { return "code" }
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "timeout" }
+
+type Temporary interface {
+	error
+	Temporary() bool
+}
+
+type User struct{}
+
+func Get(id int) (*User, error) {
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	return &User{}, nil
+}
+`
+
+func TestGetErrorMatcher(t *testing.T) {
+	ssaPkg := buildSSA(t, "example.com/dao", errMatchSrc)
+	ctx := duplicatepackagemanager.SetInstance(context.Background())
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/dao")
+	assert.Equal(t, []errorType{{code: "new(*NotFoundError)"}, {code: "new(Code)"}, {code: "new(timeoutError)"}}, getErrorTypes(ctx, ssaPkg))
+
+	// the unexported types of the other packages are not accessible
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/app")
+	assert.Equal(t, []errorType{
+		{code: "new(*dao.NotFoundError)", pkgName: "dao", pkgPath: "example.com/dao"},
+		{code: "new(dao.Code)", pkgName: "dao", pkgPath: "example.com/dao"},
+	}, getErrorTypes(ctx, ssaPkg))
+
+	function := &parsermodel.ProjectFunction{Function: ssaPkg.Func("Get")}
+	assert.Equal(t, `mockfunc.ErrorMatcher{Strategies: []string{"is", "as"}`+
+		`, Sentinels: []mockfunc.Sentinel{{Err: dao.ErrNotFound, Code: "dao.ErrNotFound", PkgName: "dao", PkgPath: "example.com/dao"}, }`+
+		`, Types: []mockfunc.ErrorType{{Target: new(*dao.NotFoundError), Code: "new(*dao.NotFoundError)", PkgName: "dao", PkgPath: "example.com/dao"}, `+
+		`{Target: new(dao.Code), Code: "new(dao.Code)", PkgName: "dao", PkgPath: "example.com/dao"}, }}`,
+		getErrorMatcher(ctx, function, []string{"is", "as"}))
+}
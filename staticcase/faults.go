@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/statement"
+	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/testcase"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"golang.org/x/tools/go/ssa"
+)
+
+// injectedFaultCode is the error injected into every error result besides the sentinel errors
+const injectedFaultCode = `fmt.Errorf("injected fault")`
+
+// getFaults lists the errors injected into the mocked callees of the function, one fault per error result and error.
+// Besides the generic error, the callee may return the sentinel errors of its package, e.g. sql.ErrNoRows.
+// Only the callees which are faked by mockfunc.MakeCall with the mock type are injected.
+func getFaults(ctx context.Context, function *parsermodel.ProjectFunction, useMockType int) []atgconstant.Fault {
+	faults := make([]atgconstant.Fault, 0)
+	ts, err := testcase.CreateTestCase(ctx, function)
+	if err != nil {
+		return faults
+	}
+	for _, stat := range ts.Statements {
+		if !isMadeCall(stat, useMockType) || stat.Signature == nil {
+			continue
+		}
+		errs := []atgconstant.Fault{{Code: injectedFaultCode}}
+		if stat.OriginalFunction != nil {
+			for _, sentinel := range getSentinelErrors(ctx, stat.OriginalFunction.Pkg) {
+				errs = append(errs, atgconstant.Fault{Code: sentinel.code, PkgName: sentinel.pkgName, PkgPath: sentinel.pkgPath})
+			}
+		}
+		results := stat.Signature.Results()
+		for i := 0; i < results.Len(); i++ {
+			if !isErrorType(results.At(i).Type()) {
+				continue
+			}
+			for _, e := range errs {
+				e.Func, e.Result = stat.Expression, i
+				faults = append(faults, e)
+			}
+		}
+	}
+	return faults
+}
+
+// isMadeCall reports whether the middle code fakes the statement by mockfunc.MakeCall, see GetAllMock
+func isMadeCall(stat statement.Statement, useMockType int) bool {
+	if stat.Seam != "" {
+		return stat.SeamInterface == nil && useMockType == atgconstant.UseGoMonkeyMock
+	}
+	if stat.SpecialType == "overpass" {
+		return false
+	}
+	return useMockType == atgconstant.UseGoMonkeyMock || useMockType == atgconstant.UseMockitoMock
+}
+
+// sentinelError is the package variable of the error type, code refers to it, e.g. dao.ErrNotFound
+type sentinelError struct {
+	code    string
+	pkgName string
+	pkgPath string
+}
+
+// getSentinelErrors finds the package variables of the error type which the test is able to refer to,
+// e.g. var ErrNotFound = errors.New("not found")
+func getSentinelErrors(ctx context.Context, pkg *ssa.Package) []sentinelError {
+	sentinels := make([]sentinelError, 0)
+	if pkg == nil {
+		return sentinels
+	}
+	for _, member := range pkg.Members {
+		global, ok := member.(*ssa.Global)
+		if !ok || !isErrorType(global.Type().(*types.Pointer).Elem()) {
+			continue
+		}
+		if code, pkgName, pkgPath, ok := globalCode(ctx, global); ok {
+			sentinels = append(sentinels, sentinelError{code: code, pkgName: pkgName, pkgPath: pkgPath})
+		}
+	}
+	sort.Slice(sentinels, func(i, j int) bool {
+		return sentinels[i].code < sentinels[j].code
+	})
+	return sentinels
+}
+
+// globalCode returns how the test refers to the package variable. The unexported variable of the other package is
+// not accessible.
+func globalCode(ctx context.Context, global *ssa.Global) (code, pkgName, pkgPath string, ok bool) {
+	pkg := global.Pkg.Pkg
+	if strings.Contains(global.Name(), "$") {
+		return "", "", "", false
+	}
+	if !global.Object().Exported() && pkg.Path() != duplicatepackagemanager.GetInstance(ctx).RelativePath() {
+		return "", "", "", false
+	}
+	pkgName, _ = duplicatepackagemanager.GetInstance(ctx).PutAndGet(pkg.Name(), pkg.Path())
+	if pkgName == "" {
+		return global.Name(), "", "", true
+	}
+	return pkgName + "." + global.Name(), pkgName, pkg.Path(), true
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,59 @@
+package staticcase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/statement"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/stretchr/testify/assert"
+)
+
+const faultsSrc = `package dao
+
+type daoError string
+
+func (e daoError) Error() string { return string(e) }
+
+var ErrNotFound error = daoError("not found")
+
+var errClosed error = daoError("closed")
+
+var ErrConflict = daoError("conflict")
+
+var Retries int
+
+func Get(id int) (string, error) {
+	if id == 0 {
+		return "", ErrNotFound
+	}
+	return "", errClosed
+}
+`
+
+func TestGetSentinelErrors(t *testing.T) {
+	ssaPkg := buildSSA(t, "example.com/dao", faultsSrc)
+	ctx := duplicatepackagemanager.SetInstance(context.Background())
+	// only the variables of the error type are the sentinels
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/dao")
+	assert.Equal(t, []sentinelError{{code: "ErrNotFound"}, {code: "errClosed"}}, getSentinelErrors(ctx, ssaPkg))
+
+	// the unexported errors of the other packages are not accessible
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/app")
+	assert.Equal(t, []sentinelError{{code: "dao.ErrNotFound", pkgName: "dao", pkgPath: "example.com/dao"}}, getSentinelErrors(ctx, ssaPkg))
+	assert.Equal(t, 0, len(getSentinelErrors(ctx, nil)))
+}
+
+func TestIsMadeCall(t *testing.T) {
+	patched := statement.Statement{Expression: "dao.Get"}
+	seam := statement.Statement{Expression: "&now", Seam: atgconstant.SeamGlobal}
+	overpass := statement.Statement{Expression: "rpc.SetMock.Get", SpecialType: "overpass"}
+	assert.True(t, isMadeCall(patched, atgconstant.UseGoMonkeyMock))
+	assert.True(t, isMadeCall(patched, atgconstant.UseMockitoMock))
+	assert.False(t, isMadeCall(patched, atgconstant.UseNoMock))
+	assert.False(t, isMadeCall(seam, atgconstant.UseNoMock))
+	assert.False(t, isMadeCall(seam, atgconstant.UseMockitoMock))
+	assert.False(t, isMadeCall(overpass, atgconstant.UseGoMonkeyMock))
+	assert.False(t, isMadeCall(patched, atgconstant.UseInterfaceMock))
+}
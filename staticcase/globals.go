@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/statement"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"golang.org/x/tools/go/ssa"
+)
+
+// getGlobalVars finds the package variables which the function, its closures or the functions of the same package
+// it calls read or write. The variables of the standard library, the unexported ones of the other packages and the
+// locks are left out. The variables of the basic types and the seams get the fields of the test struct, so that the
+// search generates them as the inputs. The seams are the addresses of the called variables, see getSeamGlobals.
+func getGlobalVars(ctx context.Context, function *ssa.Function, seams map[string]bool) []atgconstant.GlobalVar {
+	globals := map[*ssa.Global]bool{}
+	collectGlobals(function, globals, map[*ssa.Function]bool{})
+	relativePath := duplicatepackagemanager.GetInstance(ctx).RelativePath()
+	qualifier := func(p *types.Package) string {
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(p.Name(), p.Path())
+		return pkgName
+	}
+	res := make([]atgconstant.GlobalVar, 0)
+	fields := map[string]bool{}
+	for global := range globals {
+		pkg := global.Pkg.Pkg
+		if strings.Contains(global.Name(), "$") || isStdPkg(pkg.Path(), function.Pkg.Pkg.Path()) {
+			continue
+		}
+		if !global.Object().Exported() && pkg.Path() != relativePath {
+			continue
+		}
+		typ := global.Type().(*types.Pointer).Elem()
+		if isLock(typ) {
+			continue
+		}
+		v := atgconstant.GlobalVar{Code: global.Name()}
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(pkg.Name(), pkg.Path())
+		if pkgName != "" {
+			v.Code = pkgName + "." + global.Name()
+			v.PkgName, v.PkgPath = pkgName, pkg.Path()
+		}
+		basic, ok := typ.Underlying().(*types.Basic)
+		generated := ok && basic.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0 && basic.Info()&types.IsUntyped == 0
+		if (generated || seams["&"+v.Code]) && isTypeAccessible(ctx, typ) {
+			v.Field = "Global" + strings.Title(pkgName) + strings.Title(global.Name())
+			for fields[v.Field] {
+				v.Field += "_"
+			}
+			fields[v.Field] = true
+			v.Type = types.TypeString(typ, qualifier)
+		}
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Code < res[j].Code
+	})
+	return res
+}
+
+// getSeamGlobals returns the addresses of the package variables which the function calls, e.g. &now, unless the
+// middle code replaces them by the fakes, see isMadeCall. The test cases hold their stubs instead.
+func getSeamGlobals(ctx context.Context, function *ssa.Function, useMockType int) map[string]bool {
+	seams := map[string]bool{}
+	for _, seam := range statement.CreateSeamStatements(ctx, function) {
+		if seam.Seam == atgconstant.SeamGlobal && !isMadeCall(*seam, useMockType) {
+			seams[seam.Expression] = true
+		}
+	}
+	return seams
+}
+
+func collectGlobals(function *ssa.Function, globals map[*ssa.Global]bool, visited map[*ssa.Function]bool) {
+	if function == nil || visited[function] {
+		return
+	}
+	visited[function] = true
+	for _, block := range function.Blocks {
+		for _, instr := range block.Instrs {
+			for _, operand := range instr.Operands(nil) {
+				if operand == nil {
+					continue
+				}
+				if global, ok := (*operand).(*ssa.Global); ok && global.Pkg != nil {
+					globals[global] = true
+				}
+			}
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			if callee := call.Common().StaticCallee(); callee != nil && callee.Pkg != nil && callee.Pkg == function.Pkg {
+				collectGlobals(callee, globals, visited)
+			}
+		}
+	}
+	for _, anon := range function.AnonFuncs {
+		collectGlobals(anon, globals, visited)
+	}
+}
+
+// isLock reports whether the variable must not be copied, e.g. sync.Mutex
+func isLock(t types.Type) bool {
+	methods := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < methods.Len(); i++ {
+		if name := methods.At(i).Obj().Name(); name == "Lock" || name == "RLock" {
+			return true
+		}
+	}
+	return false
+}
+
+// isStdPkg reports whether the package belongs to the standard library, whose first path element has no dot,
+// unless the module of the tested package has no dot as well
+func isStdPkg(path, testedPath string) bool {
+	root := strings.Split(path, "/")[0]
+	return !strings.Contains(root, ".") && root != strings.Split(testedPath, "/")[0]
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"sort"
+
+	"github.com/bytedance/nxt_unit/atghelper"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/bytedance/nxt_unit/smartunitvariablebuild"
+)
+
+// interfaceImplementation is the concrete type of the module which satisfies an interface parameter.
+type interfaceImplementation struct {
+	iface string // name of the interface in the test file
+	value string // expression of the implementation, e.g. dao.NewUserDao() or &dao.UserDao{}
+	code  string // how the test case writes the value, empty if the fields are generated
+}
+
+// getInterfaceImplementations returns the implementations of the interface parameters and the interface fields
+// of the receiver when the user asks for the real implementations. The key is the full name of the interface.
+func getInterfaceImplementations(ctx context.Context, function *parsermodel.ProjectFunction) map[string]*interfaceImplementation {
+	implementations := map[string]*interfaceImplementation{}
+	if function != nil {
+		// the clients of the redis stand-in take the place of the stubs of the go-redis interfaces
+		implementations = getRedisImplementations(ctx, function.Function)
+	}
+	opt, _ := contexthelper.GetOption(ctx)
+	if !opt.UseRealImplementation || function == nil || function.Function == nil {
+		return implementations
+	}
+	modulePath, err := atghelper.GetModulePath(filepath.Dir(opt.FilePath))
+	if err != nil && function.Program != nil {
+		// only the tested package is safe to use
+		modulePath = function.Program.PkgPath
+	}
+	for _, dependency := range getParamAndFieldTypes(function.Function.Signature) {
+		named, ok := dependency.(*types.Named)
+		if !ok || !types.IsInterface(named) || smartunitvariablebuild.SpecialVariableChecker(ctx, named) || !isTypeAccessible(ctx, named) {
+			continue
+		}
+		if _, exist := implementations[named.String()]; exist {
+			continue
+		}
+		for _, candidate := range setup.GetImplementations(function.Program, named, modulePath) {
+			if implementation, ok := buildInterfaceImplementation(ctx, named, candidate); ok {
+				implementations[named.String()] = implementation
+				break
+			}
+		}
+	}
+	return implementations
+}
+
+// getParamAndFieldTypes returns the types of the parameters and of the fields of the receiver,
+// the receiver is generated like the parameters
+func getParamAndFieldTypes(signature *types.Signature) []types.Type {
+	dependencies := make([]types.Type, 0)
+	params := signature.Params()
+	for i := 0; i < params.Len(); i++ {
+		dependencies = append(dependencies, params.At(i).Type())
+	}
+	if signature.Recv() == nil {
+		return dependencies
+	}
+	recv := signature.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if st, ok := recv.Underlying().(*types.Struct); ok {
+		for i := 0; i < st.NumFields(); i++ {
+			dependencies = append(dependencies, st.Field(i).Type())
+		}
+	}
+	return dependencies
+}
+
+func buildInterfaceImplementation(ctx context.Context, iface *types.Named, candidate setup.Implementation) (*interfaceImplementation, bool) {
+	if !isTypeAccessible(ctx, candidate.Type) {
+		return nil, false
+	}
+	qualifier := func(p *types.Package) string {
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(p.Name(), p.Path())
+		return pkgName
+	}
+	constructor := candidate.Constructor
+	if constructor != nil && (constructor.Object().Exported() || constructor.Pkg.Pkg.Path() == duplicatepackagemanager.GetInstance(ctx).RelativePath()) {
+		implementation := &interfaceImplementation{iface: types.TypeString(iface, qualifier)}
+		if pkgName := qualifier(constructor.Pkg.Pkg); pkgName != "" {
+			implementation.value = fmt.Sprintf("%s.%s()", pkgName, constructor.Name())
+		} else {
+			implementation.value = fmt.Sprintf("%s()", constructor.Name())
+		}
+		implementation.code = implementation.value
+		return implementation, true
+	}
+	// without the constructor, the test case builds the struct and generates its fields
+	elem, prefix := candidate.Type, ""
+	if ptr, ok := elem.(*types.Pointer); ok {
+		elem, prefix = ptr.Elem(), "&"
+	}
+	if _, ok := elem.Underlying().(*types.Struct); !ok {
+		return nil, false
+	}
+	return &interfaceImplementation{
+		iface: types.TypeString(iface, qualifier),
+		value: fmt.Sprintf("%s%s{}", prefix, types.TypeString(elem, qualifier)),
+	}, true
+}
+
+func sortedImplementationKeys(implementations map[string]*interfaceImplementation) []string {
+	keys := make([]string, 0, len(implementations))
+	for key := range implementations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
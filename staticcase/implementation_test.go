@@ -0,0 +1,121 @@
+package staticcase
+
+import (
+	"context"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const implementationSrc = `package store
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type Cache interface {
+	Hit(key string) bool
+}
+
+type MemoryStore struct {
+	Data map[string]string
+}
+
+func (m *MemoryStore) Get(key string) (string, error) {
+	return m.Data[key], nil
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{Data: map[string]string{}}
+}
+
+type LRUCache struct {
+	Size int
+}
+
+func (c LRUCache) Hit(key string) bool {
+	return c.Size > 0
+}
+
+type Finder interface {
+	Find(key string) bool
+}
+
+type MapFinder struct {
+	Keys map[string]bool
+}
+
+func (f MapFinder) Find(key string) bool {
+	return f.Keys[key]
+}
+
+func Load(s Store, c Cache, key string) string {
+	v, _ := s.Get(key)
+	return v
+}
+
+type Service struct {
+	Finder Finder
+	Name   string
+}
+
+func (s *Service) Has(key string) bool {
+	return s.Finder.Find(key)
+}
+`
+
+func TestGetInterfaceImplementations(t *testing.T) {
+	ssaPkg := buildSSA(t, "example.com/mod/store", implementationSrc)
+	allFuncs := ssautil.AllFunctions(ssaPkg.Prog)
+	methodsByName := make(map[string][]*ssa.Function)
+	for f := range allFuncs {
+		if f.Signature.Recv() != nil {
+			methodsByName[f.Name()] = append(methodsByName[f.Name()], f)
+		}
+	}
+	function := &parsermodel.ProjectFunction{
+		Function: ssaPkg.Func("Load"),
+		Program: &parsermodel.ProjectProgram{
+			PkgName:       "store",
+			PkgPath:       "example.com/mod/store",
+			Prog:          ssaPkg.Prog,
+			Pkgs:          []*ssa.Package{ssaPkg},
+			AllFuncs:      allFuncs,
+			MethodsByName: methodsByName,
+		},
+	}
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/mod\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{FilePath: filepath.Join(dir, "store", "store.go")})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/mod/store")
+
+	// the stubs are used unless the user asks for the real implementations
+	assert.Equal(t, 0, len(getInterfaceImplementations(ctx, function)))
+
+	ctx = contexthelper.SetOption(ctx, atgconstant.Options{FilePath: filepath.Join(dir, "store", "store.go"), UseRealImplementation: true})
+	implementations := getInterfaceImplementations(ctx, function)
+	assert.Equal(t, 2, len(implementations))
+	assert.Equal(t, &interfaceImplementation{iface: "Store", value: "NewMemoryStore()", code: "NewMemoryStore()"},
+		implementations["example.com/mod/store.Store"])
+	assert.Equal(t, &interfaceImplementation{iface: "Cache", value: "LRUCache{}"},
+		implementations["example.com/mod/store.Cache"])
+
+	// the interface fields of the receiver are generated like the parameters
+	function.Function = ssaPkg.Prog.FuncValue(ssaPkg.Pkg.Scope().Lookup("Service").(*types.TypeName).Type().(*types.Named).Method(0))
+	implementations = getInterfaceImplementations(ctx, function)
+	assert.Equal(t, 1, len(implementations))
+	assert.Equal(t, &interfaceImplementation{iface: "Finder", value: "MapFinder{}"},
+		implementations["example.com/mod/store.Finder"])
+}
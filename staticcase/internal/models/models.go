@@ -145,6 +145,39 @@ type Function struct {
 	RowData          string
 	ReturnsError     bool
 	ContainAnonFuncs int
+	// the inputs of the test hold the typed mocks, whose calls the test asserts
+	TypedMocks bool
+	// the test serves the net/http client calls from the httptest stand-in
+	HTTPStandIn bool
+	// the *sql.DB inputs of the test are the in-memory stand-ins
+	SQLStandIn bool
+	// the go-redis clients of the test talk to the redis stand-in
+	RedisStandIn bool
+	// the test reads the fake clock instead of the real one
+	FakeClock bool
+	// the test runs in the sandbox of the generated environment keys and files
+	Sandbox bool
+	// another function of the file runs in the sandbox, so the middle code keeps the cases out of it
+	SandboxedFile bool
+	// the environment keys and the relative paths which the middle code generates for the sandbox
+	SandboxEnv   []string
+	SandboxFiles []string
+	// the package variables which the test snapshots and restores for each case
+	Globals []atgconstant.GlobalVar
+	// why the calls of the function can not be isolated without patching
+	NotIsolated []string
+	// the errors which the middle code injects into the mocked callees after the random cases
+	Faults []atgconstant.Fault
+	// the test checks the returned error by errors.Is, errors.As or its message besides whether there is one
+	ErrorMatch bool
+	// the code of the mockfunc.ErrorMatcher by which the middle code finds how to check the error
+	ErrorMatcher string
+	// the cases carry the result fields which differ between the runs, see mockfunc.Unstable
+	Unstable bool
+	// how many times the middle code re-runs each case to find the unstable fields
+	Reruns int
+	// how the cases which panic are kept, see atgconstant.PanicTestExpect. empty drops them
+	PanicTests string
 }
 
 func (f *Function) TestParameters() []*Field {
@@ -178,6 +211,17 @@ func (f *Function) TestResults() []*Field {
 	return ps
 }
 
+// HasUncheckedResults reports whether some results are compared only if the test file is able to render them,
+// i.e. the results which are neither the basic types nor the writers
+func (f *Function) HasUncheckedResults() bool {
+	for _, r := range f.Results {
+		if !r.IsWriter() && !r.IsBasicType() {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *Function) ReturnsMultiple() bool {
 	return len(f.Results) > 1
 }
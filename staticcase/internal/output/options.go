@@ -23,10 +23,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/bytedance/nxt_unit/atghelper"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
 	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/bytedance/nxt_unit/atgconstant"
 
@@ -48,6 +50,7 @@ type Options struct {
 	MiddleBuilders []string
 	GlobalInit     []string
 	MiddleCodeInit []string
+	Stubs          []string // declarations of the interface stubs
 	TestCaseNum    int
 	TemplateParams map[string]interface{}
 	TemplateData   [][]byte
@@ -124,13 +127,18 @@ func (o *Options) writeTests(w io.Writer, head *models.Header, funcs []*models.F
 	if err := o.render.Header(b, head, headerTemplate); err != nil {
 		return fmt.Errorf("render.Header: %v", err)
 	}
+	for _, stub := range o.Stubs {
+		if _, err := b.WriteString(stub + "\n"); err != nil {
+			return fmt.Errorf("render stub: %v", err)
+		}
+	}
 
 	if o.TestMode == atgconstant.MiddleCode {
 		wgStr := fmt.Sprintf("wg%s := sync.WaitGroup{}\n", o.Uid)
 		// get context pkgname
 		ctxPkgName, _ := duplicatepackagemanager.GetInstance(o.Ctx).PutAndGet("", "context")
 		// define the render path of final suite
-		declPath := fmt.Sprintf("t.Parallel();originPath := \"%s\" \n declLocker := sync.RWMutex{} \n declData := map[string][]string{}\n useMockMap := map[string]map[string]int{}\n type DeclResult struct {\n\t\tAvailableList []bool\n\t\tPathSync  sync.Map\n\t} \n declStatistics := map[string]DeclResult{} \n smartUnitCtx := duplicatepackagemanager.SetInstance(%s.Background())\n", o.FilePath, ctxPkgName)
+		declPath := fmt.Sprintf("t.Parallel();originPath := \"%s\" \n declLocker := sync.RWMutex{} \n declData := map[string][]string{}\n useMockMap := map[string]map[string]int{}\n type DeclResult struct {\n\t\tAvailableList []bool\n\t\tPathSync  sync.Map\n\t} \n declStatistics := map[string]DeclResult{} \n declRuns := map[string][]mockfunc.CaseRuns{} \n declPanics := map[string][]string{} \n smartUnitCtx := duplicatepackagemanager.SetInstance(%s.Background())\n panics := &mockfunc.Panics{} \n PanicHook%s = panics.Record \n", o.FilePath, ctxPkgName, o.Uid)
 		var orginalImportStr string
 		for index := range head.OriginalImports {
 			orginalImportStr = fmt.Sprintf("%sduplicatepackagemanager.GetInstance(smartUnitCtx).PutAndGet(\"%s\",%s)\n", orginalImportStr, head.OriginalImports[index].Name, head.OriginalImports[index].Path)
@@ -155,21 +163,30 @@ func (o *Options) writeTests(w io.Writer, head *models.Header, funcs []*models.F
 		for _, statement := range o.MiddleCodeInit {
 			globalInitSet = fmt.Sprintf("%sduplicatepackagemanager.GetInstance(smartUnitCtx).SetInitBuilder(\"%s\")\n", globalInitSet, statement)
 		}
+		// the final suite declares the stubs again
+		for _, stub := range o.Stubs {
+			globalInitSet = fmt.Sprintf("%sduplicatepackagemanager.GetInstance(smartUnitCtx).SetStubBuilder(%q)\n", globalInitSet, stub)
+		}
 		_, err := b.WriteString("func TestD" + o.Uid + "(t *testing.T)  { \n " + wgStr + declPath + globalInit + orginalImportStr + globalInitSet)
 		if err != nil {
 			return fmt.Errorf("render.TestFunction: %v", err)
 		}
 	}
+	// how the final suite asserts the calls of the mocked functions, which is set by the middle code
+	var matcher atgconstant.MockCallMatcher
+	if o.Ctx != nil {
+		matcher, _ = contexthelper.GetMockCallMatcher(o.Ctx)
+	}
 	for index := range funcs {
 		if o.TestMode == atgconstant.FinalTest && funcs[index].RowData == "" {
 			continue
 		}
 		mocks := []string{}
 		builders := []string{}
+		callAssertion := ""
+		seams := []string{}
 		// need fullName to get mocks because of the same functionName but different receiver
 		switch o.UseMockType {
-		case atgconstant.UseNoMock:
-			mocks = []string{}
 		case atgconstant.UseMockitoMock:
 			if statementMap, ok := o.Mocks[funcs[index].Name]; ok {
 				statements := make([]string, 0)
@@ -184,6 +201,11 @@ func (o *Options) writeTests(w io.Writer, head *models.Header, funcs []*models.F
 				if statementMap, ok := o.Mocks[funcName]; ok {
 					statements := make([]string, 0)
 					for statement, _ := range statementMap {
+						// the seams are replaced after the receiver is created
+						if strings.HasPrefix(statement, "mockfunc.Replace(") {
+							seams = append(seams, statement)
+							continue
+						}
 						statements = append(statements, statement)
 					}
 					mocks = statements
@@ -194,28 +216,41 @@ func (o *Options) writeTests(w io.Writer, head *models.Header, funcs []*models.F
 					funcName = funcs[index].Receiver.Type.String() + funcName
 				}
 				if mockFuncNames, ok := o.Mocks[funcName]; ok {
-					for mockFuncName, outCount := range mockFuncNames {
-						patchName := fmt.Sprintf("%sPatch", atghelper.RandStringBytes(5))
-						totalOut := ""
-						for index := 0; index < outCount; index++ {
-							separate := ""
-							if index > 0 {
-								separate = ","
-							}
-							totalOut = totalOut + fmt.Sprintf("%stt.MonkeyOutputMap[\"%s\"][%d]", separate, mockFuncName, index)
+					for mockFuncName := range mockFuncNames {
+						// the seam is keyed by its address, e.g. &now
+						if strings.HasPrefix(mockFuncName, "&") {
+							seams = append(seams, fmt.Sprintf("mockfunc.Replace(t,%s,callRecorder.Return(\"%s\",%s,tt.MonkeyOutputMap[\"%s\"]...))", mockFuncName, mockFuncName, strings.TrimPrefix(mockFuncName, "&"), mockFuncName))
+							continue
 						}
-						mockStateMent := fmt.Sprintf("%s := gomonkeyv2.ApplyFuncReturn(%s,%s)\n\t\tdefer %s.Reset()", patchName, mockFuncName, totalOut, patchName)
+						patchName := fmt.Sprintf("%sPatch", atghelper.RandStringBytes(5))
+						// the patch returns the recorded values in order and records the calls
+						mockStateMent := fmt.Sprintf("%s := gomonkeyv2.ApplyFunc(%s,callRecorder.Return(\"%s\",%s,tt.MonkeyOutputMap[\"%s\"]...))\n\t\tdefer %s.Reset()", patchName, mockFuncName, mockFuncName, mockFuncName, mockFuncName, patchName)
 						mocks = append(mocks, mockStateMent)
 					}
 				}
+				if len(mocks) != 0 || len(seams) != 0 {
+					mocks = append([]string{"callRecorder := mockfunc.NewCallRecorder()"}, mocks...)
+					if !matcher.Disabled {
+						// assert the calls after the tested function returns
+						callAssertion = fmt.Sprintf("convey.So(mockfunc.DiffCalls(tt.MockCalls, callRecorder, %#v), convey.ShouldBeEmpty)", matcher)
+					}
+				}
 			}
 		default:
 			mocks = []string{}
 		}
+		// the typed mocks in the inputs record their calls, which are checked like the calls of the patches
+		if o.TestMode == atgconstant.FinalTest && funcs[index].TypedMocks && !matcher.Disabled {
+			recorder := "mockfunc.NewCallRecorder()"
+			if callAssertion != "" {
+				recorder = "callRecorder"
+			}
+			callAssertion = fmt.Sprintf("convey.So(mockfunc.DiffCalls(tt.MockCalls, %s.ObserveMocks(tt), %#v), convey.ShouldBeEmpty)", recorder, matcher)
+		}
 		if statement, ok := o.Builders[funcs[index].Name]; ok {
 			builders = statement
 		}
-		err := o.render.TestFunction(b, funcs[index], o.PrintInputs, o.Subtests, o.Named, o.Parallel, o.TemplateParams, mocks, builders, o.MiddleBuilders, o.TestCaseNum, o.UseMockType, o.Uid, funcs[index].RowData, o.TestMode, o.FilePath, o.GlobalInit)
+		err := o.render.TestFunction(b, funcs[index], o.PrintInputs, o.Subtests, o.Named, o.Parallel, o.TemplateParams, mocks, builders, o.MiddleBuilders, o.TestCaseNum, o.UseMockType, o.Uid, funcs[index].RowData, o.TestMode, o.FilePath, o.GlobalInit, callAssertion, seams)
 		if err != nil {
 			return fmt.Errorf("render.TestFunction: %v", err)
 		}
@@ -278,8 +313,9 @@ res := map[string]string{}
 		sResult, ok := declStatistics[k]
 		if ok {
 			dataList := make([]string, 0)
-			for index, available := range sResult.AvailableList {
-				if available {
+			// the runs of each case report their coverage, and the first case of each panic site is kept
+			for index, available := range mockfunc.SelectPanics(mockfunc.FoldRuns(sResult.AvailableList, declRuns[k]), declPanics[k]) {
+				if available && index < len(v) {
 					dataList = append(dataList, v[index])
 				}
 			}
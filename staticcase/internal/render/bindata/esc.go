@@ -85,7 +85,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBasefuncTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x56\xcd\x6e\xdb\x46\x10\x3e\x93\x4f\x31\x11\x94\x80\x2c\x94\x35\xd0\xa3\x0c\x1d\x9a\xc6\x0d\x72\x48\x1d\xd8\x6e\x73\x28\x8a\x82\xa2\x86\x32\x91\xd5\x52\x59\x0e\x6d\x08\x8b\x7d\xf7\x62\x96\x4b\x72\x49\x51\x89\x2f\xf1\xc1\x24\x67\xe7\xe7\x9b\xbf\x6f\x65\xcc\x0e\x8b\x52\x21\x2c\xb6\x59\x8d\x45\xa3\xf2\x85\xb5\xb1\x31\x6f\x61\x59\xc0\x7a\x03\xc2\xda\x38\xbe\xba\x82\x67\x84\x5c\x63\x46\x08\xf4\x88\x40\x58\x13\x10\x1e\x8e\x92\x25\x45\xa5\x9d\x54\x37\x4a\x65\x5b\x89\xc0\x6e\xa8\xac\x14\x1b\x1e\x25\x66\x35\x42\x51\x4a\xd9\x9b\xe6\x2c\xc9\xd4\x0e\x0e\x55\xfe\x75\xd0\xe6\x17\x30\x46\x3c\x60\x4d\x7f\x66\x07\xb4\x36\x21\xf8\x85\x0d\x4a\xb5\x17\x0f\x29\x98\x18\x00\x80\xc1\xe9\x4c\xed\x11\x96\xe2\x83\xac\xb6\x99\xfc\xa8\x4a\xb2\xd6\x1d\x42\xab\x21\xfc\x27\xeb\xa2\xda\x59\x1b\x47\xfc\xfa\x5c\xd2\x23\x88\x3b\xcc\xb1\x7c\x42\xcd\x52\x27\x2e\x0b\x10\x1f\xeb\x7b\xd2\x4d\x4e\x4e\xd8\x4b\xff\x28\x51\xee\xea\x56\x16\xd1\xe9\x88\xd0\x4a\xa0\x76\xca\x60\xdc\xc1\x80\x68\x6c\x10\x19\xe3\xbe\xb9\x8a\x2e\xb1\xd3\x11\xfd\x51\x00\x2c\x8a\xa2\x3e\x66\x27\x9a\xe0\x0e\x5e\x19\x15\x17\xe8\x73\xa6\xb3\x03\x12\x6a\x17\xcc\x41\xfb\x4d\xef\x47\xc0\x02\x58\xe7\x16\x2e\xa0\x13\x9d\xa1\x0b\x22\x8e\xe2\x73\x41\x5d\x20\xd7\xfe\x36\x90\x89\x87\xa2\x33\xb6\x44\x55\x04\x82\xbb\xb7\x4b\xad\xe5\x27\x2b\x96\x6a\x6f\xcc\xe0\xa4\xeb\xcc\x59\x3b\x20\xf8\xf3\xc9\xf2\x98\xf4\xcd\x09\xea\x0b\x93\x3f\xdf\x97\xf6\x71\xe6\x08\x65\x8d\x33\x46\xc6\x74\xc1\x27\x45\x38\xb3\x3f\xc3\x7e\x2e\x99\xed\x4c\xe8\xc8\xf5\x87\xff\xfd\xc0\x51\xd0\xb3\x3b\xac\x1b\x49\x53\x47\xc6\x7c\xc9\x14\x5d\x80\x7c\x19\xdc\x1d\x52\xa3\x55\x7d\xa3\x75\x35\x2d\x36\xfb\xbb\xd1\x1a\xb6\x55\x25\x2f\x78\xf2\xfd\xc7\x9a\x6a\x26\x86\x7f\xfe\xe5\xd7\xa1\xfd\x57\x57\xf0\x70\xfb\xfe\x76\x0d\xd9\x6e\x37\x5a\x74\x6f\x1c\x31\x4b\xfc\xb7\x02\x22\xb6\xf6\x39\xb6\xde\x4c\x1c\x75\x18\xf1\x1b\x88\xbf\x6a\xfc\x54\xe5\x5f\x39\x2d\xf8\x15\xac\x65\x92\x28\xa9\x12\x9f\x33\xca\x1f\x7f\xaf\xd4\x13\x9e\x12\x22\x37\x63\x2b\xa0\x95\xe3\x8f\x24\x35\x7d\x9f\xc1\x5a\xc8\x9d\x9a\xf8\xae\xb6\x1f\xf1\x59\xf0\xda\x95\x0a\xaa\x62\x4c\x51\xf5\x4c\x9f\x96\x82\xd1\x4e\x5a\x34\x30\xd0\xb8\x90\x03\xb3\xdc\x37\x5b\x97\xfd\x48\xc8\xa3\x23\x25\x4a\x6b\xdb\x32\x11\x5d\xf7\x38\xa3\x70\x21\x3a\x45\xbf\x6a\xd6\x2a\xde\xb4\xf5\x06\xf8\x79\x6d\x0c\x87\x03\x67\x45\xe2\xae\x51\x89\x31\xec\x3e\xd0\x35\xa6\x5d\x09\x22\xe1\x3f\x39\x8a\x2f\xcf\x94\x75\xa3\x59\x84\xfd\x7b\x92\x8e\x41\xf6\xbc\x76\x81\x72\x2f\x91\x2e\xcb\x47\xfb\xb8\xde\x40\x0b\x9c\x67\xc1\x29\x67\xda\xda\x37\x3e\x9a\x1f\x7d\xf1\x77\x26\x1b\xb4\xd6\x74\x2e\x2e\x70\x71\x64\x8c\x68\x6f\x95\x35\x10\xf9\x43\x11\x30\xf4\x6a\x70\x30\x30\x73\x34\x43\xd7\x67\x1f\x3e\xde\x0c\xc1\x76\x69\x7e\xd1\x25\xf5\xd9\x8f\x88\x77\xbd\x81\x37\xdb\x13\x61\x2d\xde\x35\x45\x81\xda\xbc\x24\xa0\x1f\x83\x96\x6c\x6f\x95\x3c\x85\xab\x9d\x9e\xcb\x6f\x15\xba\x2a\xa5\xd0\x23\xeb\x6f\xef\x85\x6e\x39\x66\x01\xcb\xc2\x11\xca\x70\x92\x67\x52\xb6\xe2\x4b\x28\xa6\x9c\xc2\xbe\xcb\xc2\x77\x6d\x0a\xcc\x5a\x40\xad\xdb\xae\xce\x05\xb9\xee\x96\x12\x12\xd6\x7b\xb5\x01\x55\xca\x94\x9f\x44\xa2\x63\x28\xdf\x66\x12\xce\x65\x91\x2c\x42\x5f\x07\xac\xeb\x6c\x8f\x3e\x15\x64\x0d\xd8\xc0\xeb\xa7\x15\x3c\x7b\xf3\xd7\x4f\x8b\xd5\x28\x7c\xa9\x8e\x4d\x9f\x3c\x6a\xbd\x0a\x82\xa5\xf3\x97\xf3\xf4\x62\x1d\x48\xfa\x7b\x4d\x77\x65\xf9\x50\xd1\x30\xd9\xfd\x10\x88\x7b\x77\x45\x26\xe9\x75\xa0\xd2\xa6\x1d\x52\xfd\x30\x18\xcc\x72\x6d\x8c\x77\x59\x5d\xe6\xc1\xef\x8a\xbe\xfa\xcb\x62\x6e\x00\x78\x6b\x46\x18\xc2\x42\xc8\x52\xe1\xb4\x13\x2f\xc6\xf3\x93\xe2\xbf\xd2\x58\x48\xcc\x49\xbc\x47\x3c\xde\x7c\x6b\x32\x99\xf4\x1e\x56\x63\x40\x69\x88\xa8\x5f\xe0\x97\x0c\x4a\x07\xd8\x83\xfd\xd4\x48\x2a\x8f\x72\x04\xd6\xe3\x19\x86\xe9\x07\x93\x74\x11\xe4\xe5\xdf\x7b\xe1\xad\xc0\x77\x18\x27\xd4\xaa\xc0\xdb\xe0\x32\x61\x17\x36\xb6\x71\xec\x21\xfd\x1f\x00\x00\xff\xff\x54\x32\x2c\x46\xc2\x0b\x00\x00")
+var _templatesBasefuncTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x56\x4b\x6f\x9c\x48\x10\x3e\x33\xbf\xa2\x32\x72\x2c\x58\x4d\x3a\xd2\x1e\x6d\xe5\x90\x6c\x1e\xca\x21\x71\x64\x7b\x37\x87\x28\x8a\x18\xa6\xb0\xd1\xb6\x9b\x49\xd3\xd8\xb2\x5a\xfc\xf7\xad\x6a\x1a\x68\x5e\x89\x2f\x8b\x34\x03\x5d\xd4\xe3\xab\x47\x7f\x8d\xb5\x07\xcc\x0b\x85\xb0\xdd\xa7\x15\xe6\xb5\xca\xb6\x4d\xb3\xb1\xf6\x05\x9c\xe4\x70\xf6\x0a\x04\xad\x36\x2f\x5f\xc2\x03\x42\xa6\x31\x35\x08\xe6\x96\x7e\x58\x19\xfa\xbb\x3b\x4a\x96\xe4\xa5\x76\x52\x5d\x2b\x95\xee\x25\x09\xc8\x8d\x29\x4a\xc5\x86\x47\x89\xe4\x18\xf2\x42\xca\xde\x34\x63\x49\xaa\x0e\x70\x57\x66\xff\x0e\xda\xfc\x00\xd6\x8a\x6b\x52\xf9\x9c\xde\x61\xd3\xc4\x06\xfe\x60\x83\x42\xdd\x88\xeb\x04\xec\x06\xe8\x62\x70\x3a\x55\x37\x08\x27\xe2\x83\x2c\xf7\xa9\xfc\xa8\x0a\x43\x38\xa1\xbb\xc8\x87\x5f\xb2\x2e\xaa\x03\xad\x22\x7e\x7c\x28\xcc\x2d\x88\x4b\xcc\xb0\xb8\x47\xcd\x52\x27\x2e\x72\x10\x1f\xab\x2b\xa3\xeb\xcc\x38\x61\x2f\x7d\x5f\xa0\x3c\x54\xad\x2c\x32\x8f\x47\x84\x56\x02\x95\x53\x26\x44\x91\xd7\x6e\x11\x8d\x0d\xe8\x85\x5b\x73\x15\x5d\x62\xe4\xc0\xbf\x0a\x80\xd1\xd5\xc7\xec\x44\x13\xdc\xc1\x23\xa3\xe2\x02\x7d\x49\x35\x95\xc8\xa0\x76\xc1\x1c\xb4\xd7\xfa\x66\x04\x2c\x80\x35\xb7\x70\x01\x9d\x68\x86\x2e\x88\x38\x8a\xcf\x05\x75\x81\x5c\xfb\xdb\x40\x76\x33\x14\x9d\xb1\xc5\xaa\x34\x20\xb8\x7b\x87\xa4\x69\xf8\xce\x8a\xd4\x3f\x6b\x07\x27\x5d\x67\x66\xed\x80\xe0\xf2\xc9\xf2\x98\xf4\xcd\x09\xea\x0b\x93\xcb\xf7\xa5\xbd\xcd\x1c\xa1\xac\x70\xc1\xc8\xda\x2e\xf8\xa4\x08\x33\xfb\x19\xf6\xb9\x64\xb1\x33\xa1\x23\xd7\x1f\xfe\xfb\x8d\xa3\xa0\x67\x97\x58\xd5\xd2\x54\x33\x44\x5f\x53\x65\x56\x20\xaf\x83\xbb\x44\x53\x6b\x55\xbd\xd3\xba\x9c\x16\x9b\xfd\x91\x1c\xf6\x65\x29\x57\x3c\xf9\xfe\x13\xa8\x8a\x89\xe1\xdb\x77\x7e\x1c\xda\x4f\x5b\xfd\xfa\xe2\xed\xc5\x19\xa4\x87\xc3\x68\xa3\x7b\xe3\x88\x59\xe2\xc7\x0e\x8c\x61\x6b\x9f\x63\xeb\x8d\x66\xb5\xc3\x88\x3f\x41\xfc\x5d\xe1\x27\xe2\x05\x4e\x0b\xfe\x84\xa6\x61\x92\x28\x4c\x29\xbe\xa4\x26\xbb\xfd\xab\x54\xf7\xf8\x18\x1b\xe3\x66\x8c\xdc\xed\x1c\x7f\xc4\x89\xed\xfb\x4c\x26\x90\x39\x35\xf1\x4b\x6d\x3f\xe2\x8b\xe0\xb5\x2b\x15\x94\xf9\x98\xa2\xaa\x85\x3e\x9d\x08\x46\x3b\x69\xd1\xc0\x40\x13\x16\xea\xf7\xf0\x55\xbd\x77\xd9\x8f\x84\x3c\x3a\x52\xa2\x6c\x9a\xb6\x4c\xc6\x9c\xf7\x38\xa3\x70\x43\x74\x8a\x7e\xab\x35\x8d\xe2\x9d\x46\x16\x7c\x27\x1b\x0e\x07\xce\xca\x88\xcb\x5a\xc5\xd6\xb2\xfb\x40\x97\xdc\xba\x2d\x41\xa5\xf1\x4b\x8e\xe2\xcb\x33\x65\xdd\x68\x11\x61\xff\x4c\xe5\xb4\x0b\xc4\x15\xad\x51\xee\x1a\xe9\xb2\x7c\xb4\x1f\x29\x9f\x16\x38\xcf\x82\x53\x4e\xc9\xc3\xa9\x8f\xe6\x47\x5f\xfc\x93\xca\x9a\x32\xb1\x9d\x8b\x15\x2e\xa6\x37\xa2\x3d\x55\xce\xa8\xae\xfe\xa5\x08\x18\x7a\x37\x38\x18\x98\x39\x5a\xa0\xeb\xd9\xc2\xc7\x5b\x20\xd8\x2e\xcd\xaf\xba\x30\x7d\xf6\x23\xe2\xa5\x1c\x4f\xf7\x8f\x54\x6e\xf1\xa6\xce\x73\xd4\xf6\x29\x01\xfd\x18\xb4\x64\x7b\xa1\xe4\x63\xb8\xb5\x93\xb9\xfc\x42\xa1\xab\x52\x02\x3d\xb2\xfe\xf4\xde\xea\x96\x63\xb6\x74\xe2\x3b\x42\x19\xde\x64\xd4\xde\x56\xbc\x86\x62\xca\x29\xec\x9b\xc4\x6d\xd7\xa6\xc0\xc8\x3b\x12\xc7\xb8\xae\x2e\x05\x39\xef\x36\x25\xc4\xac\xf7\x8c\xa6\xb9\x90\x09\xdf\xa9\x5f\x1d\x43\xf9\x36\x1b\xe1\x5c\xe6\xf1\x36\xf4\x75\x87\x55\x95\xde\xa0\x4f\x05\x59\x03\x5e\xc1\xf3\xfb\x1d\x3c\x78\xf3\xe7\xf7\xdb\xdd\x28\x7c\xa1\x8e\x75\x9f\x3c\x59\xec\x82\x60\xc9\xf2\xe1\x1c\xad\x92\xf4\xaf\x9a\xee\xca\xf2\xa1\x34\xc3\x64\xf7\x43\x20\xae\xdc\x11\x19\x27\xe7\x81\x4a\x9b\x76\x48\xf5\xc3\x60\x30\xcb\xb5\x31\xde\xa4\x55\x91\x05\xdf\x15\x7d\xf5\x4f\xf2\xa5\x01\xe0\x5d\x33\xc2\x10\x16\x42\xd2\x77\xe0\xb4\x13\x4f\xc6\xf3\x3f\xc5\x7f\xa6\x31\x97\x98\x19\xf1\x16\xf1\xf8\xee\x67\x9d\xca\xb8\xf7\xb0\x1b\x03\x4a\x42\x44\xfd\x06\x7e\xca\xa0\x74\x80\x3d\xd8\x4f\xd4\xcd\x82\xbe\x5b\x43\xb0\x1e\xcf\x30\x4c\xbf\x99\xa4\x55\x90\xeb\xdf\x7b\xe1\xa9\xc0\x67\x18\x27\xd4\xaa\xc0\x8b\xe0\x30\x61\x17\xcd\x86\xbe\xca\x3d\xa4\xff\x00\x54\x32\x2c\x46\xc2\x0b\x00\x00")
 
 func templatesBasefuncTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -105,7 +105,7 @@ func templatesBasefuncTmpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesCallTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8e\x4d\x6a\xc4\x30\x0c\x85\xaf\x22\x42\x16\x2d\x04\x1d\xa0\xd0\x45\x97\xd9\x94\xd2\xdf\xb5\xb1\x95\x54\x90\xb8\x45\x56\x3b\x0c\x42\x77\x1f\x6c\x32\xe3\xd5\x33\xcf\x1f\xef\x93\x59\xa2\x85\x33\xc1\x10\xc3\xb6\x0d\xee\x66\x27\xd6\x6f\xc0\x57\x8a\xc4\xff\x24\xb5\xe1\x05\xf2\x8f\x02\xce\xe5\x4d\xe5\x2f\xaa\xbb\x2a\x9a\x51\x4e\xf5\xf7\x4a\x02\xba\xf7\x16\x9f\xc3\x4e\xed\xf1\xc1\xc9\xfd\xce\x4c\x42\x5e\x09\x46\x9e\x60\xa4\x0d\x1e\x1e\x01\x5f\x82\x84\x9d\x94\xa4\x1c\x96\x91\xdd\x27\xb8\x6d\x74\xef\x97\xb0\xd6\x5b\x54\xf1\x49\xd6\xd2\x35\x6d\xa2\x9a\x1b\x8d\xef\xe7\x5f\xc2\xb9\x7c\x06\xe1\x90\x38\xba\x23\x76\xb6\xc5\xfd\x91\x97\x00\x00\x00\xff\xff\xf4\xce\x7f\xc5\xf9\x00\x00\x00")
+var _templatesCallTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x45\x8e\xcb\x0a\xc2\x40\x0c\x45\x7f\x25\x94\x2e\x14\x4a\x3e\x40\x70\xe1\xd2\x8d\x88\xcf\xf5\x30\x4d\x6b\xa0\x56\x49\xa3\x22\x61\xfe\xdd\x99\x41\x9d\xd5\x0d\x37\x87\x9c\x98\xb5\xd4\xf1\x48\x50\x79\x37\x0c\x55\x08\x66\x2f\xd6\x0b\xe0\x8e\x3c\xf1\x93\x24\x35\xdc\xc1\x78\x53\xc0\xf5\xb4\x57\x79\x78\x0d\x41\x15\xcd\x68\x6c\xd3\xf6\x47\x02\x86\x50\x5a\xdc\xb8\x2b\xe5\xe1\xc8\xb1\x98\x99\x89\x1b\x7b\x82\x9a\x1b\xa8\x69\x80\xc5\x12\x70\xeb\x24\x42\x4a\x32\x7d\x2d\x35\x87\xd0\xc0\xff\x46\xf1\x9e\x85\x35\xfd\x12\xbd\x2b\xe9\xa7\xa2\xc9\x27\x92\x39\xd3\x78\x78\xdf\x29\xe2\x27\x27\xec\x5a\xf6\xf1\x21\x2c\x6c\x8e\xf9\x37\x3f\xf4\xce\x7f\xc5\xf9\x00\x00\x00")
 
 func templatesCallTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -125,7 +125,7 @@ func templatesCallTmpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesFinalsuiteTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\x5f\x6f\xdb\x36\x10\x7f\xb6\x3f\xc5\xd5\xc8\x0a\x69\x70\x59\xa0\x7b\x4b\x90\x87\x76\x6d\x87\x3e\x64\x29\xec\x76\x7d\x28\x8a\x81\x91\x4f\x09\x11\x9a\x52\xc9\x53\x5a\x83\xe0\x77\x1f\x48\x51\x12\x25\x39\x5e\x80\x2d\x0f\x91\x79\x3c\xde\xfd\xee\x0f\xef\x47\x6b\x77\x58\x0a\x85\xb0\x2a\x85\xe2\xd2\x34\x82\x70\xe5\xdc\xd2\xda\x17\x70\x56\xc2\xf9\x25\x30\xe7\x96\xcb\xb2\x51\x05\x58\xcb\x3e\xa1\xa1\x3f\xf9\x1e\x9d\xcb\x08\x7e\x25\x34\x24\xd4\x2d\xfb\x94\x83\x5d\x02\x00\xf8\x53\x9a\xab\x5b\x84\x33\xf6\xa6\x11\x72\x87\xda\x38\x17\xb6\xc2\x2e\x8b\x0b\xaf\x87\x6a\xe7\xdc\x72\xe1\x7f\xfe\x10\x74\x07\x6c\x83\x05\x8a\x07\xd4\x5e\x1a\xc4\xa2\x04\xf6\xc1\x6c\x49\x37\x05\x05\x61\x2f\x7d\x2f\x50\xee\x4c\x2b\x5b\xd0\xa1\x46\x68\x25\x60\x82\x32\xd8\xb0\x31\xa0\x19\x1f\xe8\xcc\x48\x02\xf6\x41\xed\xf0\x67\xdc\xbf\xe2\x3f\xc3\xb2\x53\x6b\x91\x86\x2d\x9f\x85\x10\xff\xa1\xc6\xb8\x3d\x89\x23\x9a\x1d\x56\x3d\xe0\x4e\x34\x09\x3a\xf9\xe9\x43\xf2\x99\xfd\xc8\x35\xdf\x23\xb5\x49\x6b\xe3\x7a\xad\x6f\x47\x51\x25\x31\xcd\x4f\x04\x87\x41\x34\xc3\x9b\x78\x1c\xfb\x0f\x5e\x7c\x21\xa3\x17\xdb\x55\x0b\xac\xf5\xc0\x32\x55\x11\x30\x5f\xf3\x5d\xee\x9c\xff\x7a\x45\xa1\x6e\xad\x6d\x2d\x0c\xea\x47\x0a\x09\xc9\x5f\x8c\x94\xab\xdd\x50\xd6\xa4\x32\x30\xf9\x8b\x15\x6d\x3f\x33\x43\x28\x0d\x1e\x39\x64\x6d\xe7\x7c\x92\x81\xd9\xf9\x19\xf6\xb9\xe4\x68\x59\x52\x43\xa1\x38\xfe\xdf\xbf\x18\x4a\x0a\xb6\x41\xd3\x48\x9a\x1a\xb2\xf6\x0b\x57\xf4\x08\xe4\xc7\xc1\x6d\x90\x1a\xad\xcc\x3b\xad\xab\x69\xb2\xbd\xbd\x77\x5a\xc3\x4d\x55\xc9\x13\x96\xae\xaa\xe2\xde\x00\x80\xbf\xdf\x59\x3e\x75\x80\xdf\x81\x7d\x36\xe8\x95\x3c\x26\xf8\x0d\x46\x47\xd5\x3d\x1e\xae\x1b\xaa\x1b\xba\xe2\x35\xec\x79\xfd\xb5\xed\x8c\x6f\x5f\xbf\x09\x45\xa8\x4b\x5e\xa0\x9d\xc5\x01\xb1\x07\x7d\xcf\x19\x3f\x60\xac\x65\x9b\xea\xc7\x5b\x4e\xdc\xef\x94\x95\x86\xbf\xd7\x40\xe4\xb7\x62\xe6\x5a\x55\xdb\x5f\x96\x29\xae\x57\xe0\xdc\xbe\x2a\xee\x05\x55\xec\x23\xa7\xe2\xee\xf7\x4a\x3d\xe0\x21\x23\x0a\x8d\xbb\x06\x5a\xc7\x08\x6d\xdf\x3c\xe0\x1c\x14\x41\x8d\x9d\xd4\x8e\xd7\xc4\x07\x40\xc4\x42\xbe\xb2\xfc\x62\xb9\x38\x91\xa6\x14\x8e\x17\x66\xe6\x60\x0a\x2e\xa5\x77\xa4\xb0\xa0\x3c\x16\x2e\x2b\xf7\xc4\x42\xf1\xca\x6c\xb5\xfd\x0c\x86\xaa\xda\x00\xdd\x21\x44\x45\x51\xa9\x55\x9e\x8f\x41\x9c\x2a\xcd\x22\x66\xba\x68\x8f\x7f\xe4\x74\xe7\xb3\x78\x5b\xed\x43\xad\x1e\x5e\xb1\xd7\x75\x2d\x0f\xef\x1b\x55\x44\x04\x13\x64\xeb\xa7\x21\xea\x1d\xed\xb0\x44\x9d\xba\x63\x1b\x34\x48\xd9\xa0\x91\xb4\xbf\xc7\xba\x25\x4e\x78\x85\x6a\x7a\x03\x16\x09\x39\xcc\x9a\x35\xc6\x9d\xce\x51\xdf\xfd\xdb\xe6\x26\x34\xc6\x48\xe8\xef\xaa\x94\x28\x9d\x6b\x3b\x88\xe8\x62\x76\x32\x4c\xa0\x4e\x31\xce\x36\xe7\x94\x1f\x6d\xe7\x97\xe0\xbf\x17\xd6\xf6\xad\xba\x20\xb6\x69\x54\x66\xad\x37\x9f\xe8\x5a\xdb\xce\x20\x22\x16\x97\xde\x4b\x6c\x9d\x29\x39\x2e\x8e\x22\xec\x7f\x67\xf9\x18\x64\xcf\x22\x8f\xb0\xe3\x63\xfc\xe8\xe5\xa3\x01\x18\xae\x57\x98\x63\x87\x1a\x83\x32\xd7\xce\x3d\x8f\xde\xe2\xac\x61\x7f\x71\xd9\xa0\x73\x76\x60\xc7\xa3\xb4\xf9\x54\xde\x8c\xf5\x62\xed\x43\xe1\xdc\xdf\x9b\xd6\x10\x4b\xd8\x74\x9d\xd8\x1c\x48\x73\xba\x3c\x42\xac\xb3\x45\xc4\x7a\x84\x0a\xbb\x14\x7d\xd1\x82\xfa\xcc\x8d\x28\xf2\xfc\x12\x9e\xdf\x1c\x08\x0d\x7b\xd3\x94\x25\x6a\xfb\x14\x87\xb1\x85\x5a\x66\xbc\x56\xf2\x90\xce\xe1\x7c\x2e\xbf\x56\x18\x32\x9c\x43\x8f\x8c\x70\x5f\x4b\x4e\x08\x2b\xdd\x12\xc2\x0a\xce\xca\x30\xfd\x87\x1d\x7f\x31\x5b\xf1\x63\x28\xa6\x04\xe0\x6d\x8b\x32\x56\x7c\x0a\xcc\x39\x40\xad\xdb\x8e\x38\xe6\xe4\xa2\xbb\x62\x90\x79\xbd\x67\x97\xa0\x84\xcc\xfd\x97\x88\x75\x74\x12\x5b\x64\x11\x27\xe7\xb6\xca\x52\xe5\x75\x37\x51\xb7\x77\x55\x23\x77\x7e\x16\xec\x6f\x24\xc2\x3a\x31\x91\x8f\x9e\x60\x13\x4e\x3c\xd6\x03\x27\x8b\x3e\x3e\x7e\xaa\xea\x21\x2f\x7f\x54\x34\x5c\x8b\xbe\x0b\xd8\x36\xd0\x56\x96\x5f\x24\x2a\x6d\xdc\x29\x31\x0f\x8f\xc2\x21\xfa\x5e\xff\x64\xe8\x83\x95\x7c\xd6\xd6\x9e\x8a\x5a\xbc\x6f\xb8\x11\x45\xf2\xc0\xec\x4b\x79\x56\x1e\xeb\x26\x7f\x7d\x47\xf1\x0c\x45\x15\x4a\x0a\x85\xd3\xb2\x3e\x29\xb6\xff\x39\xb4\x49\x53\xfe\xa7\x48\xa0\x9b\xe4\xcf\xda\x67\x86\x61\xef\xbe\x37\x5c\xbe\xaf\xe4\x2e\x30\xe9\xb6\xd6\x42\x51\x99\xad\x7e\x79\x58\xad\x87\x68\xf3\xf5\x7c\x73\x0c\x3c\x0e\xe8\xc5\xcb\x97\xf0\xe9\xfa\xed\x35\xd4\x1a\x0b\x61\x10\xb8\x31\xa8\x3d\xe5\x81\x30\x40\x55\x05\x06\x95\x11\x24\x1e\x70\x0d\xb5\x44\x6e\x10\x4a\x21\x65\xa2\x77\x73\x80\x43\xd5\x68\x83\xb2\x5c\x3e\x61\x7c\x4d\x99\xcc\xbf\x49\x5c\xde\x3f\x94\x5e\x24\x84\xe8\xd3\xeb\x96\x6e\xb9\xec\x88\xe2\x9f\x00\x00\x00\xff\xff\x1f\x57\xe3\x13\xb9\x0d\x00\x00")
+var _templatesFinalsuiteTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc5\x19\xdb\x6e\xd4\x38\xf4\x79\xfa\x15\x26\x2a\x28\x59\x0d\x41\x62\xdf\xa8\x78\x80\x42\x59\xa4\x2d\xb0\x9d\x76\x79\x40\x68\xe5\x49\x3c\x9d\xa8\x19\x27\xc4\x4e\xe9\x10\xe5\xdf\xf7\x1c\x5f\x62\xe7\x32\x43\xb4\x5a\xed\x0e\x52\x67\x7c\x7c\x7c\xee\x17\x1f\xd3\x34\x29\xdb\x64\x9c\x91\x00\xfe\xd2\x5c\xd4\x99\x64\x41\xdb\x9e\x34\xcd\x53\x72\xba\x21\x2f\x5e\x92\x18\x56\xb0\xac\x28\xbf\x65\x24\xfe\x50\xc8\xf7\xa2\xc8\xa9\x64\x69\xdb\x3e\x7b\x46\x78\x21\x49\x66\x00\x2f\x48\xd3\xc4\xea\x2c\xe3\x29\x79\x0a\xbf\x36\x35\x4f\x10\x78\xcd\x84\xfc\x40\x77\xac\x6d\x43\x49\x7e\x91\xb0\xca\xf8\x6d\x7c\x1d\x91\xe6\x84\xc0\x07\x99\x69\xfa\xa7\xf1\xeb\x3a\xcb\x53\x56\x09\x38\x4d\xf4\x47\x13\xb5\x78\x40\x1a\x56\x0b\xfc\xf9\x3d\x93\x5b\x12\x5f\xb1\x84\x65\xf7\xac\x42\xa8\x02\x67\x1b\x12\xbf\x17\x2b\x59\xd5\x89\x54\xc0\x0e\x7a\x91\xb1\x3c\x15\x1a\xb6\x90\xfb\x92\x11\x0d\x21\x42\x21\x83\x34\x0b\x83\x6d\xb4\xed\x1d\xb0\x64\x72\x09\xf4\x79\xca\x1e\xcc\xfe\x25\x7d\x50\x4b\x8b\xa6\x25\x55\x5b\x68\x3c\xa5\x3f\xf0\x32\xdb\x03\x3d\x0c\x59\xb7\xea\x04\xb6\xa0\x81\xd2\xde\x4f\x54\x09\x2d\xfb\x89\x56\x60\x5b\xa9\x8d\xa6\xf5\x7a\x55\xdd\xf6\xb4\xf2\x74\x1a\x9f\x50\x0c\x15\x68\x24\xaf\xc7\xb1\xcf\x5f\x71\x41\x47\x1a\x2e\x8d\xf5\x16\x1c\x47\xc1\x42\x0c\x8c\x18\x7d\x9e\x46\x6d\x8b\xdf\x88\x08\x5e\x57\xc1\xe1\x9c\x4b\x26\x1d\x49\xbc\x8f\xd1\x94\x42\x48\x75\x6e\xf5\x3c\x43\x06\x1f\xe3\x51\xfd\x35\x22\xc4\x72\xc1\x26\x0e\x35\x8d\x65\x3e\xb0\xc0\xe8\xfc\x48\xf6\x31\x64\xd2\x2d\x3e\x21\xe5\x1c\xfc\xf3\x13\x42\x9e\xc3\xae\x98\xa8\x73\x29\x46\x12\x7d\xa6\x5c\x1e\x10\xf9\xb0\x70\x57\x4c\xd6\x15\x17\x6f\xab\xaa\x18\x1a\x1b\xe9\x01\x9c\xac\x8b\x22\x9f\xf2\x42\xac\x0e\x5d\x52\x99\x6c\xa7\x4f\xaa\x2d\xb2\x2b\x92\x3b\xcc\x7d\x0f\xfd\x1f\x9b\xf2\x86\x0b\x49\xd7\xb9\xaf\x9a\x05\x39\x3e\x16\x32\x83\xde\x6f\x54\xdc\xf0\x64\xcb\x92\x3b\x96\x8e\xcd\xda\x6d\x91\x1d\x2d\xbf\xe8\x90\xfd\xda\xb3\xc6\x61\xca\x9f\x28\xcf\x12\xf4\x96\x4f\x50\x01\x9d\xa0\x6a\x79\x84\xd6\x25\x20\x0a\xf8\x46\xe4\x30\x1a\xb2\x60\xdf\xc0\x1e\x82\x21\x12\x7a\x9b\xfc\x4a\x7a\x47\xf9\x1d\xdb\x7f\xac\x65\x59\xcb\x4b\x5a\xfa\x0a\x7c\x81\x7f\x19\x87\x50\xdc\xd0\x84\x35\x7d\x6e\xe7\x34\xcf\x85\x8f\xec\x9c\xf7\x50\xb2\x04\xea\xba\xc2\x38\x19\xa6\x91\x8e\x73\x90\x22\x55\x32\xb7\xff\x02\x55\x48\xf1\x29\x87\x5d\x5f\x7f\x5a\x49\xc8\xff\xf7\xdc\xdb\x45\x28\xb8\xaf\x2c\xb8\x60\xc2\xd9\xb7\x07\x9e\x95\x0b\x69\x26\xc6\xd4\x15\xf8\x0d\x95\xd4\x51\xee\x40\x33\xa8\x5e\xd0\x3b\x76\x9e\xc3\x49\x6f\x4f\xad\x1d\x39\xb5\x5c\x31\x89\x9d\x70\x06\xc5\x15\x48\xb8\x2e\x1e\xbc\x1d\x03\x71\x14\x0d\x60\x5e\x51\x79\x97\x17\x6b\x68\xf7\x53\xb2\x63\xe5\xec\xc1\x2d\x68\x76\x91\x71\x1d\x03\x3b\x84\xc0\x5b\x04\x1c\xbd\x2a\xbe\xa3\xf9\x70\x67\x53\x54\xe4\xaf\x25\x91\x12\xb7\x8c\x48\x1a\xb5\xe9\x5a\xdb\x30\xd6\x9f\x43\x6c\xa0\xae\x99\x2c\x20\x8b\xa0\xa2\x9c\x17\xfc\x9e\xed\x43\x29\x55\x9b\x01\x6a\x4b\x93\x35\x8d\x8b\x51\x10\x3a\x51\x68\xf1\x51\x6c\x23\x30\xca\x0f\x08\x2a\x9e\xc3\xe8\xec\x64\x71\x24\xf5\x7c\x71\x10\x18\x8a\xbd\x48\x20\xa4\x91\x11\x87\x00\x8f\x4c\x99\x0d\x37\x3b\xa9\xcb\xe0\x26\x0c\x56\x37\xd0\x05\x8b\x52\x10\xb9\x65\xc4\x20\x66\x05\x0f\xa2\xa8\x2f\xc4\xb1\x74\x5f\x18\x43\x27\xfa\x38\x98\x62\x8b\x56\xbc\x2d\x76\x2a\xff\xef\x9f\xc7\xaf\xca\x32\xdf\x5f\x80\x72\x46\x82\x81\x64\xcb\x79\x12\x75\x8c\xe0\x82\x08\x7d\xd1\x63\x07\x9a\x09\x26\x43\x87\xe1\xc5\x15\xca\x0a\xe9\x24\xd9\x25\xe3\xc3\x7e\xb5\xf0\xae\x72\xe3\x68\xd1\xcb\xb1\x11\x06\xf9\x6f\x79\x6e\xa5\x2c\x0d\x14\xd5\x77\x59\xa0\x61\x78\x08\x9d\xdd\xab\x07\x71\x1c\x0f\xd5\xf2\xc8\x60\x4a\x0a\x66\xd5\x1a\x49\x36\x59\x2f\x2c\xb1\xca\x03\x4f\x89\xa3\x8e\xa1\x3c\x5d\x15\x19\x0a\xe2\x53\x98\x25\x89\x5f\x63\xba\x98\x50\x35\xc6\xe7\x7f\x51\x31\xf6\x43\xe3\x21\x7b\xf5\x63\xe4\x5a\x04\xce\xe2\xe9\xaa\x90\xa5\x20\x4c\x15\xf2\x79\xbe\xc5\x4e\x63\x50\x43\x89\x69\x6e\x0f\x0e\x39\x9b\xd3\xb3\x78\xbb\x8a\x65\x69\xdc\x6a\x48\xdf\xde\x9c\x96\x62\x5b\x48\x83\x1d\xca\x6e\x72\xe9\xce\x2f\xc9\x13\x88\xc3\xf3\x22\x85\x42\x66\x02\x6e\x28\x97\xa1\x8c\x81\x2e\x8b\x8a\x4d\x87\xfa\x58\xa0\x61\x09\x75\x70\xc3\x8e\xbc\x44\x63\xb8\x8a\xda\x3b\xe9\xe9\x3c\x6d\x05\x7f\x22\x50\xde\xa8\xd7\xd2\xdc\x36\x1c\x10\x6f\x9d\x79\xce\xf2\xb6\xd5\xd5\x55\xca\xb3\x2e\xab\x16\xfe\x5d\xda\x22\x9a\x5b\x7a\xdb\x72\xbc\xa4\xc3\x09\xfc\x86\x33\xb6\x1d\xc3\xa4\x14\x5f\xd5\x3c\x6c\x1a\x24\xef\xe1\x02\x59\x75\x9b\x06\x8d\xcc\x12\xb9\x98\xb2\x3a\x1c\xf3\x16\x93\x12\x76\xbf\xa1\x0c\x37\x13\x03\xcf\xe2\xd0\x9c\x77\x68\xd2\x43\x78\xef\x2a\xaf\x5a\x8f\xbd\xa9\x28\x64\x0a\x14\x9e\x18\x6e\xa6\xa1\xc5\x7f\xd2\xbc\xc6\x68\x70\x73\xde\xe4\x00\x38\x77\x02\x34\x1e\x8b\xf5\xc8\xfb\x02\x7d\xae\x09\xc5\xde\x5c\xb8\xf4\x68\xba\xf1\x6f\xb8\x9c\x18\x11\x47\x0b\x23\xeb\x8a\xd1\x9d\x11\xb5\x9b\x9a\x8f\xa0\x4f\xcc\x80\xd6\xa2\x9f\xab\x4c\x76\x86\xee\xcd\x86\x60\xce\x27\xeb\x3d\x78\x16\xe6\xf4\x0d\x64\x4a\x33\x47\x3e\xd3\xc9\xdc\xf5\x98\x04\x4c\x5d\x02\x03\x8d\x03\xfb\x52\xea\xed\x78\x05\x9c\xc9\xa3\x97\x24\x08\xcc\x34\x6e\xda\xf7\xaa\x08\xbb\x1c\x7f\x93\x6d\x36\x0a\x3b\xd4\x1d\x9c\x60\x0b\x97\x6c\x57\xe2\x2b\x04\x09\xb0\xd7\x05\xe4\x74\x03\xe2\xb6\xcb\x8e\x72\xb4\xb4\x37\x81\xd5\xb6\xa8\xf3\xf4\x35\x7b\xbb\x2b\xe5\x3e\x52\x4c\x2a\xd5\x28\x4f\x7a\xe3\xb7\xb9\xe2\x0e\x25\xaf\x58\x59\x15\x69\x9d\xb0\x39\xc2\xeb\xcc\xe9\xed\x1f\xce\x0f\x44\x5f\xdd\x65\x25\x74\xe5\x8e\x89\xee\xcb\xa5\x9a\x21\x1e\x7f\x23\x54\x92\xc7\x22\x70\x4a\xe9\xc0\x5d\xf6\x25\x88\xac\xdb\xa6\x4c\xa2\xdf\x18\x0e\x6b\x3d\xf0\x1b\x56\x0a\x3d\xca\x7f\xe4\xf9\xde\x1f\x1c\xa3\x31\xfc\x23\x67\x4a\x9e\x88\x74\x11\xe5\x44\xa8\xf4\xa8\x65\x1c\x73\x58\xb8\x29\x29\x86\x13\xeb\xa2\x2f\xe0\x48\xb6\xc1\x98\x8a\xe8\x0c\x86\x5a\x55\x0b\x0e\x1a\xa5\x9f\x78\x40\x5a\xd5\x8d\x69\xfa\x5a\x75\xc7\x24\x02\x95\x8e\x72\x38\xb3\x57\x1b\x12\x22\x1e\xc4\x08\xcf\xf2\x08\xbf\xc1\x75\x76\xe8\x36\x51\xe0\x85\xbc\x8f\x3c\x88\x5f\xbc\x83\xed\x70\x06\x5e\x7a\x24\xa2\xde\x43\xd5\xe0\xe5\x60\xaa\xbe\xf4\x33\x7f\x64\xb5\x03\xc9\xa7\xf0\x50\x36\x9f\xb7\x36\xc4\xb1\x2c\x9b\x55\xc5\xfa\x32\x1f\xab\x4b\x07\x84\x0b\xbd\x7a\x05\xd7\x2f\x1c\x3e\xc3\x68\xa9\xfb\xae\x7d\x2e\x51\xae\x3d\xdd\x78\x6f\x0b\x0a\xc1\x2e\xbf\x04\x0e\x35\xf8\x0a\xb7\x46\x7b\x4d\xf8\xa9\x76\xfa\x79\xc9\x77\x02\x70\x99\x48\x11\x87\xf4\xae\x90\xae\x51\xb9\xd0\xc9\x78\x9e\x71\xe6\x85\xe7\x54\x9f\xd0\x56\x79\x4d\x05\x54\x27\xf7\xc2\x78\xc4\x30\x86\xd9\x7f\x60\x8d\x91\x39\x80\xc5\x23\x45\xd4\xbc\xaf\xf4\xa9\x4e\xc4\xfe\xff\x28\xfb\xa4\xc5\x7f\xda\xe3\x62\x7c\xd1\x78\x25\x04\xab\x70\x82\xb2\x5b\xd3\xd0\x89\xd3\xab\x3f\x7e\xf7\xe6\x0a\xdf\x12\x62\x47\x2b\x59\xf3\x4c\xde\xd3\x2a\x43\xad\xd6\xf8\x46\x1e\x9f\xa3\x21\xdd\x29\x1c\x2f\x8e\xa9\x75\xec\x06\x89\x73\x72\x1b\x75\x8f\x30\x4f\xbd\x21\x0d\x7b\x45\x7b\xa2\xfe\x0b\x40\x9f\xfe\x1b\xfe\x67\xa5\x0a\x32\x18\x00\x00")
 
 func templatesFinalsuiteTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -140,12 +140,12 @@ func templatesFinalsuiteTmpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/finalsuite.tmpl", size: 3513, mode: os.FileMode(420), modTime: time.Unix(1666927126, 0)}
+	info := bindataFileInfo{name: "templates/finalsuite.tmpl", size: 6194, mode: os.FileMode(420), modTime: time.Unix(1666927126, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesFunctionTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\x4b\x6f\xdb\x38\x10\x3e\xc7\xbf\x62\x1a\x18\x85\xb4\x75\x89\x6c\xf7\x66\x57\x87\x36\x7d\x20\xc0\xa6\x29\x9c\xa4\x3d\x14\x3d\x30\xd2\xc8\x11\x42\x53\x2a\x35\x4a\x63\x10\xfc\xef\x0b\x92\x92\xad\x97\x9d\xb4\xbb\x58\x1e\xa2\x68\x38\x9c\xf9\xe6\xc9\x91\xb5\x4e\x30\xcd\x24\xc2\x71\x5a\xc9\x98\xb2\x5c\x1e\x1b\x33\xd1\xfa\x25\x4c\x53\x98\x47\xc0\x8c\x99\xfc\x5c\x69\x3d\x65\xd7\x59\x62\x0c\x7b\x93\x24\xc1\x9f\xe1\x64\x95\x83\xe5\x0f\x08\xfe\x20\x2c\x29\x93\x2b\x76\x15\x02\xe8\xc9\x91\x3d\xfa\x33\xa3\x5b\x60\x4b\x8c\x31\xbb\x47\x65\xcc\xe4\xc8\x91\xb3\x14\xd8\x59\x79\x49\xaa\x8a\xc9\x11\xb7\xd4\x0f\x19\x8a\xa4\xf4\xb4\x23\xda\x14\x08\x9e\x02\xa5\x63\xb6\x72\x6b\x6e\xc5\xe5\x0a\x7b\x07\x1a\x31\x82\x80\x9d\xc9\x04\x1f\xea\xfd\x73\xfe\xe0\x5e\x1b\x36\x00\x00\xad\xdd\x96\xb5\x0b\xb4\x66\x57\x9b\x02\xdb\x52\x50\x26\xf5\x6b\xf7\x6d\x8b\xb6\x21\xb5\xfe\xef\xfd\x6b\xed\xb9\xc2\x92\x3e\x73\xc5\xd7\x48\xa8\x1c\x4c\x67\xd4\x1b\xb5\xea\x98\xd4\x32\x68\x78\xc2\x29\x74\xa4\x01\xd8\x96\xc6\xae\x7e\xa7\xc5\x06\xa4\xd6\xa2\x27\x50\x2f\xad\x2d\xb0\x40\xe6\x04\xec\x13\x5f\x63\x12\x1a\x63\x9f\x96\x31\x93\x2b\xad\xbd\x84\x1d\xfb\x48\x14\xa1\xb5\x6a\x4b\xb9\x4c\x76\x31\x6d\x85\x05\x7a\xab\x0e\xa7\x7f\x0c\x04\xa1\x28\x71\xe4\x90\xd6\x8d\xf2\x9e\x07\x06\xe7\x07\xd8\x87\x94\xd1\xb0\xb4\x05\xb9\xe0\xd8\x3f\x8f\x08\x6a\x05\x6c\x89\x65\x25\xa8\x2f\x48\xeb\xaf\x5c\xd2\x1e\xc8\xfb\xc1\x2d\x91\x2a\x25\xcb\xf7\x4a\xe5\x7d\x67\x5b\x79\xef\x95\x82\x9b\x3c\x17\x07\x24\x9d\xe7\xf1\x5d\x09\x00\xf7\x5c\x65\xfc\x46\x60\xcc\x55\xc2\x1c\x71\x89\x71\xae\x92\xbe\x4a\xfc\x01\xec\xba\x44\xcb\x61\x51\xc2\x5f\xd0\x11\x26\xef\x70\x73\x51\x51\x51\xd1\x39\x2f\xfa\x42\x3b\x9b\x23\x98\x6c\x62\x26\x98\xa2\xf2\x7d\x22\x84\x6d\x2a\xb6\xbb\xc9\xbb\x5c\x62\x10\xba\x1d\x13\x84\x93\x23\x22\xdb\x73\x6c\x02\x6b\x7b\xbe\x2a\x44\x16\x73\xc2\x82\xc7\x77\x7c\x85\x6b\x2e\xf9\x0a\x15\xfb\x88\x74\x26\x4b\xe2\x32\xc6\xa0\x5c\x73\x45\xd7\x32\xa3\x53\x7a\x08\xd9\x25\xd2\x12\x05\xa7\xec\x1e\x3f\x73\xba\x0d\x88\xc2\xc9\xd1\x3d\x57\xa0\xf2\x9f\xef\x38\x71\xf8\xf6\xdd\xe7\xfb\xe4\xa8\xf2\x86\x5b\x7d\x6b\x7e\x87\xc1\x9a\x17\xdf\xfc\xde\xf7\x4c\xd2\xec\xc4\xa3\x4a\x73\x05\xd9\x3c\x3a\x59\x40\x06\xaf\xc1\x02\xb7\x71\x3f\xe5\x25\x7e\xaa\xd6\xc6\x2c\x20\x7b\xf1\x02\xf4\x63\x8e\x7d\x05\xc6\xac\xf3\xf8\x2e\xa3\x9c\x7d\xe6\x14\xdf\x9e\xe6\xf2\x1e\x37\x01\x91\xab\xc5\x19\xd0\xac\x76\x93\x6e\xd5\x03\xc4\x8e\x8b\x1d\x64\xee\x66\x80\x5d\x56\xd1\x12\x65\x82\xca\xda\x06\xcf\xed\xbb\xe5\x67\x97\xc4\x09\xd7\x28\xc9\xef\xea\x41\xbd\x59\xbc\x5b\xa6\xf9\xd6\x57\xda\xcc\x46\x58\x3b\x09\x30\xf7\x3e\x3c\x94\x23\x33\x38\x09\x87\x72\xae\x4b\x4c\xac\xda\x0f\x95\x8c\xe7\x8d\x01\xa3\xe1\xe8\x9e\x35\x8b\xb1\x6e\x74\xc8\xef\x96\x18\x94\x9b\x32\xe6\x42\x58\x97\x4a\x8c\x29\xac\x8b\x2e\x48\xd7\xc4\x5c\xe1\xa5\xc1\xf1\xe5\x35\x94\x94\x17\x25\xd0\x2d\x42\xcd\x68\xaf\xc5\x30\x1c\x75\xf7\x53\x6a\xc9\xae\xa3\xd8\x8b\xb2\x79\x69\x03\xb3\xca\xd7\xce\x41\xf7\xaf\xd8\x9b\xa2\x10\x1b\xeb\x81\x1a\x4d\x0f\xe5\xec\x69\xe8\xba\xda\x7c\xed\xb5\x74\xb2\x25\x96\x48\x41\xf8\x48\xf3\xb4\xab\x5d\x53\x10\x59\x21\x84\x0f\x74\x8b\xa2\x40\x65\x4b\xec\x4b\x1d\xe6\x53\xbf\xd1\xa9\xc1\x19\x70\x5a\xc5\xb9\x2b\x4f\x62\x3d\x4e\x6d\x86\xea\x7d\x3b\x9d\xb2\xb7\x55\x26\x92\x61\x57\x76\x5c\xec\xd1\x9e\x6f\x17\x11\x44\xdd\x36\xd5\xa8\x3f\xaf\x6c\x56\xf7\x70\x2a\x4c\x05\xc6\xe4\x1a\xf4\x45\x6a\x5b\xc5\x8e\xf6\x85\x8b\xaa\x26\x86\xec\x4c\x12\xaa\x94\xc7\x18\x84\x2c\xb0\xbd\x69\xbf\x11\xae\xd9\xfe\xbe\x05\x75\x2e\x4d\xd9\x65\x75\x63\x15\x0d\x6f\x16\x7f\x55\xd8\x3b\x4c\x08\x14\xc6\xd4\x0d\x93\x16\x07\x52\xd3\xdd\xd1\xcd\x91\xfa\xf6\x37\x46\xda\xcb\x7f\x1e\x81\x7d\x2e\xb4\xb6\x68\xfa\x29\x4b\x6c\x59\xc9\x40\x6b\xab\xb2\x75\x4a\x6b\xdf\x9f\x88\x58\xfd\x6a\x35\xcf\xc6\x06\x42\xfd\x04\xa3\xa1\x3d\x6e\x4c\xf7\xcd\x1b\x7d\x17\xb4\xe6\xc8\x31\x36\x18\x8e\x0f\xf3\x08\xbc\x29\x36\xe0\xee\x3c\x57\xc6\x3c\xaf\xe1\xd7\x37\xb5\x8f\xbc\x31\xc3\xe6\xd8\x06\xd0\x1f\x42\xf7\xf1\xb6\x11\x1f\x18\x4e\x0f\x1e\xf7\xe9\x63\xdd\x6f\xcc\x1c\x88\x6a\xad\xac\x35\xc8\x0e\xdb\xea\x00\xc0\xb8\xdb\x9f\xce\xb1\x3f\x18\xfb\x03\x3a\xbe\x33\x3e\x14\xec\x14\xf5\x3a\x14\xf4\x2a\x6c\xef\xf8\xd6\x66\xf6\x09\xf2\x55\x65\xb4\x37\x8f\x3c\xeb\x6e\xbc\x9e\x47\xf0\xfc\x66\x43\x58\xb2\xb7\x55\x9a\xa2\xd2\xff\x9d\xc5\xad\x32\x74\xf3\xf7\x94\x5d\x48\xb1\x69\x8f\x7b\xe1\xc8\xc6\x85\x44\x97\x8b\x61\xbf\x2a\x77\x62\x09\xd7\x85\xe0\x84\x70\xac\xfc\x20\x7a\x0c\xd3\xd4\x4d\x9d\xbb\x1d\x7b\x91\x78\xf2\xaf\x23\x9e\x1e\x9a\x49\x9b\x95\xa5\xbe\xac\x86\x56\x19\x03\xa8\x94\xaf\xbb\x31\x40\x8b\xe6\x3e\x85\xc0\xf2\x3d\x8b\x40\x66\x22\xb4\x4f\x22\xd6\x8c\xbc\xfb\x0b\x11\x5c\xcb\xdf\x32\x46\xf0\x6c\xf7\x36\x7a\xe8\x57\x3d\xd0\xa4\xdc\xfe\x49\xbf\xe7\xaf\xa7\xe4\x9c\x73\xd7\xc7\x9c\x76\x2d\x69\x9b\x83\xec\xd2\xcd\x3b\x41\xb8\x68\xb1\x78\x6f\xb4\x3f\x29\xf6\xe7\xa5\x28\xb1\x86\xf1\x96\x97\x59\x3c\xf2\xb1\x34\x40\x62\x03\x97\x8e\xa5\x9d\xed\x88\x1d\x98\xbb\x08\x66\x52\x64\x12\xfb\x31\xfc\x6d\xc8\xff\x1f\xc4\x67\xcd\xf5\xfe\x0e\xb1\x78\xff\xa3\xe2\x22\xd8\x4a\x98\x75\x31\x8f\xb5\x26\x78\x52\xa7\xec\x9a\x1e\xed\xfc\xf2\xaf\x73\x92\xc8\xcf\x17\x10\xb5\x66\x7c\xd6\x19\xda\x07\x67\x9a\xaf\x9c\xa8\xfd\x5d\xc0\xda\x53\xf7\xbe\xe2\x7f\x74\xa0\xed\xc0\xea\x8c\xfa\x51\x07\xde\xf8\x97\xe2\xe3\xe6\x36\x9f\x6c\x11\xf0\xa2\x40\x99\x04\x35\x61\xd6\x9f\xf1\x44\x85\x57\x79\x5d\x3b\xdd\x11\x6f\x74\x9e\x1b\x4e\x6f\x9d\x91\x0b\x8c\x01\x1b\x7e\x8f\x0b\x5e\xb6\x90\x99\xd0\x7e\xd6\x82\x4f\x4c\x81\xb2\x41\x14\xc2\xeb\x08\x4e\x76\x09\xa3\x5c\x9e\x4e\x76\xf1\x4d\x30\x16\x7f\xe7\xf1\x1d\x2a\x66\x1f\xf5\x15\x67\xa9\xf6\xf8\xb7\x63\xf7\x73\x1b\xfb\x50\x09\xe1\x7e\x92\x31\xe6\xf8\x3b\x44\x8d\x03\x26\xad\x38\x9e\xf3\x62\x1f\x77\xcd\xd1\xd7\x77\x2d\x85\xd7\x68\x02\x0a\x27\x75\x25\xfc\x13\x00\x00\xff\xff\xeb\x30\xcc\xf6\xf5\x13\x00\x00")
+var _templatesFunctionTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc5\x1b\x5d\x73\xdb\x36\xf2\xd9\xfe\x15\xb0\xcf\xe7\x21\x1b\x99\xcd\xf5\xde\xec\xea\x66\x52\x37\x4e\x33\xd3\x34\x1e\x7f\xb4\x0f\x9e\x4c\x87\x16\x21\x99\x63\x8a\x64\x08\xc8\x8e\x8f\xa3\xff\x7e\xbb\x0b\x90\x04\x48\x90\xa2\xd4\xe4\xca\x07\x53\x5c\x00\xbb\x0b\xec\x62\xbf\x00\x97\x65\xc4\xe7\x71\xca\xd9\xe1\x7c\x95\xce\x64\x9c\xa5\x87\xeb\xf5\x7e\x59\x9e\xb0\xa3\x39\x3b\x9d\xb2\x00\xbe\x9e\x17\x65\x79\x14\xdc\xc6\xd1\x7a\x1d\xbc\x89\x22\xef\x5f\xfe\xfe\x22\x63\xd8\xdf\x93\xec\x3b\xc9\x85\x8c\xd3\x45\x70\xe3\x33\x56\xee\xef\xe1\xd0\xe7\x58\x3e\xb0\xe0\x8a\xcf\x78\xfc\xc4\x0b\xc0\xb0\x47\xe0\x78\xce\x82\xf7\xe2\x5a\x16\xab\x99\x24\x60\x0d\xbd\x88\x79\x12\x09\x05\xdb\x93\x2f\x39\x67\x0a\xc2\x04\x75\x46\xbc\xba\x77\x11\xa6\x0b\xde\x1a\x50\xa1\x49\x24\xe0\x4f\x23\xfe\x45\xb7\x7f\x08\xbf\xd0\x67\xd5\x8d\xc1\x53\x96\xd4\x84\xf3\x82\xdf\xc1\x0d\xd0\x32\xb1\xf0\x34\xd2\x9f\xf6\x57\xcd\x6d\x05\x32\x7e\xb7\x7e\xe2\x7c\x6e\x60\x4d\x2e\xc3\x22\x5c\x72\xc9\x0b\x62\x93\x26\xf5\xa6\x58\x58\x53\x32\x26\xd4\x1d\x41\x04\x09\xd4\x61\xd6\xa0\x68\xd3\x27\x2a\x28\x10\x4d\xa5\xdc\x67\xfa\x29\x4b\x64\xcc\x4b\x33\x58\xa3\xdf\x80\x4a\xe4\xaf\xd7\xf8\xc6\x8e\x20\xbd\xb2\x54\x18\x9a\xee\x0e\x29\x32\xe3\xd1\x33\x0d\xd3\xa8\x91\xa9\x21\x16\xd6\x7a\xb4\x38\xd5\xab\x83\x88\x27\x82\x3b\x06\x95\x65\x45\xbc\xb5\x02\x9d\xf1\x1d\xde\xbb\x10\xa7\x58\x4c\x44\x24\x1c\xfc\xb3\x01\x91\x21\xb0\x2b\x2e\x56\x89\x14\x1d\x8e\xfe\x08\x53\xd9\xc3\x72\x3f\x73\x57\x5c\xae\x8a\x54\xbc\x2d\x8a\xac\xbd\xd8\x88\x0f\xe0\xec\x3e\xcb\x12\x97\x14\x02\x1a\xf4\x21\x94\xb3\x07\xf7\x48\x6a\x62\xcb\x6c\xf6\x88\xbb\xd6\xe8\xbe\xf3\x52\xde\xa6\x42\x86\xf7\x89\x39\xb5\x0a\xd4\xd0\xa9\x20\x23\xf0\xfd\x12\x8a\xdb\x74\xf6\xc0\x67\x8f\x3c\xea\x2e\x6b\xdd\xc4\x96\x61\x7e\xa7\x54\xf6\x93\xb5\x1a\xfd\x98\x2f\xc3\x34\x9e\xa1\xb4\x4c\x84\x04\x6c\x18\xa5\xcf\x01\x5c\x1f\xa0\xa3\x80\xf7\x53\x58\xc4\x38\xa1\x59\x58\x44\x01\x01\x41\x43\xb3\x22\x6a\x13\xe5\x9f\x61\x85\x04\xc7\x1e\x28\x7f\xf6\x6f\x66\x21\x4b\x1f\xf9\xcb\xc7\x95\xcc\x57\xf2\x43\x98\xb7\x91\x5a\x8d\x16\x07\xe7\x61\x92\x88\x2e\x0f\x08\x76\xb0\x81\x9b\x4a\x69\x3d\x70\x10\x11\xb3\xad\x19\x6d\x89\xcf\x2d\xb7\x9b\x9b\xcb\x6b\x09\x66\xe0\x7d\x6a\xb4\x22\x14\xa4\x98\x67\xa9\xe0\xa2\x59\x66\x0b\x3c\x6a\x4b\x44\xb1\xe8\x62\x27\xf0\xcf\xa1\x0c\x1b\xcc\x35\x68\x04\xd6\x8b\xf0\x91\x9f\x27\x30\xd2\x68\xa3\xef\x06\x1d\x7d\x5e\x73\x89\x8e\x6d\x04\xc6\x6b\xe0\xf0\x3e\xfb\x62\xb4\x68\x48\x83\x51\x03\xc6\xd9\x96\x77\x49\x76\x1f\x26\xc2\xc5\x3b\x1a\x50\x0b\x5e\x81\x46\xdb\x9a\xc6\x71\x80\xe7\x07\xd3\x4a\x7e\xdc\x67\xb5\xab\x30\xbd\xfd\xcf\x59\xca\x3d\x9f\x5a\xd6\xf0\xde\x93\x12\x63\x02\x74\x30\x25\x8e\x5f\xe5\x49\x3c\x0b\x25\xcf\xc3\xd9\x63\xb8\xe0\xcb\x30\x85\xbf\x45\xf0\x8e\xcb\xf7\xb8\xf3\xd3\x19\xf7\xc4\x32\x2c\xe4\x6d\x1a\xcb\x73\xf9\xc5\x0f\x60\x49\xaf\x78\x12\x4a\xb0\xe9\x97\xa1\x7c\xf0\xa4\x04\xa4\xa0\x80\xac\xc8\x9e\x49\xa0\x77\x9f\xd4\xe6\xde\xdf\xfb\xfe\x7b\x26\x1f\x38\x9b\x91\x8e\x66\x73\xfa\x40\xc2\x60\x03\xaa\x48\x65\xc2\x44\x06\xf0\x50\x32\x1e\x82\x79\x03\x1c\xec\x31\xcd\x9e\x05\x7b\x7e\x88\xe1\x7b\x96\x81\xe7\x00\x86\x58\xc1\xf3\xac\x90\x82\x85\x05\x6c\x08\x78\x17\xab\x54\xec\xef\x29\xcc\x30\x9d\xd7\x9a\x05\x80\x02\xfd\x46\x07\x42\xc1\xaf\xa8\xa7\xd3\x92\x54\x0c\xe6\x64\x49\x44\x2c\x39\x72\x49\x8c\xcc\x60\xe4\x84\xf1\x65\x2e\x5f\x70\x5c\x2c\x59\x94\xc1\x36\x40\xdf\x4b\xbd\x15\x3d\xfa\x79\x0d\xe3\x84\x31\x6b\x43\x40\x2b\x65\x3f\x90\xc3\x25\x68\xac\x67\x58\xbe\x38\x95\x93\xd7\x4a\x2c\xbd\x1e\x80\xd7\x00\x90\x31\xe0\x00\xf5\x78\x6b\x80\x74\x2f\x5b\x45\x9a\x1d\x62\xd8\xdf\x39\x7d\x20\x8a\xbb\x4f\xa1\x5c\xcc\x32\x12\xad\x54\x9d\xca\xfd\x96\xea\x5a\x43\xa9\xed\x02\x16\xf3\x14\xba\xe4\xc0\xba\x9c\xb3\xc3\x7f\x7e\x3e\x84\x6e\x00\x5c\xaf\x27\x4c\x99\x7a\x6c\x0e\xd4\x4f\x04\x9e\x67\x11\xef\x8c\x40\x20\x36\x5e\x3e\x2e\x30\x6e\xe9\xb4\x6b\xb8\xee\x82\xda\xe5\xea\x82\x70\xec\x02\x4b\x41\x44\x15\xd6\xf5\xc4\xb1\x18\xea\xaf\x96\x72\x12\x42\x4c\x05\x53\x8c\xb2\x25\x89\xb7\x52\x31\xd0\x21\x50\x48\x92\x3a\x2a\x8e\xa5\x9c\x38\x8e\x16\x0f\x14\xf0\x84\xb4\x2b\x96\x84\xb3\xc0\xfe\xd0\x95\x24\x5b\x0b\x55\xef\xaa\x2e\x23\xf3\xac\x60\xf1\xe9\xf4\xf5\x19\x8b\xd9\x8f\x0c\x37\x27\xea\x20\xaa\xe7\x6f\xab\xe5\x7a\x5d\x96\xa6\xc8\xd8\x2b\x96\xf0\xd4\x53\x42\xf3\x75\x58\x07\x23\x5f\xbd\x62\xa5\xc3\x12\xb6\x84\x85\x7a\xa9\x58\xfe\xae\x23\xea\xba\x13\xaa\x34\xfb\xcf\xb4\xcb\x89\x41\xa1\x56\x1c\x36\x65\xc7\x8a\x99\xbb\xf8\xa4\x33\xe2\x93\x35\x40\xaf\xcb\xcd\xcd\x84\x65\xa4\xf7\x1a\x70\x47\x08\x48\x67\xec\x01\xc0\xc9\x01\xf4\x2c\x3b\x91\x23\x70\x0e\xa6\x7b\xc5\xad\x06\x3b\x32\x92\xc8\x5a\x4d\xd1\x15\x55\xd9\xc1\x72\x6b\x30\xc1\x01\x83\x62\xed\x9a\x44\xa8\x2d\x65\xbf\xf9\x1d\x36\xcd\xae\xc0\xe1\x07\x08\x1c\x50\xb1\x62\x99\x81\xf9\x81\x9d\x7b\x9e\xa5\x4f\xfc\xc5\xd3\x0c\x80\x92\x4d\xb4\x01\x2f\x8d\x48\x1a\xe7\x0f\xbd\x82\xc1\xce\x36\x79\x7c\x90\xd0\x15\x80\x95\xc1\x60\xc7\x8d\xf3\x92\x60\xe4\x97\x3c\x95\xaa\xb5\xbb\xde\xc8\x6f\xdd\xe9\xb4\xb6\x67\xa5\xde\x58\x76\x57\x2b\xc0\x39\x55\xc6\x6d\x28\x06\x9a\xb0\xd7\x7e\x17\x0f\xac\x12\x05\x35\xca\xbc\xe8\x09\x38\xed\xe4\xc4\xc9\x2e\x05\x3f\x6e\xea\x66\x08\xe4\x26\xde\xbb\x81\xea\x7c\x27\x24\xb3\x46\xda\xe1\x1e\xde\x5d\xfe\xf5\x99\x4b\x0b\x51\x09\x8f\x9c\x31\x8b\x61\x9e\xe6\xd0\xcc\x66\x09\x45\xa9\xda\x57\x66\xf2\x41\x3b\x77\xb4\x46\x02\xdc\x0d\xa6\x00\xe4\x45\x63\xd0\xdc\xa6\x41\xf7\xcf\x8b\x6c\xc6\x85\xd0\x3e\x95\xbf\xb0\xe7\x30\x96\x16\x2d\x15\x2e\x34\xc1\x3d\xb8\x47\x1e\x26\xc4\x95\xe7\x6f\xd4\xfd\x81\xe8\xd8\x54\x72\x04\x7a\xe2\x45\xa0\x65\x45\xfd\x4d\xf9\x4c\xfa\x3a\x37\xf2\xe6\x4b\xa9\x9c\xd8\xdc\x3b\xbc\xbe\x85\xd4\x35\xcb\x05\x31\xaf\x3b\x62\xf5\xc2\xf7\x9d\xba\x3d\x26\x30\xc7\x67\x6f\xa6\x50\xa1\xa3\xc0\x5d\xb0\xc8\x96\xa4\x8d\x4f\x3f\x04\x6f\xf2\x3c\x79\x41\x75\xd3\xdc\xb4\xb8\x9c\x8c\xe3\xce\xa6\xa6\xd6\xd4\xa0\x89\x6e\x90\xcb\x51\xab\x69\x86\x56\x60\x89\xd0\xe6\xf1\x2f\x40\x2f\xc9\x21\x06\x83\x48\xeb\x77\xad\xd5\xe7\xaa\xc1\x0a\xc5\x26\xcc\xb4\xef\xad\x9e\xe5\xba\x4b\x5e\xb9\xf7\xa3\xe0\xa7\x55\x9c\x44\xdd\xe4\x59\x85\xa2\x1b\x53\xf3\x8d\x7b\x07\x1a\xb4\xdb\x98\xb2\x34\x4e\x1c\xa6\x9d\xec\xb6\xb5\x61\x2b\xee\x3f\xac\xd0\x02\xb5\xa6\x59\xf0\x39\x74\x93\x14\x1a\x7f\x9c\x63\xc0\xd9\xc0\x7e\x0f\x93\x95\x06\xfa\xc1\x7b\x98\x7a\x31\x0f\x21\x64\xf5\x03\x0f\x7d\xb1\x3f\xe0\x3c\x7a\x8a\x16\x7f\x13\x6f\xfd\xeb\x5c\x49\xad\x9d\xfd\xed\x26\xb2\xa3\x9e\x6c\x4f\x7b\x44\x3b\xe9\x9b\x1a\xc9\x19\xc5\x4d\x56\x73\x4b\xc1\x1f\xa4\xcc\x35\x5e\x8a\x8a\x0c\xc7\x83\x30\x1c\xea\xb5\x29\x04\x41\xe0\x3b\xec\x93\x81\x0a\xd3\x38\xc1\xb7\xb1\x4c\x47\x7d\x29\xa7\x0a\x4f\x9a\x26\x8b\x4b\x35\x3f\x1a\xe9\xb9\x58\x32\x07\xee\xc2\x53\x9f\xf1\x87\x15\x51\x79\x6b\x87\x17\x6d\x94\xad\xde\xe4\x1e\x2c\xbe\x2f\x0a\xce\xff\xab\x30\x7b\x15\x2e\x17\xff\x34\x72\x17\xc6\xbb\x79\xb1\x66\xbb\x4a\x8f\x3b\x8c\xeb\x06\xcf\x88\xdc\xff\xf1\x74\xd8\xa0\x7a\x9b\x3e\x61\xf8\xde\xd7\x7c\x11\x27\x1c\xf4\xdc\x66\x52\x68\x62\xe6\xd4\xdf\xe2\x8e\xaa\x88\x41\x58\xd4\xf0\xe4\x5a\x00\x8d\xa1\x7f\x09\x74\xa9\xa5\xe6\x83\x47\xc8\x89\xdb\x57\x6b\x64\xbc\xd7\x53\xcf\x1e\x68\xd7\x62\x13\x4f\x9f\xe2\x22\x4b\x31\xaa\xa2\x52\x2b\xc2\x9e\xb3\xe2\x11\x82\x1b\x16\xc5\x05\x18\x8a\xac\x78\x19\xef\xb2\x21\xa4\x12\x71\xc4\xab\x79\xfb\xdb\x49\xb3\x5b\x95\xc0\x67\xa1\xa0\xf6\xb6\x4d\xc3\x5c\x3c\x64\x52\x8f\xf0\x64\x59\x56\x96\xa8\x46\x32\x61\xc7\x75\xfe\xa5\x1d\xb6\x6b\xe9\x35\x7a\x74\x8b\x30\x57\xd7\xe2\x77\x30\xbb\xbd\x4d\xab\x70\xa2\xcd\x9f\xa2\x0f\x7a\x08\x0a\xd0\xd4\x52\x46\xae\xca\x36\x50\xa5\x1d\xab\x7b\xd9\x2a\x44\xda\x95\xca\x02\xf3\xc9\x64\xbd\xd6\x75\x16\x79\x36\x10\xca\x50\xe9\xbd\x1a\xa2\xf3\x94\xf5\x3a\xc5\xb4\x04\xc6\xe2\x1b\x46\x23\x37\xed\x10\x47\x06\x57\xab\xd4\x53\x69\xa3\x31\x0a\x48\x91\x47\x83\x95\xd0\x9f\x48\x79\xe2\x3a\xe7\x29\x47\x4c\xda\x3a\x45\x38\xea\x3b\x46\x68\x2f\x81\x71\x3c\xc4\x7a\x9e\xd6\xa9\x00\x95\x36\xaa\x32\x27\x8d\x0f\x81\xc8\xb1\x66\x5f\x17\xc5\x94\x3b\x85\xcf\x01\xac\xdd\xb3\x25\x36\xf4\x6c\x3e\x73\x62\x1b\x1e\xe0\x4d\x55\x2c\x4e\x51\xfb\x14\xd5\xc0\x38\x9f\x9a\x6c\x66\xc0\xbd\xec\xe3\x7b\xf4\x0b\xa3\x5f\xa0\xfd\x2d\xd5\x4e\xbc\xe6\xe1\xb2\x67\xfd\xba\x11\xc7\x30\x5a\x77\x89\xb2\xe1\xbf\x65\x0f\xac\xdd\xe6\x2c\xfc\x57\x0f\x55\xdf\x44\x70\x83\xe9\xdf\x21\xd6\x25\x2e\x56\x49\xa2\xc4\x71\xe8\x6f\x39\x6f\xa2\xe6\xac\xb2\x77\xd2\x34\xb3\x1a\xa4\xca\x9b\x58\x4b\xc2\x2d\xa2\x5c\xc3\x52\x9d\x9e\x88\x0e\x06\xa3\xd2\x0d\xf9\x01\xd1\xa1\xea\xe9\x6e\x12\xea\x3d\x04\xeb\xee\xc7\x3f\x8a\x58\xf6\x6e\x5b\xd5\xb5\x39\xa4\x84\xcd\x78\x7c\xff\x02\x96\x02\xf2\x84\x39\x48\xae\xfc\x7a\x0a\x36\x8f\x0b\x2c\x1c\x25\x09\x12\xa1\xda\x6d\xb7\xea\x83\xd0\x57\xaf\x7a\xe6\x82\xf6\xd2\x53\xd9\xf4\xc7\x34\x79\x31\x8f\xdb\x7c\x47\xc3\xc7\x94\x93\xd1\xf0\x59\xef\x12\x49\xbe\xcc\x13\x08\xeb\xd9\x61\xa1\x4e\xac\x20\x12\x99\x53\x25\xbe\x69\x41\x9e\x14\x78\x17\xa5\x1a\x38\x13\x74\xcc\xae\x3b\x31\x00\xf5\x1e\x0d\x56\x0f\x2f\x74\x7d\x78\x14\xd3\x9b\xed\x0a\x30\x43\x06\xb9\x87\x23\xbd\xd2\x0d\x5b\x3e\xac\xd8\x20\x0f\x67\x55\x42\xcf\x3c\xec\x77\x40\x99\xa1\x8f\x6f\x30\x9b\xd5\xd1\x68\x39\x68\x2c\x8d\x8e\x53\x76\xd0\x7c\xed\x6f\x67\x14\x49\x26\x1b\x17\xb4\x41\xaf\x8e\x5d\xa7\x56\x2d\x3e\xa0\x77\x2b\x13\x84\x0e\xfe\x37\x31\xc5\xfd\xa7\xd4\x3b\xec\x74\x92\xec\xbb\x4c\x36\x7e\xb7\xde\xf9\x75\x05\xf4\xcc\xe8\x72\xa0\xc3\xaa\xe6\x38\xbc\x7f\x8e\xd5\x61\xe5\x7b\xf1\x53\x28\xc0\x6c\x77\x0f\xfa\x5d\x3a\x76\x34\x77\x6d\x59\x74\xfb\x16\x9b\x8d\x56\xc5\x69\x12\xa7\xbc\xad\x57\x3b\xb3\xfc\xff\x63\xf1\xa0\x2a\x0c\xfc\xcc\x79\xfe\xf6\xf3\x2a\x4c\xbc\x1a\xc3\xc4\xe6\xd9\x1f\x62\x7a\x30\x1c\xb0\xa7\x3e\x6d\xd6\x65\xe4\x3e\x19\x61\xd1\x86\xcf\xf9\x5b\xfe\x52\x99\x55\x7d\xd6\x52\x1d\x00\x82\x27\x48\x40\x57\xd4\x79\x1a\xdd\x37\x90\x19\xf4\xa4\xb2\x75\x05\x15\x82\x17\x92\x47\x8e\xe2\x51\xd0\x5c\x25\x98\xb6\x2f\x13\x94\x5f\x69\x2f\xd5\x9e\xa6\xde\x54\x7e\xfd\x5d\x6b\xb7\xdf\x6f\x3e\x0f\xac\x12\xd2\x55\xb6\x4a\xa3\x9b\x22\xce\x45\x4f\xf9\xa8\x2a\x15\x1d\xdb\x5a\x10\xbc\x4d\xf8\xd2\xf3\xfd\x01\xd3\x68\x2e\xc7\xdd\x61\x33\xf6\xf0\x13\x66\x44\x45\xeb\xfc\xe4\xdb\x04\x8d\x7f\x31\xac\x2a\xa8\x4a\xef\x38\x2a\xc6\x71\x2a\x96\x62\xf7\x7c\x0e\xa9\xa3\xd6\x28\x15\x7b\x61\x5f\x04\x2c\x59\xb8\x08\xe3\xd4\x19\x6e\xa9\xc3\x8e\x40\x9d\x03\xa8\x88\xcb\x16\xc0\xf6\xf1\x97\x76\xea\xc8\xc2\x40\x94\xa8\xee\x4a\x29\xad\x8f\x62\x8c\xa3\x60\x0a\xf2\x99\xf3\x54\xcd\x61\xd5\x54\xed\x29\x7e\xc4\x73\xed\x41\xb5\x5f\x55\x37\x73\xcc\x24\xbd\xba\x9c\xe3\xd0\x7a\x3c\x71\x2c\x90\x4b\x3a\x1e\x3f\xd3\xbf\xd5\xd9\x63\xc5\xbd\x86\x5a\xe7\x8a\xbd\x2b\xf8\x1c\xa7\x91\xe7\xf6\x70\x3a\x68\xeb\xd5\xa9\x71\xf9\xe3\x96\x39\xe4\xb7\xcb\x23\x77\xca\x25\xbf\x4e\x3e\xf9\x15\x72\xca\x71\x6e\x62\x7c\xaf\x5d\x73\xd3\xcd\xad\xa3\x33\x99\x2d\x63\x9c\x1d\x33\x9a\xaf\x31\xa5\xbf\x23\x8a\xaf\x0b\x97\x6d\x9a\x3d\x83\xfe\x64\x3b\xd1\xf8\x56\x29\xd4\xe8\x85\x1d\xb1\x82\xda\xfa\x2e\x29\x66\x37\x8d\xaf\xe8\x58\x5f\x08\xd8\x83\x45\xa0\xba\x73\x21\xc2\x05\x9f\x6c\x0c\x3d\xdc\x99\x41\x0b\x82\x07\x1e\x60\x92\xbd\xe1\x7c\x40\x0b\x4d\x29\x8b\x2b\xb9\xea\xe4\xb7\x03\xd2\xe4\x3d\x59\xd0\xf8\x2d\xb8\x39\x2e\xaa\xfc\x0f\xdd\x1e\x37\xe3\x8c\x56\xe4\x3a\xd1\x26\xb8\xd9\xa7\xae\x14\xa3\x2a\x54\xd6\xd1\xa9\xb3\x7c\xbc\x79\x1e\x6b\x77\x78\xa8\x5d\xe5\xb4\xe6\x7a\x17\x1f\xbf\xb9\xf6\x04\x21\xd7\x04\x04\x00\x7b\x76\xfb\x52\x94\x3a\xc8\x36\xa9\xe0\xfe\xc9\x8b\x2c\x5a\xcd\xf8\xe1\x00\x45\x36\xad\x7f\x82\xe2\xe8\x01\xde\x36\x51\x0c\x2c\x91\xba\x3b\xdb\x60\x72\x53\x53\x37\xdb\xa6\x2c\xcc\x73\xc0\xe4\x35\xb0\x49\xc3\x02\x7e\x6f\x49\x5c\xdd\xc4\x9d\x9a\xe1\x85\x75\xf9\xa4\x1b\xfb\xe8\x6b\x74\x53\x2b\x24\x31\x6f\x8f\x0c\x2c\xf1\xf0\x5d\x01\x8b\x2d\xeb\xca\xca\xd4\x62\xcf\x7d\xa3\xd7\x31\x31\xba\x93\x32\x6d\x4d\xed\xdc\x59\xeb\xb2\xad\xf7\x60\x94\xfc\x17\x08\x6c\x0a\x64\x7b\xcf\x4a\x0d\xba\xcd\x6d\xdd\xa9\x7d\x12\x7a\x59\xf0\x24\x0b\x23\x1e\x79\x3b\x85\xd1\xbd\x77\x6f\x36\xdf\x21\xa8\x62\xdc\x3f\x27\x8c\xfe\xc9\x45\x59\x32\x7d\xd1\xb1\x1c\x4a\xe9\x8d\xa5\x3b\xa7\xbb\x7f\xde\x9c\xee\xa5\xf9\x1b\x0a\x50\xf5\x4d\x36\x75\x8b\x8d\x2c\xff\x96\x55\xf9\x31\x06\xac\x7f\xd9\xaa\x0b\xb6\xf5\x86\xd4\x80\x49\xfb\xbe\x02\xc4\xb7\x37\x99\x36\xb5\x76\xba\xe3\xbc\x9b\xd0\x15\x1d\xa5\x27\x0d\x19\x72\x97\x9d\x4b\xb5\x10\x91\x16\x02\x2f\x48\x55\x55\x5d\xbc\xf5\xb9\xc2\xbb\x63\x2a\x8b\x3b\x69\x5a\x1c\x97\x51\xac\xc3\x34\xd8\x95\x0c\x6b\x1e\x6a\xea\xec\xc4\xbc\x6f\xe7\xe3\x3d\x67\x2d\x3a\xbc\x16\xa9\x27\xed\xb3\x1f\x21\xaf\x69\x24\x56\x90\xaf\x34\x6e\x7d\x46\x7c\x96\xfc\x8a\xf7\x39\x8b\xe0\xd7\xe6\x24\x1d\xa1\x38\x1c\x53\xe5\x13\xd6\xd8\x69\xa6\x33\x66\x8d\x7d\xdf\x30\x3b\xb0\xe1\xfb\x7a\xeb\x1e\xfb\xc3\x7e\x03\x69\x12\x50\xf4\xe1\x69\x2c\xab\xe3\xee\x28\x0e\xc7\xf5\xee\x65\x19\x2f\x38\xb7\x66\x7c\x9b\xea\xdb\x03\x6b\x0f\x12\x5c\xed\x5c\xff\x07\xf4\xa0\x91\x23\x28\x36\x00\x00")
 
 func templatesFunctionTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -160,12 +160,12 @@ func templatesFunctionTmpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/function.tmpl", size: 5109, mode: os.FileMode(420), modTime: time.Unix(1666927126, 0)}
+	info := bindataFileInfo{name: "templates/function.tmpl", size: 13864, mode: os.FileMode(420), modTime: time.Unix(1666927126, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesHeaderTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\xcc\x31\x0e\xc2\x30\x0c\x85\xe1\xdd\xa7\xb0\x3a\xc1\x40\x2e\xc1\xc4\x82\xb8\x82\x45\x1e\x6d\x85\xe2\x56\x21\x9b\xf5\xee\x8e\x94\x08\xba\xfd\xb2\xf5\xbe\x88\x8c\xd7\xea\xd0\x69\x81\x65\xd4\x89\x94\x88\x6a\x3e\x43\xd3\x75\x2b\x05\xde\x3e\x64\x44\xea\x0f\x78\xd6\x0b\x29\xbb\x3d\xdf\x36\x43\x23\xd2\x63\x24\x29\xb2\x96\x7d\xab\x4d\x4f\x87\x70\xeb\x97\x01\xdc\xad\x80\x1c\x93\xb6\xfc\x38\x52\xce\xf2\xcf\x6f\x00\x00\x00\xff\xff\x99\x8d\xcc\xfd\x8f\x00\x00\x00")
+var _templatesHeaderTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xab\xae\x4e\x49\x4d\xcb\xcc\x4b\x55\x50\xca\x48\x4d\x4c\x49\x2d\x52\xaa\xad\xe5\xaa\xae\x2e\x4a\xcc\x4b\x4f\x55\xd0\x73\xce\xcf\xcd\x4d\xcd\x2b\x29\xae\xad\xad\xae\xd6\x03\x4b\xa4\xe6\xa5\x28\xe8\x02\x59\x05\x89\xc9\xd9\x89\x40\x25\x40\xf1\x00\x08\x13\x28\xc8\x95\x99\x5b\x90\x5f\x54\xa2\xa0\x81\x30\xc1\x13\x2c\x02\x31\xc0\x2f\x31\x17\xa8\x0a\xa2\xa5\x24\x03\x66\x1c\x90\xd6\xe4\x82\x33\x01\x99\x8d\xcc\xfd\x8f\x00\x00\x00")
 
 func templatesHeaderTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -185,7 +185,7 @@ func templatesHeaderTmpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesInlineTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xaa\xae\x4e\x49\x4d\xcb\xcc\x4b\x55\x50\xca\xcc\xcb\xc9\xcc\x4b\x55\xaa\xad\x55\xa8\xae\x2e\x49\xcd\x2d\xc8\x49\x2c\x49\x55\x50\x4a\x4e\xcc\xc9\x51\x52\xd0\x03\x8b\xa6\xe6\xa5\xd4\xd6\x02\x02\x00\x00\xff\xff\xaa\xeb\x41\xff\x31\x00\x00\x00")
+var _templatesInlineTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xab\xae\x4e\x49\x4d\xcb\xcc\x4b\x55\x50\xca\xcc\xcb\x01\xd2\x4a\xb5\xb5\x0a\xd5\xd5\x25\xa9\xb9\x05\x39\x89\x25\x40\xd1\xe4\xc4\x9c\x1c\x25\x05\x3d\xb0\x68\x6a\x5e\x4a\x6d\x2d\x00\xaa\xeb\x41\xff\x31\x00\x00\x00")
 
 func templatesInlineTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -205,7 +205,7 @@ func templatesInlineTmpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesInputsTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcd\xb1\x0a\x02\x31\x0c\x80\xe1\xdd\xa7\x08\xc7\x8d\x92\x07\x10\x1c\x1c\x5d\x44\xf0\x09\x2a\x4d\x8f\x0e\x17\x25\xc9\x4d\x21\xef\x2e\x0d\x0e\x9d\xda\x7c\x84\x3f\xee\x95\x5a\x67\x82\xa5\xf3\xf7\x30\x5d\x22\xdc\xd7\x06\x97\x2b\xe0\xf8\xf6\x06\xfc\x31\xc0\xd7\xf1\x36\x52\xd3\xd9\x1e\x65\xa7\x1a\x61\x86\xee\xc4\x35\x62\xc0\x19\xfe\x43\xee\xad\x0d\x9f\xd2\xd9\xee\x19\x1f\x28\x85\x37\x4a\x2f\x52\x76\x32\x12\xcd\xc4\x4d\x36\x45\xf7\xd4\x71\x7a\xea\xcc\xcf\xe9\x17\x00\x00\xff\xff\x6d\x19\xdc\xb1\xb1\x00\x00\x00")
+var _templatesInputsTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4d\x8d\x31\x0a\xc3\x30\x0c\x45\xf7\x9e\x42\x84\x8c\xc5\x07\x28\x74\xe8\x98\x25\x14\x7a\x02\x87\xc8\xc1\x43\x94\x22\x29\x93\xf0\xdd\x63\x8b\x0c\x9e\xf4\xff\xe3\xf3\x64\xb6\x62\xca\x84\x30\x64\xfa\x9f\x2a\x43\x29\x66\x63\x82\xd7\x1b\x42\x8b\x39\x01\x1d\x0a\xe1\x77\x2e\x8a\xa2\xd2\xb3\x39\xee\xb8\x96\xa2\x1a\xcc\x90\x6a\x6a\xe0\x09\x77\xf1\xdd\x98\xc2\x97\x33\xe9\xe4\xf2\x06\x39\xd2\x86\xce\x23\xd7\xb9\x22\x8b\x2b\x3e\xbc\x49\xf5\x38\x6d\xaf\x3b\x4f\x7f\x1e\x17\x6d\x19\xdc\xb1\xb1\x00\x00\x00")
 
 func templatesInputsTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -225,7 +225,7 @@ func templatesInputsTmpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesMessageTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\x8d\xe1\x6a\x83\x40\x10\x84\xff\xfb\x14\x8b\x28\xb4\xa0\xfb\x00\x85\x3e\x40\xff\x14\x69\x4b\xff\x5f\xe2\x68\x16\xf4\x62\xee\x4e\x43\x58\xf6\xdd\x83\x07\xf1\xd7\x0c\x33\xc3\x37\xaa\x3d\x06\xf1\xa0\x72\x46\x8c\x6e\x44\x49\xad\x59\xa1\x2a\x03\xf9\x6b\x22\xfe\x5d\x4f\x09\x31\x45\xb3\xfa\xc6\xa4\x0a\xdf\x9b\xa9\xde\x25\x5d\x88\x7f\x70\x86\x6c\x08\x7b\xc2\x7f\x8f\x05\xfc\xef\xa6\x15\x66\x7c\x0c\xf9\xdb\xcd\x30\x7b\xcb\x44\xee\x82\xf8\xf4\xe5\x97\x75\x07\xaa\x06\xe7\x47\x50\x25\x0d\x55\x98\xe8\xe3\x93\xb8\x73\xc1\xcd\x48\x08\xb9\x97\x81\x2a\x31\x6b\x5e\xbf\xf5\x76\x70\xb3\xbc\x17\xaa\x2d\x65\xfb\x0c\x00\x00\xff\xff\x90\x2e\xb9\x52\xc9\x00\x00\x00")
+var _templatesMessageTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x3d\x8e\x41\x0a\xc2\x40\x0c\x45\xf7\x9e\x22\x94\x16\x14\xda\x1c\x40\xf0\x00\x6e\x44\x54\xdc\x8f\x36\xad\x81\x76\x5a\x67\xa6\x15\x09\xb9\xbb\xd3\x01\xbb\x4a\xf8\x79\x79\x89\x48\x4d\x0d\x5b\x82\xac\x27\xef\x4d\x4b\x19\x54\xaa\x1b\x11\x6e\xc0\x0e\x01\xf0\x3a\x3d\x02\xf9\xe0\x55\x8b\x37\x82\x08\xd9\x5a\x55\xe4\xc3\xe1\x05\x78\xa1\x27\xf1\x4c\x6e\x49\xf0\xf6\x1d\x09\xef\xa6\x9b\x48\x15\x57\x10\x4f\xa6\x8f\xc1\x36\x19\xf1\xec\xd8\x86\xa3\x1d\xa7\x45\x28\xe2\x8c\x6d\x09\x72\x2e\x21\xa7\x0e\xf6\x87\x08\x18\x17\xf9\x40\x2e\xcd\xe3\x4a\xce\xaa\xe5\xff\x6e\x31\xaf\xde\x54\x76\xf1\xd1\x0a\x52\xfb\x03\x90\x2e\xb9\x52\xc9\x00\x00\x00")
 
 func templatesMessageTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -245,7 +245,7 @@ func templatesMessageTmpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesResultsTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8d\x4d\x0a\xc2\x40\x0c\x85\xaf\xf2\x28\xb3\x2c\x3d\x80\xe0\x52\xdc\x7b\x03\xa1\x19\x09\x94\x0c\xbc\x99\xae\x42\xee\x2e\xa9\x45\xc1\x55\x7e\xbe\x2f\x79\xee\xab\x54\x35\xc1\x44\xe9\xfb\x36\xfa\x14\x01\x77\x3e\xed\x25\x28\x3a\xa3\xc8\x86\xcb\x15\xcb\xe3\x83\x23\xdc\xb5\xa2\x68\xc4\x0c\x77\xb1\x35\x37\xf7\x36\xb0\x64\x73\xce\x5a\xf3\x60\xec\xb4\x7e\x23\x1b\x53\x16\xf2\xe4\x38\x84\xc6\xef\xd3\x7f\x39\x03\x7f\xee\x51\xdf\x01\x00\x00\xff\xff\xb0\x4f\xcf\x61\xa8\x00\x00\x00")
+var _templatesResultsTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5d\x8d\x31\x0a\x80\x30\x0c\x45\xaf\x12\xc4\x51\x7a\x00\xc1\x51\xdc\xbd\x81\xd0\x28\x05\x69\xe1\xb7\x9d\x42\xee\x6e\x5b\x45\xc1\x29\xc9\x7f\x2f\x89\x88\xe5\xdd\x79\xa6\x0e\x1c\xf3\x99\x62\xa7\x4a\x22\xd8\xfc\xc1\xd4\xbb\x81\x7a\x3e\x69\x9c\xc8\xac\x37\x56\x15\x71\x7b\x21\xaa\x43\xf1\xd8\xdb\x9a\x2c\x21\x91\xa9\xcd\x33\x17\xa3\x2c\xa4\x0c\x1f\x67\x20\xa0\xca\x0c\x3c\x9c\x9a\x10\xf0\x1e\xfd\xcb\xf5\xe1\xe7\xb6\x7a\x01\xb0\x4f\xcf\x61\xa8\x00\x00\x00")
 
 func templatesResultsTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -332,11 +332,13 @@ var _bindata = map[string]func() (*asset, error){
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -133,7 +133,18 @@ func (r *Render) TestFunction(
 	testMode string,
 	filePath string,
 	globalInit []string,
+	callAssertion string,
+	seams []string,
 ) error {
+	// the field seams refer to the receiver created by the test
+	if f.Receiver != nil {
+		for i := range seams {
+			seams[i] = strings.Replace(seams[i], atgconstant.ReceiverPlaceholder, receiverName(f.Receiver), -1)
+		}
+		for i := range f.Faults {
+			f.Faults[i].Func = strings.Replace(f.Faults[i].Func, atgconstant.ReceiverPlaceholder, receiverName(f.Receiver), -1)
+		}
+	}
 	switch testMode {
 	case atgconstant.FinalTest:
 		return r.tmpls.ExecuteTemplate(w, "finalsuite", struct {
@@ -147,6 +158,8 @@ func (r *Render) TestFunction(
 			Builders       []string
 			MiddleBuilders []string
 			MockStateMents []string
+			Seams          []string
+			CallAssertion  string
 			Uid            string
 			TemplateParams map[string]interface{}
 		}{
@@ -160,6 +173,8 @@ func (r *Render) TestFunction(
 			Builders:       builder,
 			MiddleBuilders: middleBuilder,
 			MockStateMents: mock,
+			Seams:          seams,
+			CallAssertion:  callAssertion,
 			Uid:            "",
 			TemplateParams: params,
 		})
@@ -172,6 +187,7 @@ func (r *Render) TestFunction(
 			Named          bool
 			UseMockType    int
 			Mocks          []string
+			Seams          []string
 			Builders       []string
 			TestCaseNum    int
 			MaxTestCaseN   []string
@@ -189,6 +205,7 @@ func (r *Render) TestFunction(
 			Uid:            Uid,
 			FilePath:       filePath,
 			Mocks:          mock,
+			Seams:          seams,
 			Builders:       builder,
 			TestCaseNum:    testCaseNum,
 			RealName:       strings.Replace(f.Name, Uid, "", -1),
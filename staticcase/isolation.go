@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+// GetIsolationReport tells which calls of each function can not be isolated without patching and why,
+// keyed by the full name. The interface calls are replaced by the typed mocks, and the function fields and the
+// package variables by the seams, so that only the direct calls are left.
+func GetIsolationReport(ctx context.Context) map[string][]string {
+	functionMap, _ := contexthelper.GetSetupFuncMap(ctx)
+	report := map[string][]string{}
+	for funcName, functions := range functionMap {
+		if functions.TestFunction == nil || functions.TestFuncCallGraph == nil {
+			continue
+		}
+		if reasons := isolationReasons(functions.TestFunction, functions.TestFuncCallGraph); len(reasons) != 0 {
+			report[funcName] = reasons
+		}
+	}
+	return report
+}
+
+func isolationReasons(function *parsermodel.ProjectFunction, cg *callgraph.Graph) []string {
+	mocked := map[*ssa.Function]bool{}
+	for _, callee := range function.CalleeFunctionsForTargetFunction {
+		mocked[callee] = true
+	}
+	// the tested function and the callees which run for real make the calls
+	direct := map[*ssa.Function]bool{}
+	for caller := range cg.Nodes {
+		if !mocked[caller] {
+			collectDirectCalls(caller, mocked, direct)
+		}
+	}
+	reasons := make([]string, 0)
+	for callee := range direct {
+		if callee.Signature.Recv() != nil {
+			reasons = append(reasons, fmt.Sprintf("%s is the method of the concrete type, which can only be replaced by patching. call it by an interface instead", callee.String()))
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("%s is the package function, which can only be replaced by patching. call it by a function field or a package variable instead", callee.String()))
+	}
+	sort.Strings(reasons)
+	return reasons
+}
+
+// collectDirectCalls finds the static calls of the mocked functions in the function and its closures.
+// The interface calls are left out, because the typed mocks take their place.
+func collectDirectCalls(function *ssa.Function, mocked, direct map[*ssa.Function]bool) {
+	for _, block := range function.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			if callee := call.Common().StaticCallee(); callee != nil && mocked[callee] {
+				direct[callee] = true
+			}
+		}
+	}
+	for _, anon := range function.AnonFuncs {
+		collectDirectCalls(anon, mocked, direct)
+	}
+}
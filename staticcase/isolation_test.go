@@ -0,0 +1,65 @@
+package staticcase
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/codebuilder/setup/graph"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/ssa"
+)
+
+const isolationSrc = `package shop
+
+type Store interface {
+	Get(id int) string
+}
+
+type DB struct{}
+
+func (d *DB) Get(id int) string { return "" }
+
+func Load(id int) string { return "" }
+
+type Service struct {
+	store Store
+	db    *DB
+	fetch func(int) string
+}
+
+func (s *Service) Handle(id int) string {
+	return s.store.Get(id) + s.fetch(id) + s.db.Get(id)
+}
+
+func (s *Service) Cached(id int) string {
+	return s.store.Get(id) + s.fetch(id)
+}
+
+func List(ids []int) string {
+	res := ""
+	for _, id := range ids {
+		res += Load(id)
+	}
+	return res
+}
+`
+
+func TestIsolationReasons(t *testing.T) {
+	ssaPkg := buildSSA(t, "example.com/shop", isolationSrc)
+	service := types.NewPointer(ssaPkg.Type("Service").Type())
+	db := types.NewPointer(ssaPkg.Type("DB").Type())
+	program := &parsermodel.ProjectProgram{MethodsByName: map[string][]*ssa.Function{
+		"Get": {ssaPkg.Prog.LookupMethod(db, ssaPkg.Pkg, "Get")},
+	}}
+	reasons := func(function *ssa.Function) []string {
+		projectFunction := &parsermodel.ProjectFunction{Function: function, Program: program, CalleeFunctionsForTargetFunction: map[string]*ssa.Function{}}
+		return isolationReasons(projectFunction, graph.BuildCallGraph(projectFunction, function.Name(), nil))
+	}
+	// the interface call and the function field are replaced without patching
+	assert.Equal(t, []string{"(*example.com/shop.DB).Get is the method of the concrete type, which can only be replaced by patching. call it by an interface instead"},
+		reasons(ssaPkg.Prog.LookupMethod(service, ssaPkg.Pkg, "Handle")))
+	assert.Empty(t, reasons(ssaPkg.Prog.LookupMethod(service, ssaPkg.Pkg, "Cached")))
+	assert.Equal(t, []string{"example.com/shop.Load is the package function, which can only be replaced by patching. call it by a function field or a package variable instead"},
+		reasons(ssaPkg.Func("List")))
+}
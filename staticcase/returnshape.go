@@ -0,0 +1,233 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/testcase"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"golang.org/x/tools/go/ssa"
+)
+
+// GetReturnShapes finds the return shapes of the functions which the middle code fakes by mockfunc.MakeCall,
+// keyed by the name passed to it
+func GetReturnShapes(ctx context.Context, useMockType int) map[string][]atgconstant.ReturnShape {
+	res := map[string][]atgconstant.ReturnShape{}
+	functionMap, _ := contexthelper.GetSetupFuncMap(ctx)
+	for _, functions := range functionMap {
+		ts, err := testcase.CreateTestCase(ctx, functions.TestFunction)
+		if err != nil {
+			continue
+		}
+		for _, stat := range ts.Statements {
+			if !isMadeCall(stat, useMockType) || stat.OriginalFunction == nil {
+				continue
+			}
+			if _, exist := res[stat.Expression]; exist {
+				continue
+			}
+			if shapes := getReturnShapes(ctx, stat.OriginalFunction); len(shapes) != 0 {
+				res[stat.Expression] = shapes
+			}
+		}
+	}
+	return res
+}
+
+// getReturnShapes finds what the return statements of the function return, e.g. return nil, err after err != nil.
+// It returns nothing if none of the results is known, because the random values take the place of the shapes.
+func getReturnShapes(ctx context.Context, function *ssa.Function) []atgconstant.ReturnShape {
+	shapes := make([]atgconstant.ReturnShape, 0)
+	if function == nil || function.Signature.Results().Len() == 0 {
+		return shapes
+	}
+	known := false
+	visited := map[string]bool{}
+	for _, block := range function.Blocks {
+		if len(block.Instrs) == 0 {
+			continue
+		}
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		shape := make(atgconstant.ReturnShape, 0, len(ret.Results))
+		keys := make([]string, 0, len(ret.Results))
+		for _, v := range ret.Results {
+			result := getShapeResult(ctx, v, block)
+			known = known || result.Kind != atgconstant.ResultAny
+			shape = append(shape, result)
+			keys = append(keys, result.Kind+" "+result.Code)
+		}
+		key := strings.Join(keys, ", ")
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		shapes = append(shapes, shape)
+	}
+	if !known {
+		return shapes[:0]
+	}
+	return shapes
+}
+
+// getShapeResult tells what the returned value is. The value checked by the if statement which leads to the return,
+// e.g. if err != nil { return nil, err }, is nil or not nil.
+func getShapeResult(ctx context.Context, v ssa.Value, block *ssa.BasicBlock) atgconstant.ShapeResult {
+	switch value := v.(type) {
+	case *ssa.Const:
+		if value.IsNil() {
+			return atgconstant.ShapeResult{Kind: atgconstant.ResultNil}
+		}
+		if code, ok := constCode(ctx, value); ok {
+			return atgconstant.ShapeResult{Kind: atgconstant.ResultConst, Code: code}
+		}
+		return atgconstant.ShapeResult{Kind: atgconstant.ResultAny}
+	case *ssa.UnOp:
+		// the sentinel error, e.g. return nil, ErrNotFound
+		if global, ok := value.X.(*ssa.Global); ok && value.Op == token.MUL && isErrorType(value.Type()) {
+			if code, pkgName, pkgPath, ok := globalCode(ctx, global); ok {
+				return atgconstant.ShapeResult{Kind: atgconstant.ResultConst, Code: code, PkgName: pkgName, PkgPath: pkgPath}
+			}
+		}
+	case *ssa.Alloc, *ssa.MakeInterface, *ssa.MakeSlice, *ssa.MakeMap, *ssa.MakeChan, *ssa.MakeClosure, *ssa.Function:
+		return atgconstant.ShapeResult{Kind: atgconstant.ResultNonNil}
+	case *ssa.Call:
+		if isNewError(value.Common()) {
+			return atgconstant.ShapeResult{Kind: atgconstant.ResultNonNil}
+		}
+	}
+	return atgconstant.ShapeResult{Kind: nilness(v, block)}
+}
+
+// nilness finds the if statement which compares the value with nil and leads to the block
+func nilness(v ssa.Value, block *ssa.BasicBlock) string {
+	for b := block; b != nil; b = b.Idom() {
+		if len(b.Preds) != 1 || len(b.Preds[0].Instrs) == 0 {
+			continue
+		}
+		pred := b.Preds[0]
+		ifInstr, ok := pred.Instrs[len(pred.Instrs)-1].(*ssa.If)
+		if !ok || pred.Succs[0] == pred.Succs[1] {
+			continue
+		}
+		cond, ok := ifInstr.Cond.(*ssa.BinOp)
+		if !ok || (cond.Op != token.EQL && cond.Op != token.NEQ) || !isNilComparison(cond, v) {
+			continue
+		}
+		// the first successor is the true branch
+		if (cond.Op == token.EQL) == (b == pred.Succs[0]) {
+			return atgconstant.ResultNil
+		}
+		return atgconstant.ResultNonNil
+	}
+	return atgconstant.ResultAny
+}
+
+func isNilComparison(cond *ssa.BinOp, v ssa.Value) bool {
+	isNil := func(x ssa.Value) bool {
+		c, ok := x.(*ssa.Const)
+		return ok && c.IsNil()
+	}
+	return (cond.X == v && isNil(cond.Y)) || (cond.Y == v && isNil(cond.X))
+}
+
+// isNewError reports whether the call creates the error, e.g. errors.New or fmt.Errorf
+func isNewError(call *ssa.CallCommon) bool {
+	callee := call.StaticCallee()
+	if callee == nil || callee.Pkg == nil {
+		return false
+	}
+	switch callee.Pkg.Pkg.Path() {
+	case "errors", "github.com/pkg/errors":
+		return callee.Name() == "New" || callee.Name() == "Errorf"
+	case "fmt":
+		return callee.Name() == "Errorf"
+	}
+	return false
+}
+
+// constCode returns the code of the constant. The constant of the type other than the default ones is converted,
+// e.g. int64(3) or dao.Status(1).
+func constCode(ctx context.Context, c *ssa.Const) (string, bool) {
+	if c.Value == nil {
+		return "", false
+	}
+	var code, defaultType string
+	switch c.Value.Kind() {
+	case constant.Bool:
+		code, defaultType = c.Value.String(), "bool"
+	case constant.String:
+		code, defaultType = c.Value.ExactString(), "string"
+	case constant.Int:
+		code, defaultType = c.Value.ExactString(), "int"
+	case constant.Float:
+		f, _ := constant.Float64Val(c.Value)
+		code, defaultType = strconv.FormatFloat(f, 'g', -1, 64), "float64"
+	default:
+		return "", false
+	}
+	if basic, ok := c.Type().(*types.Basic); ok && basic.Name() == defaultType {
+		return code, true
+	}
+	if !isTypeAccessible(ctx, c.Type()) {
+		return "", false
+	}
+	typeName := types.TypeString(c.Type(), func(p *types.Package) string {
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(p.Name(), p.Path())
+		return pkgName
+	})
+	return fmt.Sprintf("%s(%s)", typeName, code), true
+}
+
+// returnShapesCode renders the return shapes in the middle code
+func returnShapesCode(shapes map[string][]atgconstant.ReturnShape) string {
+	keys := make([]string, 0, len(shapes))
+	for key := range shapes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString("map[string][]atgconstant.ReturnShape{")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%q: {", key)
+		for _, shape := range shapes[key] {
+			b.WriteString("{")
+			for _, result := range shape {
+				if result.Kind == atgconstant.ResultConst {
+					fmt.Fprintf(&b, "{Kind: %q, Code: %q, PkgName: %q, PkgPath: %q, Value: %s}, ", result.Kind, result.Code, result.PkgName, result.PkgPath, result.Code)
+					continue
+				}
+				fmt.Fprintf(&b, "{Kind: %q}, ", result.Kind)
+			}
+			b.WriteString("}, ")
+		}
+		b.WriteString("}, ")
+	}
+	b.WriteString("}")
+	return b.String()
+}
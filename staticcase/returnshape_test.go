@@ -0,0 +1,93 @@
+package staticcase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/stretchr/testify/assert"
+)
+
+const returnShapeSrc = `package dao
+
+type daoError string
+
+func (e daoError) Error() string { return string(e) }
+
+var ErrNotFound error = daoError("not found")
+
+type Status int
+
+type User struct {
+	Name string
+}
+
+func load(id int) (*User, error) { return nil, nil }
+
+func Get(id int) (*User, error) {
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	u, err := load(id)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func New(name string) (*User, error) {
+	if name == "" {
+		return nil, daoError("empty")
+	}
+	return &User{Name: name}, nil
+}
+
+func State(id int) (Status, int64, bool) {
+	if id > 0 {
+		return Status(1), 2, true
+	}
+	return 0, 0, false
+}
+
+func Name(id int) string {
+	return lookup(id)
+}
+
+func lookup(id int) string { return "" }
+`
+
+func TestGetReturnShapes(t *testing.T) {
+	ssaPkg := buildSSA(t, "example.com/dao", returnShapeSrc)
+	ctx := duplicatepackagemanager.SetInstance(context.Background())
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/app")
+	nilResult := atgconstant.ShapeResult{Kind: atgconstant.ResultNil}
+	nonNil := atgconstant.ShapeResult{Kind: atgconstant.ResultNonNil}
+	anyResult := atgconstant.ShapeResult{Kind: atgconstant.ResultAny}
+	constResult := func(code string) atgconstant.ShapeResult {
+		return atgconstant.ShapeResult{Kind: atgconstant.ResultConst, Code: code}
+	}
+	assert.ElementsMatch(t, []atgconstant.ReturnShape{
+		{nilResult, {Kind: atgconstant.ResultConst, Code: "dao.ErrNotFound", PkgName: "dao", PkgPath: "example.com/dao"}},
+		{nilResult, nonNil},
+		{anyResult, nilResult},
+	}, getReturnShapes(ctx, ssaPkg.Func("Get")))
+	assert.ElementsMatch(t, []atgconstant.ReturnShape{
+		{nilResult, nonNil},
+		{nonNil, nilResult},
+	}, getReturnShapes(ctx, ssaPkg.Func("New")))
+	assert.ElementsMatch(t, []atgconstant.ReturnShape{
+		{constResult("dao.Status(1)"), constResult("int64(2)"), constResult("true")},
+		{constResult("dao.Status(0)"), constResult("int64(0)"), constResult("false")},
+	}, getReturnShapes(ctx, ssaPkg.Func("State")))
+	assert.Equal(t, []atgconstant.ReturnShape{{constResult(`""`)}}, getReturnShapes(ctx, ssaPkg.Func("lookup")))
+	// nothing is known about the result
+	assert.Equal(t, 0, len(getReturnShapes(ctx, ssaPkg.Func("Name"))))
+}
+
+func TestReturnShapesCode(t *testing.T) {
+	code := returnShapesCode(map[string][]atgconstant.ReturnShape{
+		"dao.Get": {{{Kind: atgconstant.ResultNil}, {Kind: atgconstant.ResultConst, Code: "dao.ErrNotFound", PkgName: "dao", PkgPath: "example.com/dao"}}},
+	})
+	assert.Equal(t, `map[string][]atgconstant.ReturnShape{"dao.Get": {{{Kind: "nil"}, {Kind: "const", Code: "dao.ErrNotFound", PkgName: "dao", PkgPath: "example.com/dao", Value: dao.ErrNotFound}, }, }, }`, code)
+}
@@ -291,14 +291,19 @@ func PluginCmd(opt atgconstant.Options) error {
 		logextractor.ExecutionLog.DebugInfo(stdBuffer.String())
 		logextractor.ExecutionLog.DebugInfo(stdErrBuff.String())
 	}()
+	// nothing is patched without the mocks, so that the inlining is kept
+	gcflags := "-gcflags=all=-N -l"
+	if opt.UseMockType == atgconstant.UseNoMock {
+		gcflags = "-gcflags="
+	}
 	if opt.RunForFinalSuite {
-		cmd = exec.Command(atgconstant.GoDirective, "test", "-gcflags=all=-N -l", "-v", "-vet=off", "-count=1", "-timeout=80s", fmt.Sprintf("-test.run=%s", opt.FinalSuiteTestName))
+		cmd = exec.Command(atgconstant.GoDirective, "test", gcflags, "-v", "-vet=off", "-count=1", "-timeout=80s", fmt.Sprintf("-test.run=%s", opt.FinalSuiteTestName))
 	} else {
 		switch opt.MinUnit {
 		case atgconstant.MinUnit, atgconstant.FileMode:
-			cmd = exec.Command(atgconstant.GoDirective, "test", "-gcflags=all=-N -l", "-v", "-vet=off", "-count=1", "-timeout=100s", fmt.Sprintf("-test.run=%s", opt.Uid))
+			cmd = exec.Command(atgconstant.GoDirective, "test", gcflags, "-v", "-vet=off", "-count=1", "-timeout=100s", fmt.Sprintf("-test.run=%s", opt.Uid))
 		default:
-			cmd = exec.Command(atgconstant.GoDirective, "test", "-gcflags=all=-N -l", "-v", "-vet=off", "-count=1", "-timeout=80s", "./...")
+			cmd = exec.Command(atgconstant.GoDirective, "test", gcflags, "-v", "-vet=off", "-count=1", "-timeout=80s", "./...")
 		}
 	}
 	cmd.Env = os.Environ()
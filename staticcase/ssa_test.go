@@ -0,0 +1,29 @@
+package staticcase
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// buildSSA builds the package of the path from the source
+func buildSSA(t *testing.T, path, src string) *ssa.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Base(path)+".go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage(path, f.Name.Name)
+	ssaPkg, _, err := ssautil.BuildPackage(&types.Config{Importer: standInImporter{fset: fset}}, fset, pkg, []*ast.File{f}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssaPkg
+}
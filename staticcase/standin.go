@@ -0,0 +1,420 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/bytedance/nxt_unit/staticcase/internal/models"
+	"golang.org/x/tools/go/ssa"
+)
+
+// httpClientFuncs are the package functions of net/http which send the request by the default client
+var httpClientFuncs = map[string]bool{"Get": true, "Post": true, "PostForm": true, "Head": true}
+
+// httpClientMethods are the methods of *http.Client which send the request
+var httpClientMethods = map[string]bool{"Do": true, "Get": true, "Post": true, "PostForm": true, "Head": true}
+
+// usesHTTPClient reports whether the function, its closures or the functions of the same package it calls
+// send the request by the net/http client
+func usesHTTPClient(function *ssa.Function) bool {
+	return callsFunction(function, isHTTPClientCall, map[*ssa.Function]bool{})
+}
+
+// clockFuncs are the functions of the time package which read or wait for the clock
+var clockFuncs = map[string]bool{"Now": true, "Since": true, "Until": true, "Sleep": true}
+
+// usesClock reports whether the function, its closures or the functions of the same package it calls
+// read the clock, so that the outputs change every run
+func usesClock(function *ssa.Function) bool {
+	return callsFunction(function, func(callee *ssa.Function) bool {
+		return callee.Pkg != nil && callee.Pkg.Pkg.Path() == "time" && callee.Signature.Recv() == nil && clockFuncs[callee.Name()]
+	}, map[*ssa.Function]bool{})
+}
+
+// callsFunction reports whether the function, its closures or the functions of the same package it calls
+// call the function which matches
+func callsFunction(function *ssa.Function, match func(callee *ssa.Function) bool, visited map[*ssa.Function]bool) bool {
+	if function == nil || visited[function] {
+		return false
+	}
+	visited[function] = true
+	for _, block := range function.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			callee := call.Common().StaticCallee()
+			if callee == nil {
+				continue
+			}
+			if match(callee) {
+				return true
+			}
+			if callee.Pkg != nil && callee.Pkg == function.Pkg && callsFunction(callee, match, visited) {
+				return true
+			}
+		}
+	}
+	for _, anon := range function.AnonFuncs {
+		if callsFunction(anon, match, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHTTPClientCall(callee *ssa.Function) bool {
+	if callee.Pkg == nil || callee.Pkg.Pkg.Path() != "net/http" {
+		return false
+	}
+	recv := callee.Signature.Recv()
+	if recv == nil {
+		return httpClientFuncs[callee.Name()]
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Name() == "Client" && httpClientMethods[callee.Name()]
+}
+
+// usesSQLDB reports whether the function takes *sql.DB by the parameters or the fields of the receiver,
+// which are generated as the in-memory stand-ins
+func usesSQLDB(function *ssa.Function) bool {
+	if function == nil {
+		return false
+	}
+	for i, param := range function.Params {
+		if isSQLDB(param.Type()) {
+			return true
+		}
+		if i != 0 || function.Signature.Recv() == nil {
+			continue
+		}
+		recv := param.Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		st, ok := recv.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for j := 0; j < st.NumFields(); j++ {
+			if isSQLDB(st.Field(j).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isSQLDB(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "database/sql" && named.Obj().Name() == "DB"
+}
+
+// redisPkgPaths are the go-redis packages whose clients are served by the redis stand-in
+var redisPkgPaths = map[string]bool{
+	"github.com/go-redis/redis/v7": true,
+	"github.com/go-redis/redis/v8": true,
+	"github.com/redis/go-redis/v9": true,
+}
+
+// redisInterfaces are the interfaces of go-redis which *redis.Client implements
+var redisInterfaces = map[string]bool{"Cmdable": true, "UniversalClient": true}
+
+// getRedisClients returns the go-redis clients and interfaces which the function takes by the parameters
+// or the fields of the receiver. The key is the full name of the type.
+func getRedisClients(function *ssa.Function) map[string]types.Type {
+	clients := map[string]types.Type{}
+	if function == nil {
+		return clients
+	}
+	for i, param := range function.Params {
+		if isRedisClient(param.Type()) {
+			clients[param.Type().String()] = param.Type()
+		}
+		if i != 0 || function.Signature.Recv() == nil {
+			continue
+		}
+		recv := param.Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		st, ok := recv.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for j := 0; j < st.NumFields(); j++ {
+			if isRedisClient(st.Field(j).Type()) {
+				clients[st.Field(j).Type().String()] = st.Field(j).Type()
+			}
+		}
+	}
+	return clients
+}
+
+// isRedisClient reports whether t is *redis.Client or one of the interfaces which it implements
+func isRedisClient(t types.Type) bool {
+	isClient := false
+	if ptr, ok := t.(*types.Pointer); ok {
+		t, isClient = ptr.Elem(), true
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || !redisPkgPaths[named.Obj().Pkg().Path()] {
+		return false
+	}
+	if isClient {
+		return named.Obj().Name() == "Client"
+	}
+	return redisInterfaces[named.Obj().Name()]
+}
+
+// redisClientCode is how the test builds the client which talks to the redis stand-in
+func redisClientCode(ctx context.Context, pkg *types.Package) string {
+	pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(pkg.Name(), pkg.Path())
+	return fmt.Sprintf("%s.NewClient(&%s.Options{Addr: \"stand-in\", Dialer: mockfunc.DialRedis})", pkgName, pkgName)
+}
+
+// getRedisImplementations returns the clients which satisfy the go-redis interfaces when the redis stand-in is on
+func getRedisImplementations(ctx context.Context, function *ssa.Function) map[string]*interfaceImplementation {
+	implementations := map[string]*interfaceImplementation{}
+	if opt, _ := contexthelper.GetOption(ctx); !opt.RedisStandIn {
+		return implementations
+	}
+	for key, t := range getRedisClients(function) {
+		named, ok := t.(*types.Named)
+		if !ok {
+			continue
+		}
+		code := redisClientCode(ctx, named.Obj().Pkg())
+		implementations[key] = &interfaceImplementation{
+			iface: types.TypeString(named, func(p *types.Package) string {
+				pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(p.Name(), p.Path())
+				return pkgName
+			}),
+			value: code,
+			code:  code,
+		}
+	}
+	return implementations
+}
+
+// getRedisClientBuilder registers the clients for the *redis.Client when the redis stand-in is on,
+// e.g. injector.SetBuilder(redis.NewClient(...), "redis.NewClient(...)")
+func getRedisClientBuilder(ctx context.Context, function *ssa.Function) []string {
+	builders := make([]string, 0)
+	if opt, _ := contexthelper.GetOption(ctx); !opt.RedisStandIn {
+		return builders
+	}
+	for _, t := range getRedisClients(function) {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			continue
+		}
+		code := redisClientCode(ctx, ptr.Elem().(*types.Named).Obj().Pkg())
+		builders = append(builders, fmt.Sprintf("injector.SetBuilder(%s, %q)", code, code))
+	}
+	sort.Strings(builders)
+	return builders
+}
+
+// sandboxPkgs are the packages whose functions read or change the environment and the files of the process
+var sandboxPkgs = map[string]bool{"os": true, "io/ioutil": true}
+
+// envFuncs read the environment key of the first argument
+var envFuncs = map[string]bool{"Getenv": true, "LookupEnv": true}
+
+// readFileFuncs read the file of the first argument
+var readFileFuncs = map[string]bool{"ReadFile": true, "Open": true, "OpenFile": true}
+
+// sandboxUsage is what the function reads from the environment and the files
+type sandboxUsage struct {
+	used  bool
+	env   []string
+	files []string
+}
+
+// getSandboxUsage finds the calls of os and io/ioutil in the function, its closures and the functions of the same
+// package it calls. The constant environment keys and the constant relative paths are collected, so that the test
+// case generates them.
+func getSandboxUsage(function *ssa.Function) sandboxUsage {
+	usage := sandboxUsage{}
+	env, files := map[string]bool{}, map[string]bool{}
+	collectSandboxUsage(function, &usage, env, files, map[*ssa.Function]bool{})
+	for key := range env {
+		usage.env = append(usage.env, key)
+	}
+	for path := range files {
+		usage.files = append(usage.files, path)
+	}
+	sort.Strings(usage.env)
+	sort.Strings(usage.files)
+	return usage
+}
+
+func collectSandboxUsage(function *ssa.Function, usage *sandboxUsage, env, files map[string]bool, visited map[*ssa.Function]bool) {
+	if function == nil || visited[function] {
+		return
+	}
+	visited[function] = true
+	for _, block := range function.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			callee := call.Common().StaticCallee()
+			if callee == nil || callee.Pkg == nil {
+				continue
+			}
+			if callee.Pkg == function.Pkg {
+				collectSandboxUsage(callee, usage, env, files, visited)
+				continue
+			}
+			if !sandboxPkgs[callee.Pkg.Pkg.Path()] || callee.Signature.Recv() != nil {
+				continue
+			}
+			usage.used = true
+			args := call.Common().Args
+			if len(args) == 0 {
+				continue
+			}
+			arg, ok := args[0].(*ssa.Const)
+			if !ok || arg.Value == nil || arg.Value.Kind() != constant.String {
+				continue
+			}
+			value := constant.StringVal(arg.Value)
+			switch {
+			case envFuncs[callee.Name()] && value != "":
+				env[value] = true
+			case readFileFuncs[callee.Name()] && value != "" && !filepath.IsAbs(value) && !strings.HasPrefix(value, ".."):
+				files[filepath.ToSlash(filepath.Clean(value))] = true
+			}
+		}
+	}
+	for _, anon := range function.AnonFuncs {
+		collectSandboxUsage(anon, usage, env, files, visited)
+	}
+}
+
+// standIn is the feature of the final suite which the middle code turns on for the functions it applies to.
+// The final suite is rendered by the middle code, so the middle code keeps the functions in its context and
+// the final suite looks them up by contains.
+type standIn struct {
+	name contexthelper.StandIn
+	// enabled reports whether the options turn the stand-in on
+	enabled func(option atgconstant.Options, useMockType int) bool
+	// applies reports whether the function needs the stand-in and fills what the templates render for it
+	applies func(fun *models.Function, function *ssa.Function) bool
+	set     func(fun *models.Function, on bool)
+}
+
+var standIns = []standIn{
+	{
+		name:    contexthelper.HTTPStandIn,
+		enabled: func(option atgconstant.Options, _ int) bool { return option.HTTPStandIn },
+		applies: func(_ *models.Function, function *ssa.Function) bool { return usesHTTPClient(function) },
+		set:     func(fun *models.Function, on bool) { fun.HTTPStandIn = on },
+	},
+	{
+		name:    contexthelper.SQLStandIn,
+		enabled: func(option atgconstant.Options, _ int) bool { return option.SQLStandIn },
+		applies: func(_ *models.Function, function *ssa.Function) bool { return usesSQLDB(function) },
+		set:     func(fun *models.Function, on bool) { fun.SQLStandIn = on },
+	},
+	{
+		name:    contexthelper.RedisStandIn,
+		enabled: func(option atgconstant.Options, _ int) bool { return option.RedisStandIn },
+		applies: func(_ *models.Function, function *ssa.Function) bool { return len(getRedisClients(function)) != 0 },
+		set:     func(fun *models.Function, on bool) { fun.RedisStandIn = on },
+	},
+	{
+		// the fake clock patches the time functions, so it goes with gomonkey
+		name: contexthelper.FakeClock,
+		enabled: func(option atgconstant.Options, useMockType int) bool {
+			return option.FakeClock && useMockType == atgconstant.UseGoMonkeyMock
+		},
+		applies: func(_ *models.Function, function *ssa.Function) bool { return usesClock(function) },
+		set:     func(fun *models.Function, on bool) { fun.FakeClock = on },
+	},
+	{
+		name:    contexthelper.Sandbox,
+		enabled: func(option atgconstant.Options, _ int) bool { return option.Sandbox },
+		applies: func(fun *models.Function, function *ssa.Function) bool {
+			usage := getSandboxUsage(function)
+			fun.SandboxEnv, fun.SandboxFiles = usage.env, usage.files
+			return usage.used
+		},
+		set: func(fun *models.Function, on bool) { fun.Sandbox = on },
+	},
+}
+
+// setStandIns turns the enabled stand-ins on for the functions they apply to and returns the middle code
+// which keeps the functions in the context
+func setStandIns(ctx context.Context, funcs []*models.Function, ssaFunctionMap map[string]setup.Functions, useMockType int) []string {
+	option, ok := contexthelper.GetOption(ctx)
+	if !ok {
+		return nil
+	}
+	builder := make([]string, 0)
+	for _, s := range standIns {
+		if !s.enabled(option, useMockType) {
+			continue
+		}
+		names := make([]string, 0)
+		for _, fun := range funcs {
+			ssaFunctionInfo, exist := ssaFunctionMap[fun.FullName()]
+			if exist && s.applies(fun, ssaFunctionInfo.TestFunction.Function) {
+				s.set(fun, true)
+				names = append(names, fun.FullName())
+			}
+		}
+		sort.Strings(names)
+		builder = append(builder, fmt.Sprintf("smartUnitCtx = contexthelper.SetStandIn(smartUnitCtx, %q, %#v)", s.name, names))
+	}
+	return builder
+}
+
+// getStandIns turns the stand-ins on for the functions which the middle code kept in the context
+func getStandIns(ctx context.Context, funcs []*models.Function) {
+	for _, s := range standIns {
+		names, ok := contexthelper.GetStandIn(ctx, s.name)
+		if !ok {
+			continue
+		}
+		for _, fun := range funcs {
+			s.set(fun, contains(names, fun.FullName()))
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package staticcase
+
+import (
+	"context"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/bytedance/nxt_unit/staticcase/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const standInSrc = `package standin
+
+import "net/http"
+
+type Client struct {
+	http *http.Client
+}
+
+func (c *Client) Load(req *http.Request) int {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+func Ping(url string) bool {
+	return get(url)
+}
+
+func get(url string) bool {
+	_, err := http.Get(url)
+	return err == nil
+}
+
+func Async(url string) {
+	go func() {
+		http.Head(url)
+	}()
+}
+
+func Build(url string) (*http.Request, error) {
+	return http.NewRequest("GET", url, nil)
+}
+`
+
+// httpSrc declares the client API of net/http which the detection looks for
+const httpSrc = `package http
+
+type Request struct{}
+
+type Response struct {
+	StatusCode int
+}
+
+type Client struct{}
+
+func (c *Client) Do(req *Request) (*Response, error) { return nil, nil }
+
+func Get(url string) (*Response, error) { return nil, nil }
+
+func Head(url string) (*Response, error) { return nil, nil }
+
+func NewRequest(method, url string, body interface{}) (*Request, error) { return nil, nil }
+`
+
+// sqlSrc declares the *sql.DB which the detection looks for
+const sqlSrc = `package sql
+
+type DB struct{}
+`
+
+// redisSrc declares the go-redis client and interfaces which the detection looks for
+const redisSrc = `package redis
+
+type Options struct{}
+
+type Client struct{}
+
+type Cmdable interface {
+	Get(key string) string
+}
+
+type Pipeliner interface {
+	Exec() error
+}
+`
+
+// timeSrc declares the clock functions which the detection looks for
+const timeSrc = `package time
+
+type Time struct{}
+
+type Duration int64
+
+func Now() Time { return Time{} }
+
+func Since(t Time) Duration { return 0 }
+
+func (t Time) Format(layout string) string { return "" }
+`
+
+// osSrc declares the environment and file functions which the sandbox looks for
+const osSrc = `package os
+
+type File struct{}
+
+func Getenv(key string) string { return "" }
+
+func LookupEnv(key string) (string, bool) { return "", false }
+
+func ReadFile(name string) ([]byte, error) { return nil, nil }
+
+func Open(name string) (*File, error) { return nil, nil }
+
+func WriteFile(name string, data []byte, perm uint32) error { return nil }
+
+func (f *File) Close() error { return nil }
+`
+
+// syncSrc declares the lock which the snapshot of the globals leaves out
+const syncSrc = `package sync
+
+type Mutex struct{ state int32 }
+
+func (m *Mutex) Lock() {}
+
+func (m *Mutex) Unlock() {}
+`
+
+// standInSrcs are the sources of the packages which the stand-ins detect
+var standInSrcs = map[string]string{
+	"net/http":                     httpSrc,
+	"database/sql":                 sqlSrc,
+	"github.com/go-redis/redis/v8": redisSrc,
+	"time":                         timeSrc,
+	"os":                           osSrc,
+	"sync":                         syncSrc,
+	"github.com/bytedance/nxt_unit/smartunitvariablebuild": mockRecorderSrc,
+}
+
+// standInImporter imports the packages which the stand-ins detect from their sources, and the others by default
+type standInImporter struct {
+	fset *token.FileSet
+}
+
+func (m standInImporter) Import(path string) (*types.Package, error) {
+	src, ok := standInSrcs[path]
+	if !ok {
+		return importer.Default().Import(path)
+	}
+	f, err := parser.ParseFile(m.fset, "client.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	return (&types.Config{}).Check(path, m.fset, []*ast.File{f}, nil)
+}
+
+func TestUsesHTTPClient(t *testing.T) {
+	ssaPkg := buildSSA(t, "standin", standInSrc)
+	load := ssaPkg.Prog.FuncValue(ssaPkg.Pkg.Scope().Lookup("Client").Type().(*types.Named).Method(0))
+	assert.True(t, usesHTTPClient(load))
+	// the callee of the same package sends the request
+	assert.True(t, usesHTTPClient(ssaPkg.Func("Ping")))
+	assert.True(t, usesHTTPClient(ssaPkg.Func("Async")))
+	assert.False(t, usesHTTPClient(ssaPkg.Func("Build")))
+}
+
+func TestSetStandIns(t *testing.T) {
+	ssaPkg := buildSSA(t, "standin", standInSrc)
+	ssaFunctionMap := map[string]setup.Functions{}
+	funcs := make([]*models.Function, 0)
+	for _, name := range []string{"Ping", "Build"} {
+		funcs = append(funcs, &models.Function{Name: name})
+		ssaFunctionMap[name] = setup.Functions{TestFunction: &parsermodel.ProjectFunction{Function: ssaPkg.Func(name)}}
+	}
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{HTTPStandIn: true})
+	builder := setStandIns(ctx, funcs, ssaFunctionMap, atgconstant.UseMockitoMock)
+	assert.Equal(t, []string{`smartUnitCtx = contexthelper.SetStandIn(smartUnitCtx, "http", []string{"Ping"})`}, builder)
+	assert.True(t, funcs[0].HTTPStandIn)
+	assert.False(t, funcs[1].HTTPStandIn)
+
+	final := []*models.Function{{Name: "Ping"}, {Name: "Build"}}
+	getStandIns(contexthelper.SetStandIn(context.Background(), contexthelper.HTTPStandIn, []string{"Ping"}), final)
+	assert.True(t, final[0].HTTPStandIn)
+	assert.False(t, final[1].HTTPStandIn)
+}
+
+const sqlStandInSrc = `package standin
+
+import "database/sql"
+
+type Dao struct {
+	db *sql.DB
+}
+
+func (d *Dao) Count() int {
+	return 0
+}
+
+func Query(db *sql.DB, id int64) int {
+	return 0
+}
+
+func Plain(id int64) int {
+	return 0
+}
+`
+
+func TestUsesSQLDB(t *testing.T) {
+	ssaPkg := buildSSA(t, "standin", sqlStandInSrc)
+	count := ssaPkg.Prog.FuncValue(ssaPkg.Pkg.Scope().Lookup("Dao").Type().(*types.Named).Method(0))
+	assert.True(t, usesSQLDB(count))
+	assert.True(t, usesSQLDB(ssaPkg.Func("Query")))
+	assert.False(t, usesSQLDB(ssaPkg.Func("Plain")))
+}
+
+const redisStandInSrc = `package standin
+
+import "github.com/go-redis/redis/v8"
+
+type Cache struct {
+	client *redis.Client
+	pipe   redis.Pipeliner
+}
+
+func (c *Cache) Get(key string) string {
+	return ""
+}
+
+func Load(cmd redis.Cmdable, key string) string {
+	return ""
+}
+`
+
+func TestGetRedisClients(t *testing.T) {
+	ssaPkg := buildSSA(t, "standin", redisStandInSrc)
+	get := ssaPkg.Prog.FuncValue(ssaPkg.Pkg.Scope().Lookup("Cache").Type().(*types.Named).Method(0))
+	clients := getRedisClients(get)
+	assert.Equal(t, 1, len(clients))
+	assert.Contains(t, clients, "*github.com/go-redis/redis/v8.Client")
+	assert.Contains(t, getRedisClients(ssaPkg.Func("Load")), "github.com/go-redis/redis/v8.Cmdable")
+
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{RedisStandIn: true})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("standin")
+	assert.Equal(t, []string{`injector.SetBuilder(redis.NewClient(&redis.Options{Addr: "stand-in", Dialer: mockfunc.DialRedis}), "redis.NewClient(&redis.Options{Addr: \"stand-in\", Dialer: mockfunc.DialRedis})")`},
+		getRedisClientBuilder(ctx, get))
+	implementation := getRedisImplementations(ctx, ssaPkg.Func("Load"))["github.com/go-redis/redis/v8.Cmdable"]
+	assert.Equal(t, "redis.Cmdable", implementation.iface)
+	assert.Equal(t, `redis.NewClient(&redis.Options{Addr: "stand-in", Dialer: mockfunc.DialRedis})`, implementation.code)
+}
+
+const clockSrc = `package clock
+
+import "time"
+
+func Elapsed(start time.Time) int64 {
+	return int64(time.Since(start))
+}
+
+func Stamp() string {
+	return format(time.Time{})
+}
+
+func format(t time.Time) string {
+	return t.Format("15:04:05")
+}
+
+func Today() string {
+	return format(now())
+}
+
+func now() time.Time {
+	return time.Now()
+}
+`
+
+func TestUsesClock(t *testing.T) {
+	ssaPkg := buildSSA(t, "clock", clockSrc)
+	assert.True(t, usesClock(ssaPkg.Func("Elapsed")))
+	assert.True(t, usesClock(ssaPkg.Func("Today")))
+	// formatting the time does not read the clock
+	assert.False(t, usesClock(ssaPkg.Func("Stamp")))
+}
+
+const sandboxSrc = `package config
+
+import "os"
+
+func Load(name string) (string, error) {
+	if _, ok := os.LookupEnv("APP_ENV"); !ok {
+		return "", nil
+	}
+	data, err := os.ReadFile("./conf/app.yaml")
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Open("/etc/hosts"); err != nil {
+		return "", err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return port() + string(data), nil
+}
+
+func port() string {
+	return os.Getenv("APP_PORT")
+}
+
+func Save(name string) error {
+	return os.WriteFile(name, nil, 0644)
+}
+
+func Join(a, b string) string {
+	return a + b
+}
+`
+
+func TestGetSandboxUsage(t *testing.T) {
+	ssaPkg := buildSSA(t, "config", sandboxSrc)
+	usage := getSandboxUsage(ssaPkg.Func("Load"))
+	assert.True(t, usage.used)
+	assert.Equal(t, []string{"APP_ENV", "APP_PORT"}, usage.env)
+	// the absolute paths and the paths of the arguments are not generated
+	assert.Equal(t, []string{"conf/app.yaml"}, usage.files)
+	usage = getSandboxUsage(ssaPkg.Func("Save"))
+	assert.True(t, usage.used)
+	assert.Empty(t, usage.files)
+	assert.False(t, getSandboxUsage(ssaPkg.Func("Join")).used)
+}
+
+const globalsSrc = `package counter
+
+import "sync"
+
+type Level int
+
+var (
+	Count   int
+	level   Level
+	Names   []string
+	mu      sync.Mutex
+	Unused  string
+	factors = map[string]float64{}
+)
+
+func Incr() int {
+	mu.Lock()
+	defer mu.Unlock()
+	Count++
+	return Count * weight()
+}
+
+func weight() int {
+	if level > 1 {
+		return int(factors["x"])
+	}
+	return 1
+}
+
+func List() []string {
+	return Names
+}
+
+var scale = func(n int) int { return n }
+
+type Weigher interface {
+	Weigh(n int) int
+}
+
+var weigher Weigher
+
+func Scaled() int {
+	return weigher.Weigh(scale(Count))
+}
+`
+
+func TestGetGlobalVars(t *testing.T) {
+	ssaPkg := buildSSA(t, "example.com/counter", globalsSrc)
+	ctx := duplicatepackagemanager.SetInstance(context.Background())
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/counter")
+	assert.Equal(t, []atgconstant.GlobalVar{
+		{Code: "Count", Field: "GlobalCount", Type: "int"},
+		{Code: "factors"},
+		{Code: "level", Field: "GlobalLevel", Type: "Level"},
+	}, getGlobalVars(ctx, ssaPkg.Func("Incr"), nil))
+	assert.Equal(t, []atgconstant.GlobalVar{{Code: "Names"}}, getGlobalVars(ctx, ssaPkg.Func("List"), nil))
+
+	// the unexported variables of the other packages are not accessible
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/app")
+	globals := getGlobalVars(ctx, ssaPkg.Func("Incr"), nil)
+	assert.Equal(t, 1, len(globals))
+	assert.Equal(t, atgconstant.GlobalVar{Code: "counter.Count", PkgName: "counter", PkgPath: "example.com/counter", Field: "GlobalCounterCount", Type: "int"}, globals[0])
+
+	// the called variables are generated as the stubs, unless gomonkey replaces the function variables by the fakes
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("example.com/counter")
+	seams := getSeamGlobals(ctx, ssaPkg.Func("Scaled"), atgconstant.UseMockitoMock)
+	assert.Equal(t, map[string]bool{"&scale": true, "&weigher": true}, seams)
+	assert.Equal(t, []atgconstant.GlobalVar{
+		{Code: "Count", Field: "GlobalCount", Type: "int"},
+		{Code: "scale", Field: "GlobalScale", Type: "func(n int) int"},
+		{Code: "weigher", Field: "GlobalWeigher", Type: "Weigher"},
+	}, getGlobalVars(ctx, ssaPkg.Func("Scaled"), seams))
+	assert.Equal(t, map[string]bool{"&weigher": true}, getSeamGlobals(ctx, ssaPkg.Func("Scaled"), atgconstant.UseGoMonkeyMock))
+	assert.Equal(t, 3, len(getGlobalVars(ctx, ssaPkg.Func("Scaled"), nil)))
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright 2022 ByteDance Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package staticcase
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/codebuilder/unitestframwork/statement"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/bytedance/nxt_unit/smartunitvariablebuild"
+	"golang.org/x/tools/go/ssa"
+)
+
+// interfaceStub is the struct that the test file declares to implement an interface parameter.
+// The methods of the stub return its fields, so that each test case controls the returns.
+// With the interface mock, the methods also record their calls.
+type interfaceStub struct {
+	name  string // name of the stub struct
+	iface string // name of the interface in the test file
+	decl  string // declaration of the stub struct and its methods
+	mock  bool   // whether it is a mock which records the calls
+}
+
+// GetInterfaceStubDecls returns the declarations of the stubs for all the tested functions
+func GetInterfaceStubDecls(ctx context.Context) []string {
+	functionMap, _ := contexthelper.GetSetupFuncMap(ctx)
+	stubs := map[string]*interfaceStub{}
+	for _, functions := range functionMap {
+		implementations := getInterfaceImplementations(ctx, functions.TestFunction)
+		for key, stub := range getInterfaceStubs(ctx, functions.TestFunction.Function) {
+			// the real implementation takes the place of the stub
+			if _, exist := implementations[key]; !exist {
+				stubs[key] = stub
+			}
+		}
+	}
+	decls := make([]string, 0, len(stubs))
+	for _, stub := range sortInterfaceStubs(stubs) {
+		decls = append(decls, stub.decl)
+	}
+	return decls
+}
+
+// usesTypedMocks reports whether the inputs of the function hold the typed mocks, whose calls the final suite asserts
+func usesTypedMocks(ctx context.Context, function *parsermodel.ProjectFunction) bool {
+	if function == nil || !useInterfaceMock(ctx) {
+		return false
+	}
+	implementations := getInterfaceImplementations(ctx, function)
+	for key, stub := range getInterfaceStubs(ctx, function.Function) {
+		if _, exist := implementations[key]; !exist && stub.mock {
+			return true
+		}
+	}
+	return false
+}
+
+// getInterfaceStubs returns the stubs of the interface parameters, the interface fields called by the tested function
+// and the interfaces returned by their methods.
+// With the interface mock, all the interfaces called by the tested function are mocked, e.g. the fields of the receiver.
+// The key is the full name of the interface.
+func getInterfaceStubs(ctx context.Context, function *ssa.Function) map[string]*interfaceStub {
+	stubs := map[string]*interfaceStub{}
+	if function == nil {
+		return stubs
+	}
+	params := function.Signature.Params()
+	for i := 0; i < params.Len(); i++ {
+		buildInterfaceStub(ctx, params.At(i).Type(), stubs)
+	}
+	if useInterfaceMock(ctx) {
+		for _, dependency := range getInterfaceDependencies(function) {
+			buildInterfaceStub(ctx, dependency, stubs)
+		}
+		return stubs
+	}
+	// the interface fields called by the function are set to the stubs
+	for _, seam := range statement.CreateSeamStatements(ctx, function) {
+		if seam.SeamInterface != nil {
+			buildInterfaceStub(ctx, seam.SeamInterface, stubs)
+		}
+	}
+	return stubs
+}
+
+func useInterfaceMock(ctx context.Context) bool {
+	opt, _ := contexthelper.GetOption(ctx)
+	return opt.UseMockType == atgconstant.UseInterfaceMock
+}
+
+// getInterfaceDependencies returns the named interfaces whose methods are called by the function or its closures
+func getInterfaceDependencies(function *ssa.Function) []types.Type {
+	dependencies := make([]types.Type, 0)
+	visited := map[string]bool{}
+	// the closures are appended while we walk, so that their closures are visited too
+	functions := []*ssa.Function{function}
+	for i := 0; i < len(functions); i++ {
+		functions = append(functions, functions[i].AnonFuncs...)
+		for _, block := range functions[i].Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok || !call.Common().IsInvoke() {
+					continue
+				}
+				named, ok := call.Common().Value.Type().(*types.Named)
+				if !ok || visited[named.String()] {
+					continue
+				}
+				visited[named.String()] = true
+				dependencies = append(dependencies, named)
+			}
+		}
+	}
+	return dependencies
+}
+
+func sortInterfaceStubs(stubs map[string]*interfaceStub) []*interfaceStub {
+	res := make([]*interfaceStub, 0, len(stubs))
+	for _, stub := range stubs {
+		res = append(res, stub)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+	return res
+}
+
+func buildInterfaceStub(ctx context.Context, t types.Type, stubs map[string]*interfaceStub) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() == 0 {
+		return false
+	}
+	// error, context.Context, io.Reader and io.Writer have their own special values
+	if smartunitvariablebuild.SpecialVariableChecker(ctx, t) {
+		return false
+	}
+	if _, exist := stubs[named.String()]; exist {
+		return true
+	}
+	if !isTypeAccessible(ctx, named) {
+		return false
+	}
+	obj := named.Obj()
+	samePkg := obj.Pkg() == nil || obj.Pkg().Path() == duplicatepackagemanager.GetInstance(ctx).RelativePath()
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		// we cannot implement the unexported method of other package
+		if !samePkg && !method.Exported() {
+			return false
+		}
+		sig := method.Type().(*types.Signature)
+		for j := 0; j < sig.Params().Len(); j++ {
+			if !isTypeAccessible(ctx, sig.Params().At(j).Type()) {
+				return false
+			}
+		}
+		for j := 0; j < sig.Results().Len(); j++ {
+			if !isTypeAccessible(ctx, sig.Results().At(j).Type()) {
+				return false
+			}
+		}
+	}
+	qualifier := func(p *types.Package) string {
+		pkgName, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet(p.Name(), p.Path())
+		return pkgName
+	}
+	// the mock declares MockRecorder itself, so the interface must not have it
+	_, _, conflict := types.LookupFieldOrMethod(named, true, obj.Pkg(), "MockRecorder")
+	stub := &interfaceStub{
+		iface: types.TypeString(named, qualifier),
+		mock:  useInterfaceMock(ctx) && !conflict,
+	}
+	stub.name = interfaceStubName(ctx, obj, samePkg, stub.mock)
+	// record the stub first, because the interface might return itself
+	stubs[named.String()] = stub
+
+	fields := make([]string, 0)
+	methods := make([]string, 0)
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		sig := method.Type().(*types.Signature)
+		params := make([]string, 0, sig.Params().Len())
+		args := make([]string, 0, sig.Params().Len())
+		for j := 0; j < sig.Params().Len(); j++ {
+			paramType := sig.Params().At(j).Type()
+			// the mock records the arguments, so they need the names
+			paramName := ""
+			if stub.mock {
+				paramName = fmt.Sprintf("arg%d ", j)
+				args = append(args, fmt.Sprintf("arg%d", j))
+			}
+			if sig.Variadic() && j == sig.Params().Len()-1 {
+				params = append(params, paramName+"..."+types.TypeString(paramType.(*types.Slice).Elem(), qualifier))
+				continue
+			}
+			params = append(params, paramName+types.TypeString(paramType, qualifier))
+		}
+		results := make([]string, 0, sig.Results().Len())
+		returns := make([]string, 0, sig.Results().Len())
+		for j := 0; j < sig.Results().Len(); j++ {
+			resultType := sig.Results().At(j).Type()
+			fieldName := fmt.Sprintf("%sResult%d", strings.Title(method.Name()), j)
+			fields = append(fields, fmt.Sprintf("%s %s", fieldName, types.TypeString(resultType, qualifier)))
+			results = append(results, types.TypeString(resultType, qualifier))
+			returns = append(returns, "s."+fieldName)
+			buildInterfaceStub(ctx, resultType, stubs)
+		}
+		body := ""
+		if stub.mock {
+			body = fmt.Sprintf("s.recorder.Record(%s)\n", strings.Join(append([]string{fmt.Sprintf("%q", method.Name())}, args...), ", "))
+		}
+		if len(returns) > 0 {
+			body += fmt.Sprintf("return %s", strings.Join(returns, ", "))
+		}
+		methods = append(methods, fmt.Sprintf("func (s *%s) %s(%s) (%s) {\n%s\n}\n", stub.name, method.Name(),
+			strings.Join(params, ", "), strings.Join(results, ", "), body))
+	}
+	if stub.mock {
+		recorderPkg, _ := duplicatepackagemanager.GetInstance(ctx).PutAndGet("smartunitvariablebuild", "github.com/bytedance/nxt_unit/smartunitvariablebuild")
+		recorderType := recorderPkg + ".MockRecorder"
+		fields = append(fields, fmt.Sprintf("recorder %s", recorderType))
+		methods = append(methods, fmt.Sprintf("// MockRecorder returns the calls of the mock\nfunc (s *%s) MockRecorder() *%s {\nreturn &s.recorder\n}\n",
+			stub.name, recorderType))
+		stub.decl = fmt.Sprintf("// %s is the mock of %s. Its methods record the calls and return the fields of the same name.\ntype %s struct {\n%s\n}\n\n%s",
+			stub.name, stub.iface, stub.name, strings.Join(fields, "\n"), strings.Join(methods, "\n"))
+		return true
+	}
+	stub.decl = fmt.Sprintf("// %s is the stub of %s. Its methods return the fields of the same name.\ntype %s struct {\n%s\n}\n\n%s",
+		stub.name, stub.iface, stub.name, strings.Join(fields, "\n"), strings.Join(methods, "\n"))
+	return true
+}
+
+// The stub name contains the tested file name to avoid the conflict between the test files of the same package.
+// For example: StubDBInterfaceForInterface, or MockDBInterfaceForInterface for the interface mock
+func interfaceStubName(ctx context.Context, obj *types.TypeName, samePkg bool, mock bool) string {
+	prefix := "Stub"
+	if mock {
+		prefix = "Mock"
+	}
+	name := obj.Name()
+	if !samePkg {
+		name = strings.Title(obj.Pkg().Name()) + name
+	}
+	opt, _ := contexthelper.GetOption(ctx)
+	fileName := strings.TrimSuffix(filepath.Base(opt.FilePath), filepath.Ext(opt.FilePath))
+	fileName = strings.NewReplacer("-", "_", ".", "_").Replace(fileName)
+	if fileName == "" {
+		return prefix + name
+	}
+	return fmt.Sprintf("%s%sFor%s", prefix, name, strings.Title(fileName))
+}
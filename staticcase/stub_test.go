@@ -0,0 +1,164 @@
+package staticcase
+
+import (
+	"context"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/bytedance/nxt_unit/atgconstant"
+	"github.com/bytedance/nxt_unit/atghelper/contexthelper"
+	"github.com/bytedance/nxt_unit/codebuilder/setup/parsermodel"
+	"github.com/bytedance/nxt_unit/manager/duplicatepackagemanager"
+	"github.com/stretchr/testify/assert"
+)
+
+const stubSrc = `package stub
+
+type DBInterface interface {
+	Find(ctx map[string]int, ids ...int64) DBInterface
+	Where() DBInterface
+	Count() (int, error)
+	Close()
+}
+
+func Query(ctx map[string]int, db DBInterface, err error) error {
+	return nil
+}
+`
+
+func TestGetInterfaceStubs(t *testing.T) {
+	ssaPkg := buildSSA(t, "stub", stubSrc)
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{FilePath: "/tmp/stub.go"})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("stub")
+
+	stubs := getInterfaceStubs(ctx, ssaPkg.Func("Query"))
+	// error has its own special value
+	assert.Equal(t, 1, len(stubs))
+	stub := stubs["stub.DBInterface"]
+	assert.Equal(t, "StubDBInterfaceForStub", stub.name)
+	assert.Equal(t, "DBInterface", stub.iface)
+	assert.True(t, strings.Contains(stub.decl, "func (s *StubDBInterfaceForStub) Find(map[string]int, ...int64) (DBInterface)"))
+
+	// the stub must implement the interface
+	code := stubSrc + stub.decl + "\nvar _ DBInterface = &StubDBInterfaceForStub{}\n"
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "stub.go", code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&types.Config{Importer: importer.Default()}).Check("stub", fset, []*ast.File{f}, nil)
+	assert.Nil(t, err)
+}
+
+const mockSrc = `package stub
+
+type Store interface {
+	Get(key string, opts ...int) (string, error)
+}
+
+type Service struct {
+	Store Store
+}
+
+func (s *Service) Load(key string) string {
+	load := func() string {
+		v, _ := s.Store.Get(key)
+		return v
+	}
+	return load()
+}
+`
+
+// mockRecorderSrc declares the part of smartunitvariablebuild that the mocks use
+const mockRecorderSrc = `package smartunitvariablebuild
+
+type MockRecorder struct{}
+
+func (r *MockRecorder) Record(method string, args ...interface{}) {}
+`
+
+func TestGetInterfaceMocks(t *testing.T) {
+	ssaPkg := buildSSA(t, "stub", mockSrc)
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{FilePath: "/tmp/stub.go", UseMockType: atgconstant.UseInterfaceMock})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("stub")
+
+	load := ssaPkg.Prog.LookupMethod(types.NewPointer(ssaPkg.Type("Service").Type()), ssaPkg.Pkg, "Load")
+	// the receiver field is called in the closure
+	stubs := getInterfaceStubs(ctx, load)
+	assert.Equal(t, 1, len(stubs))
+	mock := stubs["stub.Store"]
+	assert.True(t, mock.mock)
+	assert.Equal(t, "MockStoreForStub", mock.name)
+	assert.True(t, strings.Contains(mock.decl, "func (s *MockStoreForStub) Get(arg0 string, arg1 ...int) (string, error)"))
+	assert.True(t, strings.Contains(mock.decl, `s.recorder.Record("Get", arg0, arg1)`))
+	// the final suite asserts the calls of the mock
+	assert.True(t, usesTypedMocks(ctx, &parsermodel.ProjectFunction{Function: load}))
+
+	// the mock must implement the interface
+	code := strings.Replace(mockSrc, "package stub\n", "package stub\n\nimport \"github.com/bytedance/nxt_unit/smartunitvariablebuild\"\n", 1)
+	code += mock.decl + "\nvar _ Store = &MockStoreForStub{}\n"
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "stub.go", code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&types.Config{Importer: standInImporter{fset: fset}}).Check("stub", fset, []*ast.File{f}, nil)
+	assert.Nil(t, err)
+
+	// without the interface mock, only the parameters are stubbed
+	ctx = contexthelper.SetOption(ctx, atgconstant.Options{FilePath: "/tmp/stub.go"})
+	assert.Equal(t, 0, len(getInterfaceStubs(ctx, load)))
+	assert.False(t, usesTypedMocks(ctx, &parsermodel.ProjectFunction{Function: load}))
+}
+
+const seamSrc = `package stub
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type Service struct {
+	store Store
+}
+
+func (s *Service) Load(key string) string {
+	v, _ := s.store.Get(key)
+	return v
+}
+`
+
+func TestGetInterfaceStubsForFieldSeams(t *testing.T) {
+	ssaPkg := buildSSA(t, "stub", seamSrc)
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{FilePath: "/tmp/stub.go", UseMockType: atgconstant.UseGoMonkeyMock})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("stub")
+
+	load := ssaPkg.Prog.LookupMethod(types.NewPointer(ssaPkg.Type("Service").Type()), ssaPkg.Pkg, "Load")
+	stubs := getInterfaceStubs(ctx, load)
+	// the interface field is set to the stub instead of being patched
+	assert.Equal(t, 1, len(stubs))
+	assert.Equal(t, "StubStoreForStub", stubs["stub.Store"].name)
+	assert.False(t, stubs["stub.Store"].mock)
+}
+
+func TestGetInterfaceStubsWithoutMocks(t *testing.T) {
+	ssaPkg := buildSSA(t, "stub", seamSrc)
+	ctx := contexthelper.SetOption(context.Background(), atgconstant.Options{FilePath: "/tmp/stub.go", UseMockType: atgconstant.UseNoMock})
+	ctx = duplicatepackagemanager.SetInstance(ctx)
+	duplicatepackagemanager.GetInstance(ctx).SetRelativeString("stub")
+
+	load := ssaPkg.Prog.LookupMethod(types.NewPointer(ssaPkg.Type("Service").Type()), ssaPkg.Pkg, "Load")
+	// without the mocks the interface field gets the plain stub, whose calls are not recorded
+	stubs := getInterfaceStubs(ctx, load)
+	assert.Equal(t, 1, len(stubs))
+	assert.Equal(t, "StubStoreForStub", stubs["stub.Store"].name)
+	assert.False(t, stubs["stub.Store"].mock)
+	assert.False(t, usesTypedMocks(ctx, &parsermodel.ProjectFunction{Function: load}))
+}
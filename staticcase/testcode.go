@@ -54,6 +54,7 @@ type Options struct {
 	ExistTestImport []*instrumentation.Import // Imports from exist test file for template
 	UseMockType     int                       // whether to user mock pkg in cases
 	UseMockMap      map[string]map[string]int // Data for function for mock
+	Stubs           []string                  // declarations of the interface stubs
 }
 
 // A GeneratedTest contains information about a test file with generated tests.
@@ -76,6 +77,7 @@ func RecordFinalSuite(ctx context.Context, filePath string, funcData map[string]
 	opt.UseMockMap = useMockMap
 	opt.Builder = duplicatepackagemanager.GetInstance(ctx).GetInitBuilder()
 	opt.MiddleBuilder = duplicatepackagemanager.GetInstance(ctx).GetMiddleBuilder()
+	opt.Stubs = duplicatepackagemanager.GetInstance(ctx).GetStubBuilder()
 	duplicatepackagemanager.SetInstance(ctx)
 	// duplicatepackagemanager.GetInstance(ctx).SetRelativePath(funcData)
 	files, err := input.Files(path.Dir(filePath))
@@ -239,12 +241,138 @@ func generateTest(src models.Path, files []models.Path, opt *Options) (*Generate
 	builder := map[string][]string{}
 	initBuilder := make([]string, 0)
 	middleCodeBuilder := make([]string, 0)
+	stubs := opt.Stubs
+	parallel := opt.Parallel
 	// Create the mock statement and also record the package
 	switch opt.TestMode {
 	case atgconstant.MiddleCode:
 		mocks = GetAllMock(opt.Ctx, opt.UseMockType)
 		builder = GetSpecialValueBuilder(opt.Ctx)
+		stubs = GetInterfaceStubDecls(opt.Ctx)
 		initBuilder, middleCodeBuilder = GetGlobalValueBuilder(opt.Ctx)
+		if option, ok := contexthelper.GetOption(opt.Ctx); ok && (option.ReferenceTime != 0 || option.Locale != "") {
+			fakerName, _ := duplicatepackagemanager.GetInstance(opt.Ctx).PutAndGet("faker", "github.com/bytedance/nxt_unit/faker")
+			if option.ReferenceTime != 0 {
+				timeName, _ := duplicatepackagemanager.GetInstance(opt.Ctx).PutAndGet("", "time")
+				initBuilder = append(initBuilder, fmt.Sprintf("%s.SetReferenceTime(%s.Unix(%d, 0).UTC())", fakerName, timeName, option.ReferenceTime))
+			}
+			if option.Locale != "" {
+				initBuilder = append(initBuilder, fmt.Sprintf("%s.SetLocale(%q)", fakerName, option.Locale))
+			}
+			h.Imports = append(h.Imports, &models.Import{Name: fakerName, Path: "\"github.com/bytedance/nxt_unit/faker\""})
+		}
+		if opt.UseMockType == atgconstant.UseGoMonkeyMock || opt.UseMockType == atgconstant.UseInterfaceMock {
+			// the final suite is rendered by the middle code, so the middle code keeps the matcher in its context
+			option, _ := contexthelper.GetOption(opt.Ctx)
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetMockCallMatcher(smartUnitCtx, %#v)", GetMockCallMatcher(option)))
+		}
+		if opt.UseMockType == atgconstant.UseInterfaceMock {
+			typedMocks := make([]string, 0)
+			for _, fun := range funcs {
+				ssaFunctionInfo, exist := ssaFunctionMap[fun.FullName()]
+				if exist && usesTypedMocks(opt.Ctx, ssaFunctionInfo.TestFunction) {
+					fun.TypedMocks = true
+					typedMocks = append(typedMocks, fun.FullName())
+				}
+			}
+			sort.Strings(typedMocks)
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetTypedMocks(smartUnitCtx, %#v)", typedMocks))
+		}
+		// the mocks return what the mocked functions are able to return
+		if shapes := GetReturnShapes(opt.Ctx, opt.UseMockType); len(shapes) != 0 {
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetReturnShapes(smartUnitCtx, %s)", returnShapesCode(shapes)))
+		}
+		initBuilder = append(initBuilder, setStandIns(opt.Ctx, funcs, ssaFunctionMap, opt.UseMockType)...)
+		// the middle code runs the functions at the same time, so the others wait for the sandboxes
+		sandboxed := false
+		for _, fun := range funcs {
+			sandboxed = sandboxed || fun.Sandbox
+		}
+		for _, fun := range funcs {
+			fun.SandboxedFile = sandboxed
+		}
+		// the called package variables which are not faked are the inputs, which are restored like the snapshots
+		if option, ok := contexthelper.GetOption(opt.Ctx); ok {
+			snapshots := map[string][]atgconstant.GlobalVar{}
+			for _, fun := range funcs {
+				ssaFunctionInfo, exist := ssaFunctionMap[fun.FullName()]
+				if !exist {
+					continue
+				}
+				seams := getSeamGlobals(opt.Ctx, ssaFunctionInfo.TestFunction.Function, opt.UseMockType)
+				globals := getGlobalVars(opt.Ctx, ssaFunctionInfo.TestFunction.Function, seams)
+				if !option.SnapshotGlobals {
+					filtered := make([]atgconstant.GlobalVar, 0, len(seams))
+					for _, global := range globals {
+						if seams["&"+global.Code] && global.Field != "" {
+							filtered = append(filtered, global)
+						}
+					}
+					globals = filtered
+				}
+				if len(globals) != 0 {
+					fun.Globals = globals
+					snapshots[fun.FullName()] = globals
+				}
+			}
+			if option.SnapshotGlobals || len(snapshots) != 0 {
+				initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetGlobalSnapshot(smartUnitCtx, %#v)", snapshots))
+			}
+		}
+		if option, ok := contexthelper.GetOption(opt.Ctx); ok && len(option.ErrorAssertion) != 0 {
+			matches := make([]string, 0)
+			for _, fun := range funcs {
+				ssaFunctionInfo, exist := ssaFunctionMap[fun.FullName()]
+				if !exist || !fun.ReturnsError {
+					continue
+				}
+				fun.ErrorMatch = true
+				fun.ErrorMatcher = getErrorMatcher(opt.Ctx, ssaFunctionInfo.TestFunction, option.ErrorAssertion)
+				matches = append(matches, fun.FullName())
+			}
+			sort.Strings(matches)
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetErrorMatch(smartUnitCtx, %#v)", matches))
+		}
+		if option, ok := contexthelper.GetOption(opt.Ctx); ok && option.Reruns > 0 {
+			unstable := make([]string, 0)
+			for _, fun := range funcs {
+				// the stand-ins and the package variables keep what the first run changed, so the runs differ
+				if len(fun.TestResults()) == 0 || fun.HTTPStandIn || fun.SQLStandIn || fun.RedisStandIn || fun.Sandbox || len(fun.Globals) != 0 {
+					continue
+				}
+				fun.Unstable, fun.Reruns = true, option.Reruns
+				unstable = append(unstable, fun.FullName())
+			}
+			sort.Strings(unstable)
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetUnstable(smartUnitCtx, %#v)", unstable))
+		}
+		if option, ok := contexthelper.GetOption(opt.Ctx); ok && option.PanicTests != "" {
+			for _, fun := range funcs {
+				fun.PanicTests = option.PanicTests
+			}
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetPanicTests(smartUnitCtx, %q)", option.PanicTests))
+		}
+		if option, ok := contexthelper.GetOption(opt.Ctx); ok && option.FaultInjection {
+			for _, fun := range funcs {
+				// the fault re-runs a case with its stand-ins, which keep what the first run did, e.g. the sql expects
+				if fun.HTTPStandIn || fun.SQLStandIn || fun.RedisStandIn || fun.Sandbox || len(fun.Globals) != 0 {
+					continue
+				}
+				if ssaFunctionInfo, exist := ssaFunctionMap[fun.FullName()]; exist {
+					fun.Faults = getFaults(opt.Ctx, ssaFunctionInfo.TestFunction, opt.UseMockType)
+				}
+			}
+		}
+		if opt.UseMockType == atgconstant.UseNoMock {
+			report := GetIsolationReport(opt.Ctx)
+			for _, fun := range funcs {
+				fun.NotIsolated = report[fun.FullName()]
+				for _, reason := range fun.NotIsolated {
+					logextractor.ExecutionLog.Log(fmt.Sprintf("%s is not isolated: %s", fun.FullName(), reason))
+				}
+			}
+			initBuilder = append(initBuilder, fmt.Sprintf("smartUnitCtx = contexthelper.SetNotIsolated(smartUnitCtx, %#v)", report))
+		}
 		// picks := PickStructField(opt.Ctx)
 		// initBuilder = append(initBuilder, picks...)
 	case atgconstant.BaseTest:
@@ -254,12 +382,56 @@ func generateTest(src models.Path, files []models.Path, opt *Options) (*Generate
 		if opt.UseMockMap != nil && len(opt.UseMockMap) != 0 {
 			mocks = opt.UseMockMap
 		}
+		getStandIns(opt.Ctx, funcs)
+		if typedMocks, ok := contexthelper.GetTypedMocks(opt.Ctx); ok {
+			for _, fun := range funcs {
+				fun.TypedMocks = contains(typedMocks, fun.FullName())
+			}
+		}
+		if _, ok := contexthelper.GetStandIn(opt.Ctx, contexthelper.SQLStandIn); ok {
+			duplicatepackagemanager.GetInstance(opt.Ctx).PutAndGet("smartunitvariablebuild", "github.com/bytedance/nxt_unit/smartunitvariablebuild")
+		}
+		// the sandbox changes the environment and the working directory of the process, so the cases of the
+		// file take turns
+		for _, fun := range funcs {
+			if fun.Sandbox {
+				parallel = false
+			}
+		}
+		if matches, ok := contexthelper.GetErrorMatch(opt.Ctx); ok {
+			for _, fun := range funcs {
+				fun.ErrorMatch = contains(matches, fun.FullName())
+			}
+		}
+		if unstable, ok := contexthelper.GetUnstable(opt.Ctx); ok {
+			for _, fun := range funcs {
+				fun.Unstable = contains(unstable, fun.FullName())
+			}
+		}
+		if mode, ok := contexthelper.GetPanicTests(opt.Ctx); ok {
+			for _, fun := range funcs {
+				fun.PanicTests = mode
+			}
+		}
+		if report, ok := contexthelper.GetNotIsolated(opt.Ctx); ok {
+			for _, fun := range funcs {
+				fun.NotIsolated = report[fun.FullName()]
+			}
+		}
+		if snapshots, ok := contexthelper.GetGlobalSnapshot(opt.Ctx); ok {
+			for _, fun := range funcs {
+				fun.Globals = snapshots[fun.FullName()]
+				for _, global := range fun.Globals {
+					duplicatepackagemanager.GetInstance(opt.Ctx).PutAndGet(global.PkgName, global.PkgPath)
+				}
+			}
+		}
 	}
 
 	options := output.Options{
 		PrintInputs:    opt.PrintInputs,
 		Subtests:       opt.Subtests,
-		Parallel:       opt.Parallel,
+		Parallel:       parallel,
 		Named:          opt.Named,
 		Template:       opt.Template,
 		TemplateDir:    opt.TemplateDir,
@@ -269,6 +441,7 @@ func generateTest(src models.Path, files []models.Path, opt *Options) (*Generate
 		Builders:       builder,
 		GlobalInit:     initBuilder,
 		MiddleCodeInit: middleCodeBuilder,
+		Stubs:          stubs,
 		TestCaseNum:    opt.TestCaseNum,
 		FilePath:       opt.FilePath,
 		TestMode:       opt.TestMode,